@@ -0,0 +1,508 @@
+package adapter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// adfPanelLabels는 panel 노드의 panelType별 머리말이다.
+var adfPanelLabels = map[string]string{
+	"info":    "ℹ️ 정보",
+	"note":    "📝 참고",
+	"warning": "⚠️ 경고",
+	"success": "✅ 성공",
+	"error":   "❌ 오류",
+	"tip":     "💡 팁",
+}
+
+// adfToMarkdown은 Atlassian Document Format 문서를 Markdown으로 변환한다.
+// 첨부 미디어는 {{MEDIA:파일명}} 또는 {{MEDIA_ID:id}} 플레이스홀더로 남겨
+// MarkdownGenerator가 실제 이미지/프레임 경로로 치환할 수 있게 한다.
+func adfToMarkdown(adf interface{}) string {
+	if adf == nil {
+		return ""
+	}
+
+	switch v := adf.(type) {
+	case string:
+		// API v2 또는 일반 텍스트 필드
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		if adfType(v) == "doc" {
+			return strings.TrimSpace(renderADFBlocks(adfChildren(v), "\n\n"))
+		}
+		return strings.TrimSpace(renderADFBlock(v))
+	default:
+		return fmt.Sprintf("%v", adf)
+	}
+}
+
+// adfType은 노드의 type 값을 반환한다.
+func adfType(node map[string]interface{}) string {
+	t, _ := node["type"].(string)
+	return t
+}
+
+// adfChildren은 노드의 content 배열을 반환한다.
+func adfChildren(node map[string]interface{}) []interface{} {
+	content, _ := node["content"].([]interface{})
+	return content
+}
+
+// adfAttr은 노드 attrs의 문자열 값을 반환한다 (숫자는 문자열로 변환).
+func adfAttr(node map[string]interface{}, key string) string {
+	attrs, _ := node["attrs"].(map[string]interface{})
+	switch v := attrs[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// renderADFBlocks는 블록 노드 목록을 변환하여 sep으로 연결한다. 빈 결과는 건너뛴다.
+func renderADFBlocks(nodes []interface{}, sep string) string {
+	var parts []string
+	for _, child := range nodes {
+		node, ok := child.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if rendered := renderADFBlock(node); strings.TrimSpace(rendered) != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// renderADFBlock은 단일 블록 노드를 Markdown으로 변환한다.
+func renderADFBlock(node map[string]interface{}) string {
+	switch adfType(node) {
+	case "paragraph":
+		return renderADFInline(adfChildren(node))
+
+	case "heading":
+		level, _ := strconv.Atoi(adfAttr(node, "level"))
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(renderADFInline(adfChildren(node)))
+
+	case "bulletList", "orderedList":
+		return renderADFList(node)
+
+	case "taskList", "decisionList":
+		return renderADFTaskList(node)
+
+	case "codeBlock":
+		return renderADFCodeBlock(node)
+
+	case "blockquote":
+		return prefixMarkdownLines(renderADFBlocks(adfChildren(node), "\n\n"), "> ")
+
+	case "rule":
+		return "---"
+
+	case "panel":
+		label, ok := adfPanelLabels[adfAttr(node, "panelType")]
+		if !ok {
+			label = "📌 패널"
+		}
+		body := "**" + label + "**\n\n" + renderADFBlocks(adfChildren(node), "\n\n")
+		return prefixMarkdownLines(body, "> ")
+
+	case "expand", "nestedExpand":
+		title := adfAttr(node, "title")
+		if title == "" {
+			title = "펼치기"
+		}
+		return "**▶ " + title + "**\n\n" + renderADFBlocks(adfChildren(node), "\n\n")
+
+	case "table":
+		return renderADFTable(node)
+
+	case "mediaSingle", "mediaGroup":
+		var parts []string
+		for _, child := range adfChildren(node) {
+			childNode, ok := child.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch adfType(childNode) {
+			case "media":
+				if placeholder := adfMediaPlaceholder(childNode); placeholder != "" {
+					parts = append(parts, placeholder)
+				}
+			case "caption":
+				if caption := strings.TrimSpace(renderADFInline(adfChildren(childNode))); caption != "" {
+					parts = append(parts, "*"+caption+"*")
+				}
+			}
+		}
+		return strings.Join(parts, "\n")
+
+	case "media":
+		return adfMediaPlaceholder(node)
+
+	case "blockCard", "embedCard":
+		if url := adfAttr(node, "url"); url != "" {
+			return fmt.Sprintf("[%s](%s)", url, url)
+		}
+		return ""
+
+	case "layoutSection", "layoutColumn", "bodiedExtension", "multiBodiedExtension", "extensionFrame":
+		return renderADFBlocks(adfChildren(node), "\n\n")
+
+	case "extension":
+		return ""
+	}
+
+	// 알 수 없는 노드: 하위 노드가 블록이면 블록으로, 아니면 인라인으로 변환한다.
+	children := adfChildren(node)
+	if len(children) > 0 && adfContainsBlock(children) {
+		return renderADFBlocks(children, "\n\n")
+	}
+	return renderADFInline([]interface{}{node})
+}
+
+// adfBlockTypes는 인라인이 아닌 블록 노드 타입 집합이다.
+var adfBlockTypes = map[string]bool{
+	"paragraph": true, "heading": true, "bulletList": true, "orderedList": true, "taskList": true,
+	"decisionList": true, "codeBlock": true, "blockquote": true, "rule": true, "panel": true,
+	"expand": true, "nestedExpand": true, "table": true, "mediaSingle": true, "mediaGroup": true,
+	"blockCard": true, "embedCard": true, "layoutSection": true, "bodiedExtension": true,
+}
+
+// adfContainsBlock은 노드 목록에 블록 노드가 포함되어 있는지 확인한다.
+func adfContainsBlock(nodes []interface{}) bool {
+	for _, child := range nodes {
+		if node, ok := child.(map[string]interface{}); ok && adfBlockTypes[adfType(node)] {
+			return true
+		}
+	}
+	return false
+}
+
+// adfMediaPlaceholder는 media 노드를 파일명 또는 ID 기반 플레이스홀더로 변환한다.
+func adfMediaPlaceholder(node map[string]interface{}) string {
+	if filename := adfAttr(node, "alt"); filename != "" {
+		return fmt.Sprintf("{{MEDIA:%s}}", filename)
+	}
+	if id := adfAttr(node, "id"); id != "" {
+		return fmt.Sprintf("{{MEDIA_ID:%s}}", id)
+	}
+	if url := adfAttr(node, "url"); url != "" {
+		return fmt.Sprintf("![](%s)", url)
+	}
+	return ""
+}
+
+// renderADFList는 글머리표/번호 목록을 변환한다. 항목의 하위 블록은 마커 폭만큼 들여쓴다.
+func renderADFList(node map[string]interface{}) string {
+	ordered := adfType(node) == "orderedList"
+	order := 1
+	if start, err := strconv.Atoi(adfAttr(node, "order")); err == nil && start > 0 {
+		order = start
+	}
+
+	var items []string
+	for _, child := range adfChildren(node) {
+		item, ok := child.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", order)
+			order++
+		}
+		body := renderADFBlocks(adfChildren(item), "\n")
+		items = append(items, marker+indentMarkdownContinuation(body, len(marker)))
+	}
+	return strings.Join(items, "\n")
+}
+
+// renderADFTaskList는 작업/결정 목록을 체크박스 목록으로 변환한다.
+func renderADFTaskList(node map[string]interface{}) string {
+	var items []string
+	for _, child := range adfChildren(node) {
+		item, ok := child.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch adfType(item) {
+		case "taskItem":
+			box := "[ ]"
+			if adfAttr(item, "state") == "DONE" {
+				box = "[x]"
+			}
+			items = append(items, "- "+box+" "+strings.TrimSpace(renderADFInline(adfChildren(item))))
+		case "decisionItem":
+			items = append(items, "- **결정**: "+strings.TrimSpace(renderADFInline(adfChildren(item))))
+		case "taskList", "decisionList":
+			// 중첩 목록은 부모 항목 아래로 들여쓴다.
+			items = append(items, prefixMarkdownLines(renderADFTaskList(item), "  "))
+		}
+	}
+	return strings.Join(items, "\n")
+}
+
+// renderADFCodeBlock은 코드 블록을 펜스 코드로 변환한다. 본문에 ```가 있으면 더 긴 펜스를 쓴다.
+func renderADFCodeBlock(node map[string]interface{}) string {
+	var code strings.Builder
+	for _, child := range adfChildren(node) {
+		if textNode, ok := child.(map[string]interface{}); ok {
+			text, _ := textNode["text"].(string)
+			code.WriteString(text)
+		}
+	}
+	body := strings.TrimRight(code.String(), "\n")
+
+	fence := "```"
+	for strings.Contains(body, fence) {
+		fence += "`"
+	}
+	return fence + adfAttr(node, "language") + "\n" + body + "\n" + fence
+}
+
+// renderADFTable은 표를 GFM 표로 변환한다. 첫 행을 머리글로 사용하고 열 수를 맞춘다.
+func renderADFTable(node map[string]interface{}) string {
+	var rows [][]string
+	columns := 0
+	for _, child := range adfChildren(node) {
+		rowNode, ok := child.(map[string]interface{})
+		if !ok || adfType(rowNode) != "tableRow" {
+			continue
+		}
+		var row []string
+		for _, cellChild := range adfChildren(rowNode) {
+			cell, ok := cellChild.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			row = append(row, renderADFTableCell(cell))
+			// 병합된 셀은 빈 셀로 채워 열 위치를 유지한다.
+			if span, err := strconv.Atoi(adfAttr(cell, "colspan")); err == nil {
+				for i := 1; i < span; i++ {
+					row = append(row, "")
+				}
+			}
+		}
+		if len(row) > columns {
+			columns = len(row)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 || columns == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// renderADFTableCell은 셀 내용을 한 줄로 변환한다 (줄바꿈은 <br>, 파이프는 이스케이프).
+func renderADFTableCell(cell map[string]interface{}) string {
+	content := renderADFBlocks(adfChildren(cell), "\n")
+	content = strings.ReplaceAll(content, "|", "\\|")
+	lines := strings.Split(content, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// renderADFInline은 인라인 노드 목록을 변환한다. 같은 mark를 가진 연속 텍스트는 합쳐서 처리한다.
+func renderADFInline(nodes []interface{}) string {
+	var sb strings.Builder
+	var pendingText strings.Builder
+	var pendingMarks []interface{}
+	hasPending := false
+
+	flush := func() {
+		if hasPending {
+			sb.WriteString(applyADFMarks(pendingText.String(), pendingMarks))
+			pendingText.Reset()
+			pendingMarks = nil
+			hasPending = false
+		}
+	}
+
+	for _, child := range nodes {
+		node, ok := child.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if adfType(node) == "text" {
+			text, _ := node["text"].(string)
+			marks, _ := node["marks"].([]interface{})
+			if hasPending && fmt.Sprint(marks) != fmt.Sprint(pendingMarks) {
+				flush()
+			}
+			pendingText.WriteString(text)
+			pendingMarks = marks
+			hasPending = true
+			continue
+		}
+
+		flush()
+		sb.WriteString(renderADFInlineNode(node))
+	}
+	flush()
+
+	return sb.String()
+}
+
+// renderADFInlineNode는 텍스트가 아닌 인라인 노드를 변환한다.
+func renderADFInlineNode(node map[string]interface{}) string {
+	switch adfType(node) {
+	case "hardBreak":
+		return "\n"
+
+	case "mention":
+		text := adfAttr(node, "text")
+		if text == "" {
+			text = adfAttr(node, "id")
+		}
+		if !strings.HasPrefix(text, "@") {
+			text = "@" + text
+		}
+		return text
+
+	case "emoji":
+		if text := adfAttr(node, "text"); text != "" {
+			return text
+		}
+		return adfAttr(node, "shortName")
+
+	case "inlineCard":
+		if url := adfAttr(node, "url"); url != "" {
+			return fmt.Sprintf("[%s](%s)", url, url)
+		}
+		return ""
+
+	case "date":
+		if ms, err := strconv.ParseInt(adfAttr(node, "timestamp"), 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC().Format("2006-01-02")
+		}
+		return adfAttr(node, "timestamp")
+
+	case "status":
+		return "[" + strings.ToUpper(adfAttr(node, "text")) + "]"
+
+	case "mediaInline":
+		return adfMediaPlaceholder(node)
+
+	case "placeholder":
+		return adfAttr(node, "text")
+
+	case "inlineExtension":
+		return ""
+	}
+
+	if text, ok := node["text"].(string); ok {
+		return text
+	}
+	return renderADFInline(adfChildren(node))
+}
+
+// applyADFMarks는 텍스트에 mark를 Markdown 서식으로 적용한다.
+// 앞뒤 공백은 서식 밖으로 빼서 "**굵게 **"처럼 깨진 강조가 생기지 않게 한다.
+func applyADFMarks(text string, marks []interface{}) string {
+	if len(marks) == 0 || strings.TrimSpace(text) == "" {
+		return text
+	}
+
+	trimmed := strings.TrimSpace(text)
+	start := strings.Index(text, trimmed)
+	leading, trailing := text[:start], text[start+len(trimmed):]
+
+	var href string
+	formatted := trimmed
+	markTypes := make(map[string]map[string]interface{})
+	for _, m := range marks {
+		mark, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		markTypes[adfType(mark)] = mark
+	}
+
+	if _, ok := markTypes["code"]; ok {
+		fence := "`"
+		for strings.Contains(formatted, fence) {
+			fence += "`"
+		}
+		if len(fence) > 1 {
+			formatted = fence + " " + formatted + " " + fence
+		} else {
+			formatted = fence + formatted + fence
+		}
+	}
+	if _, ok := markTypes["strike"]; ok {
+		formatted = "~~" + formatted + "~~"
+	}
+	if _, ok := markTypes["em"]; ok {
+		formatted = "*" + formatted + "*"
+	}
+	if _, ok := markTypes["strong"]; ok {
+		formatted = "**" + formatted + "**"
+	}
+	if _, ok := markTypes["underline"]; ok {
+		formatted = "<u>" + formatted + "</u>"
+	}
+	if mark, ok := markTypes["subsup"]; ok {
+		tag := adfAttr(mark, "type")
+		if tag == "sub" || tag == "sup" {
+			formatted = "<" + tag + ">" + formatted + "</" + tag + ">"
+		}
+	}
+	if mark, ok := markTypes["link"]; ok {
+		href = adfAttr(mark, "href")
+		if href != "" {
+			formatted = "[" + formatted + "](" + href + ")"
+		}
+	}
+
+	return leading + formatted + trailing
+}
+
+// prefixMarkdownLines는 모든 줄 앞에 prefix를 붙인다 (빈 줄은 공백 없이 prefix만).
+func prefixMarkdownLines(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentMarkdownContinuation은 첫 줄을 제외한 줄을 width칸 들여쓴다.
+func indentMarkdownContinuation(text string, width int) string {
+	lines := strings.Split(text, "\n")
+	indent := strings.Repeat(" ", width)
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
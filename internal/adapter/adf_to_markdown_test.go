@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "testdata의 golden 파일을 현재 출력으로 갱신한다")
+
+// TestADFToMarkdown_Golden은 testdata/adf/*.json 샘플을 변환하여 같은 이름의 .md 파일과 비교한다.
+// 출력이 의도적으로 바뀐 경우 `go test ./internal/adapter -run Golden -update`로 갱신한다.
+func TestADFToMarkdown_Golden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "adf", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list samples: %v", err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no ADF samples found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatalf("failed to read sample: %v", err)
+			}
+			var adf interface{}
+			if err := json.Unmarshal(data, &adf); err != nil {
+				t.Fatalf("failed to parse sample: %v", err)
+			}
+
+			got := adfToMarkdown(adf) + "\n"
+			goldenPath := strings.TrimSuffix(input, ".json") + ".md"
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+					t.Fatalf("failed to update golden: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("markdown mismatch for %s\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+			}
+		})
+	}
+}
+
+func TestADFToMarkdown_NonDocInputs(t *testing.T) {
+	if got := adfToMarkdown(nil); got != "" {
+		t.Errorf("expected empty string for nil, got %q", got)
+	}
+	if got := adfToMarkdown("  plain v2 text \n"); got != "plain v2 text" {
+		t.Errorf("expected trimmed plain text, got %q", got)
+	}
+}
+
+// TestADFToMarkdown_MediaPlaceholdersSurviveReformat은 변환 결과가 reformatDescription을 거쳐도
+// 미디어 플레이스홀더와 코드 블록 들여쓰기가 유지되는지 확인한다.
+func TestADFToMarkdown_MediaPlaceholdersSurviveReformat(t *testing.T) {
+	adf := map[string]interface{}{
+		"type": "doc",
+		"content": []interface{}{
+			map[string]interface{}{"type": "codeBlock", "content": []interface{}{
+				map[string]interface{}{"type": "text", "text": "func main() {\n\n\n    panic(1)\n}"},
+			}},
+			map[string]interface{}{"type": "mediaSingle", "content": []interface{}{
+				map[string]interface{}{"type": "media", "attrs": map[string]interface{}{"id": "x1", "alt": "shot.png"}},
+			}},
+		},
+	}
+
+	got := reformatDescription(adfToMarkdown(adf))
+
+	if !strings.Contains(got, "func main() {\n\n\n    panic(1)\n}") {
+		t.Errorf("expected code block preserved verbatim, got %q", got)
+	}
+	if !strings.Contains(got, "{{MEDIA:shot.png}}") {
+		t.Errorf("expected media placeholder, got %q", got)
+	}
+}
+
+func TestReformatDescription_KeepsLongerFenceOpen(t *testing.T) {
+	input := "before\n\n\n\n````\n```\n\n\n\ninner\n````\nafter"
+
+	got := reformatDescription(input)
+
+	want := "before\n\n````\n```\n\n\n\ninner\n````\nafter"
+	if got != want {
+		t.Errorf("unexpected result:\n got: %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,415 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+)
+
+// RunStatus is the JSON sidecar ("<base>_status.json") describing one AI agent CLI run.
+// 프로세스를 시작하면 한 번, 종료 후 결과 파일까지 조립한 뒤 Finished = true로 다시 기록한다.
+// 결과 파일 조립에 필요한 정보(Kind, Agent, SourcePath)도 남겨, 실행 도중 앱이 종료되면
+// 다음 시작 때 RecoverAgentRuns가 저장된 stdout/stderr로 마무리할 수 있다.
+type RunStatus struct {
+	PID          int       `json:"pid"`
+	Args         []string  `json:"args"`          // 프롬프트를 제외한 CLI 인자
+	OutputFormat string    `json:"output_format"` // stdout 형식 (text 또는 stream-json)
+	WorkDir      string    `json:"work_dir"`
+	OutputPath   string    `json:"output_path"` // 조립한 결과 파일 (_plan.md, _execution.md 등)
+	StdoutPath   string    `json:"stdout_path"`
+	StderrPath   string    `json:"stderr_path"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at,omitempty"`
+	DurationMS   int64     `json:"duration_ms"`
+	ExitCode     int       `json:"exit_code"`       // 시그널로 종료되면 -1
+	Error        string    `json:"error,omitempty"` // 실행 또는 결과 조립 실패 사유
+	Finished     bool      `json:"finished"`
+
+	// Usage is the token usage and cost from the stream-json result event (text 형식이거나 result 이벤트가 없으면 nil)
+	Usage *domain.AnalysisUsage `json:"usage,omitempty"`
+
+	SessionID      string `json:"session_id,omitempty"`      // stream-json 이벤트의 CLI 세션 ID (text 형식이면 비어 있음)
+	ResumedSession string `json:"resumed_session,omitempty"` // 이어서 실행한 이전 세션 ID
+	SessionExpired bool   `json:"session_expired,omitempty"` // 이어서 실행할 세션을 CLI가 찾지 못해 실패함
+
+	Kind         string   `json:"kind,omitempty"`          // 결과 파일 종류 (runKindAnalysis 등, 비어 있으면 조립하지 않음)
+	Agent        string   `json:"agent,omitempty"`         // 결과 파일에 표시할 에이전트 이름
+	SourcePath   string   `json:"source_path,omitempty"`   // plan: 결과 파일에 넣을 Jira 이슈 문서
+	CleanupPaths []string `json:"cleanup_paths,omitempty"` // 종료 후 지울 임시 파일 (Hook 설정, 프롬프트 파일)
+}
+
+// Result file kinds assembled from a finished run
+const (
+	runKindAnalysis  = "analysis"  // _analysis.md
+	runKindPlan      = "plan"      // _plan.md (Jira 이슈 문서 + 분석 결과 + 실행 지시사항)
+	runKindExecution = "execution" // _execution.md
+)
+
+// Duration returns how long the run took (0 while it is still running)
+func (s *RunStatus) Duration() time.Duration {
+	return time.Duration(s.DurationMS) * time.Millisecond
+}
+
+// Failed reports whether a finished run exited with a non-zero code or could not be completed
+func (s *RunStatus) Failed() bool {
+	return s.Finished && (s.ExitCode != 0 || s.Error != "")
+}
+
+// RunUsage returns the usage to record for the run.
+// CLI가 사용량을 보고하지 않았으면 실행 시간만 채운다.
+func (s *RunStatus) RunUsage() domain.AnalysisUsage {
+	if s.Usage != nil {
+		return *s.Usage
+	}
+	return domain.AnalysisUsage{DurationMS: s.DurationMS}
+}
+
+// AIRunStatus converts the sidecar into the status reported through port.AIAnalyzer
+func (s *RunStatus) AIRunStatus() *domain.AIRunStatus {
+	return &domain.AIRunStatus{
+		Finished:       s.Finished,
+		ExitCode:       s.ExitCode,
+		Error:          s.Error,
+		Duration:       s.Duration(),
+		Usage:          s.RunUsage(),
+		SessionID:      s.SessionID,
+		SessionExpired: s.SessionExpired,
+		OutputFormat:   s.OutputFormat,
+		StdoutPath:     s.StdoutPath,
+	}
+}
+
+// readRunStatus loads a run status sidecar
+func readRunStatus(path string) (*RunStatus, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("status path is empty")
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var status RunStatus
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse run status %s: %w", path, err)
+	}
+	return &status, nil
+}
+
+// agentRunStatus reads the status sidecar of a run started by startAgentRun
+func agentRunStatus(run *domain.AIRun) (*domain.AIRunStatus, error) {
+	if run == nil {
+		return nil, errors.New("run is nil")
+	}
+	status, err := readRunStatus(run.StatusPath)
+	if err != nil {
+		return nil, err
+	}
+	return status.AIRunStatus(), nil
+}
+
+func writeRunStatus(path string, status *RunStatus) error {
+	raw, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, raw)
+}
+
+// agentRun describes one agent CLI invocation supervised by startAgentRun
+type agentRun struct {
+	agent      string   // 로그에 표시할 에이전트 이름 (Claude 등)
+	label      string   // 로그 표시용 (Phase 1 등)
+	cliPath    string   // 에이전트 CLI 실행 파일
+	args       []string // 상태 파일에 기록할 CLI 인자
+	promptArgs []string // args 뒤에 붙여 실행하지만 기록하지 않는 인자 (--print <prompt> 등)
+	stdin      string   // 표준 입력으로 전달할 내용 (비어 있으면 없음)
+	format     string   // OutputFormatText 또는 OutputFormatStreamJSON
+	workDir    string
+	basePath   string // "<base>_stdout.txt", "<base>_stderr.txt", "<base>_status.json"의 접두사
+	outputPath string // 조립한 결과를 쓸 파일
+	cleanup    []string
+	resumed    string // 이어서 실행하는 이전 세션 ID (새 세션이면 빈 문자열)
+
+	kind       string // 결과 파일 종류 (runKindAnalysis 등)
+	docAgent   string // 결과 파일에 표시할 에이전트 이름 (Claude Code 등)
+	sourcePath string // runKindPlan: 결과 파일에 넣을 Jira 이슈 문서
+}
+
+func (r *agentRun) stdoutPath() string { return r.basePath + "_stdout.txt" }
+func (r *agentRun) stderrPath() string { return r.basePath + "_stderr.txt" }
+func (r *agentRun) statusPath() string { return r.basePath + "_status.json" }
+
+// startAgentRun starts the CLI directly (쉘 스크립트 없이) with stdout/stderr captured to files.
+// 프로세스가 끝나면 백그라운드에서 결과 파일을 조립하고 임시 파일을 지운 뒤 상태 파일에 종료를 기록한다.
+// 시작에 실패하면 cleanup 파일을 지우고 에러를 반환한다.
+func startAgentRun(run agentRun) (*RunStatus, error) {
+	stdout, err := os.Create(run.stdoutPath())
+	if err != nil {
+		removeFiles(run.cleanup)
+		return nil, fmt.Errorf("failed to create stdout file: %w", err)
+	}
+	stderr, err := os.Create(run.stderrPath())
+	if err != nil {
+		stdout.Close()
+		removeFiles(run.cleanup)
+		return nil, fmt.Errorf("failed to create stderr file: %w", err)
+	}
+
+	args := append(append([]string{}, run.args...), run.promptArgs...)
+	cmd := exec.Command(run.cliPath, args...)
+	cmd.Dir = run.workDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if run.stdin != "" {
+		cmd.Stdin = strings.NewReader(run.stdin)
+	}
+
+	status := &RunStatus{
+		Args:           run.args,
+		OutputFormat:   run.format,
+		WorkDir:        run.workDir,
+		OutputPath:     run.outputPath,
+		StdoutPath:     run.stdoutPath(),
+		StderrPath:     run.stderrPath(),
+		StartedAt:      time.Now(),
+		ResumedSession: run.resumed,
+		Kind:           run.kind,
+		Agent:          run.docAgent,
+		SourcePath:     run.sourcePath,
+		CleanupPaths:   run.cleanup,
+	}
+	if err := cmd.Start(); err != nil {
+		stdout.Close()
+		stderr.Close()
+		removeFiles(run.cleanup)
+		return nil, fmt.Errorf("failed to start %s: %w", run.cliPath, err)
+	}
+	status.PID = cmd.Process.Pid
+	if err := writeRunStatus(run.statusPath(), status); err != nil {
+		logger.Debug("startAgentRun: failed to write status: %v", err)
+	}
+	logger.Debug("startAgentRun: %s started, PID=%d, status=%s", run.label, status.PID, run.statusPath())
+
+	started := *status
+	go func() {
+		waitErr := cmd.Wait()
+		stdout.Close()
+		stderr.Close()
+		finishAgentRun(run, status, waitErr)
+	}()
+	return &started, nil
+}
+
+// finishAgentRun records the exit code, assembles the result file and marks the status finished
+func finishAgentRun(run agentRun, status *RunStatus, waitErr error) {
+	status.FinishedAt = time.Now()
+	var exitErr *exec.ExitError
+	switch {
+	case waitErr == nil:
+		status.ExitCode = 0
+	case errors.As(waitErr, &exitErr):
+		status.ExitCode = exitErr.ExitCode()
+	default:
+		status.ExitCode = -1
+		status.Error = waitErr.Error()
+	}
+
+	completeRunStatus(run.statusPath(), status)
+	fmt.Printf("[%s] %s 종료 (exit code: %d, %s)\n", run.agent, run.label, status.ExitCode, status.Duration().Round(time.Second))
+}
+
+// completeRunStatus assembles the result file from the saved stdout/stderr, removes temporary files
+// and marks the status finished. ExitCode, Error와 FinishedAt은 호출하는 쪽에서 채운다.
+func completeRunStatus(statusPath string, status *RunStatus) {
+	status.DurationMS = status.FinishedAt.Sub(status.StartedAt).Milliseconds()
+	out, _ := os.ReadFile(status.StdoutPath)
+	errOut, _ := os.ReadFile(status.StderrPath)
+	status.Usage = resultUsage(status.OutputFormat, out)
+	status.SessionID = resultSessionID(status.OutputFormat, out)
+	if status.ResumedSession != "" && status.ExitCode != 0 {
+		status.SessionExpired = resumeSessionMissing(status.OutputFormat, out, errOut)
+	}
+	if status.Kind != "" && status.OutputPath != "" {
+		content, err := assembleRunOutput(status, out, errOut)
+		if err == nil {
+			err = writeFileAtomic(status.OutputPath, content)
+		}
+		if err != nil {
+			status.Error = fmt.Sprintf("failed to write %s: %v", status.OutputPath, err)
+		}
+	}
+	removeFiles(status.CleanupPaths)
+
+	status.Finished = true
+	if err := writeRunStatus(statusPath, status); err != nil {
+		logger.Debug("completeRunStatus: failed to write status: %v", err)
+	}
+}
+
+// assembleRunOutput builds the result file of a finished run from its status and output
+func assembleRunOutput(status *RunStatus, stdout, stderr []byte) ([]byte, error) {
+	switch status.Kind {
+	case runKindAnalysis:
+		return buildAnalysisDocument(status.Agent, status.WorkDir, status, stdout, stderr), nil
+	case runKindPlan:
+		issueMarkdown, err := os.ReadFile(status.SourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issue document: %w", err)
+		}
+		return buildPlanDocument(status.Agent, string(issueMarkdown), status.WorkDir, status, stdout, stderr), nil
+	case runKindExecution:
+		return buildExecutionDocument(status.Agent, status.WorkDir, status, stdout, stderr), nil
+	}
+	return nil, fmt.Errorf("unknown run kind %q", status.Kind)
+}
+
+// RecoverAgentRuns finishes runs under outputDir (<outputDir>/<이슈>/*_status.json) that were left unfinished
+// because the app quit while the agent was running. 프로세스가 아직 살아 있는 실행은 건드리지 않는다.
+// 마무리한 상태 파일 경로를 반환한다.
+func RecoverAgentRuns(outputDir string) []string {
+	paths, _ := filepath.Glob(filepath.Join(outputDir, "*", "*_status.json"))
+	var recovered []string
+	for _, path := range paths {
+		status, err := readRunStatus(path)
+		if err != nil || status.Finished || status.StdoutPath == "" || ProcessRunning(status.PID) {
+			continue
+		}
+		recoverAgentRun(path, status)
+		recovered = append(recovered, path)
+	}
+	return recovered
+}
+
+// recoverAgentRun completes a run whose process ended while the app was not running.
+// 종료 코드를 받을 수 없으므로 stream-json이면 result 이벤트로, text면 출력이 있는지로 성공 여부를 판단한다.
+func recoverAgentRun(statusPath string, status *RunStatus) {
+	status.FinishedAt = status.StartedAt
+	for _, path := range []string{status.StdoutPath, status.StderrPath} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(status.FinishedAt) {
+			status.FinishedAt = info.ModTime()
+		}
+	}
+
+	out, _ := os.ReadFile(status.StdoutPath)
+	succeeded := len(bytes.TrimSpace(out)) > 0
+	if status.OutputFormat == OutputFormatStreamJSON {
+		result, ok := lastResultEvent(out)
+		succeeded = ok && !result.IsError
+	}
+	status.ExitCode = 0
+	if !succeeded {
+		status.ExitCode = -1
+		status.Error = "앱이 실행 중에 종료되어 종료 코드를 확인하지 못했습니다"
+	}
+	logger.Debug("recoverAgentRun: %s (PID=%d, succeeded=%v)", statusPath, status.PID, succeeded)
+	completeRunStatus(statusPath, status)
+}
+
+// cancelAgentRun stops the agent process of a run (Unix에서는 SIGTERM).
+// 프로세스가 종료되면 finishAgentRun이 상태 파일에 종료를 기록한다.
+func cancelAgentRun(run *domain.AIRun) error {
+	if run == nil || run.PID <= 0 {
+		return nil
+	}
+	if err := TerminateProcess(run.PID); err != nil {
+		return fmt.Errorf("failed to stop PID %d: %w", run.PID, err)
+	}
+	return nil
+}
+
+// resumeSessionMissing reports whether a failed resumed run could not find the session to continue.
+// stdout에는 모델 응답과 도구 출력이 섞이므로 stderr와 result 이벤트의 오류 메시지만 확인하고,
+// stream-json에서 assistant·도구 이벤트가 이미 나왔으면 세션이 이어진 뒤의 실패로 본다.
+func resumeSessionMissing(format string, stdout, stderr []byte) bool {
+	if format != OutputFormatStreamJSON {
+		return sessionNotFound(stderr)
+	}
+	if sessionStarted(stdout) {
+		return false
+	}
+	if sessionNotFound(stderr) {
+		return true
+	}
+	result, ok := lastResultEvent(stdout)
+	return ok && result.IsError && sessionNotFound([]byte(result.Text))
+}
+
+// sessionStarted reports whether stream-json stdout has any assistant text or tool event
+func sessionStarted(stdout []byte) bool {
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		for _, e := range ParseStreamLine(line) {
+			switch e.Kind {
+			case StreamEventText, StreamEventToolUse, StreamEventToolResult:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sessionNotFound reports whether CLI error output says the session to resume does not exist
+// (Claude: "No conversation found with session ID: ..." — 삭제·정리되었거나 다른 프로젝트 경로의 세션)
+func sessionNotFound(output []byte) bool {
+	return bytes.Contains(bytes.ToLower(output), []byte("no conversation found"))
+}
+
+func removeFiles(paths []string) {
+	for _, p := range paths {
+		os.Remove(p)
+	}
+}
+
+// failureNote is written at the top of a result file when the agent CLI failed
+func failureNote(marker, agent string, status *RunStatus, stderr []byte) string {
+	if status.ExitCode == 0 && status.Error == "" {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s 오류 발생 (exit code: %d)\n\n", marker, agent, status.ExitCode)
+	if status.Error != "" {
+		fmt.Fprintf(&b, "%s\n\n", status.Error)
+	}
+	if msg := strings.TrimSpace(string(stderr)); msg != "" {
+		fmt.Fprintf(&b, "```text\n%s\n```\n\n", msg)
+	}
+	return b.String()
+}
+
+// stripFeatureUsageBlocks removes the bkit "Feature Usage" blocks that start with a line of ─ (5개 이상)
+// and end with a line made only of ─. 끝 줄이 없으면 마지막까지 지운다.
+func stripFeatureUsageBlocks(output string) string {
+	isRule := func(line string) bool {
+		return strings.HasPrefix(line, strings.Repeat("─", 5))
+	}
+	isClosingRule := func(line string) bool {
+		return isRule(line) && strings.Trim(line, "─") == ""
+	}
+
+	lines := strings.SplitAfter(output, "\n")
+	var b strings.Builder
+	inBlock := false
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		if inBlock {
+			if isClosingRule(text) {
+				inBlock = false
+			}
+			continue
+		}
+		if isRule(text) {
+			inBlock = true
+			continue
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,410 @@
+package adapter
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// writeStubCLI writes a shell script that stands in for the Claude CLI
+func writeStubCLI(t *testing.T, dir, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, "claude")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// waitRunFinished polls the status sidecar until the run is finished
+func waitRunFinished(t *testing.T, statusPath string) *RunStatus {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, err := readRunStatus(statusPath); err == nil && status.Finished {
+			return status
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("run did not finish: %s", statusPath)
+	return nil
+}
+
+func newStubAdapter(t *testing.T, cliBody string) (*ClaudeCodeAdapter, string) {
+	t.Helper()
+	dir := t.TempDir()
+	hook := filepath.Join(dir, "hook.sh")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return NewClaudeCodeAdapter(writeStubCLI(t, dir, cliBody), true, "test-model", hook), dir
+}
+
+func TestAnalyzeAndGeneratePlan_AssemblesPlan(t *testing.T) {
+	claude, dir := newStubAdapter(t, `cat <<'JSON'
+{"type":"system","subtype":"init","session_id":"s-1","model":"test-model"}
+{"type":"assistant","session_id":"s-1","message":{"content":[{"type":"tool_use","name":"Read","input":{"file_path":"/src/a.go"}}]}}
+{"type":"result","subtype":"success","session_id":"s-1","result":"### ISSUE_SUMMARY\n─────────────────\nbkit Feature Usage\n─────────────────\n버튼이 동작하지 않음\n","num_turns":2,"total_cost_usd":0.05,"duration_ms":1200,"usage":{"input_tokens":30,"output_tokens":7}}
+JSON
+echo "warning on stderr" >&2
+`)
+	mdPath := filepath.Join(dir, "PROJ-1.md")
+	if err := os.WriteFile(mdPath, []byte("# PROJ-1\n\n이슈 본문\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := claude.AnalyzeAndGeneratePlan(mdPath, "prompt", dir)
+	if err != nil {
+		t.Fatalf("AnalyzeAndGeneratePlan() error = %v", err)
+	}
+	if result.PID <= 0 {
+		t.Errorf("PID = %d", result.PID)
+	}
+	if result.StatusPath != filepath.Join(dir, "PROJ-1_plan_status.json") {
+		t.Errorf("StatusPath = %q", result.StatusPath)
+	}
+
+	status := waitRunFinished(t, result.StatusPath)
+	if status.ExitCode != 0 || status.Failed() {
+		t.Errorf("status = %+v", status)
+	}
+	if status.PID != result.PID || status.StartedAt.IsZero() || status.FinishedAt.Before(status.StartedAt) {
+		t.Errorf("status timings/PID = %+v", status)
+	}
+	want := domain.AnalysisUsage{InputTokens: 30, OutputTokens: 7, CostUSD: 0.05, DurationMS: 1200, NumTurns: 2}
+	if status.Usage == nil || *status.Usage != want || status.RunUsage() != want {
+		t.Errorf("Usage = %+v, want %+v", status.Usage, want)
+	}
+	if strings.Join(status.Args, " ") != "--settings "+filepath.Join(dir, "PROJ-1_plan_settings.json")+" --model test-model --output-format stream-json --verbose" {
+		t.Errorf("Args = %q", status.Args)
+	}
+	if status.SessionID != "s-1" {
+		t.Errorf("SessionID = %q, want s-1", status.SessionID)
+	}
+
+	plan, err := os.ReadFile(result.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Claude Code 실행 계획",
+		"## Jira 이슈 컨텍스트\n\n# PROJ-1\n\n이슈 본문\n",
+		"## AI 분석 결과",
+		"프로젝트: " + dir,
+		"### ISSUE_SUMMARY\n버튼이 동작하지 않음\n",
+		"## 실행 지시사항",
+	} {
+		if !strings.Contains(string(plan), want) {
+			t.Errorf("plan missing %q:\n%s", want, plan)
+		}
+	}
+	if strings.Contains(string(plan), "bkit Feature Usage") || strings.Contains(string(plan), "오류 발생") {
+		t.Errorf("plan should not contain feature usage block or error note:\n%s", plan)
+	}
+
+	stderr, _ := os.ReadFile(result.LogPath)
+	if string(stderr) != "warning on stderr\n" {
+		t.Errorf("stderr = %q", stderr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "PROJ-1_plan_settings.json")); !os.IsNotExist(err) {
+		t.Errorf("settings file should be removed after the run, stat err = %v", err)
+	}
+}
+
+func TestExecutePlan_RecordsFailure(t *testing.T) {
+	claude, dir := newStubAdapter(t, `echo "partial output"
+echo "Hook denied the tool call" >&2
+exit 3
+`)
+	claude.SetOutputFormat(OutputFormatText)
+	planPath := filepath.Join(dir, "PROJ-2_plan.md")
+	if err := os.WriteFile(planPath, []byte("# plan"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := claude.ExecutePlan(planPath, dir)
+	if err != nil {
+		t.Fatalf("ExecutePlan() error = %v", err)
+	}
+	status := waitRunFinished(t, result.StatusPath)
+	if status.ExitCode != 3 || !status.Failed() {
+		t.Errorf("status = %+v", status)
+	}
+	if status.Usage != nil || status.RunUsage() != (domain.AnalysisUsage{DurationMS: status.DurationMS}) {
+		t.Errorf("text output should only record the duration, got %+v", status.RunUsage())
+	}
+
+	out, err := os.ReadFile(result.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# 실행 결과", "❌ Claude 오류 발생 (exit code: 3)", "Hook denied the tool call", "partial output", "✅ 실행 완료"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("execution result missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestResumePlan_ContinuesSession(t *testing.T) {
+	claude, dir := newStubAdapter(t, `for arg; do last="$arg"; done
+printf '%s' "$last" > prompt.txt
+echo '{"type":"result","subtype":"success","session_id":"s-2","result":"계획대로 수정했습니다"}'
+`)
+	planPath := filepath.Join(dir, "PROJ-3_plan.md")
+	if err := os.WriteFile(planPath, []byte("# plan\n\n아주 긴 계획 본문"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := claude.ResumePlan(planPath, "s-1", dir)
+	if err != nil {
+		t.Fatalf("ResumePlan() error = %v", err)
+	}
+	if result.ResumedSession != "s-1" {
+		t.Errorf("ResumedSession = %q", result.ResumedSession)
+	}
+	status := waitRunFinished(t, result.StatusPath)
+	if status.Failed() || status.SessionExpired || status.ResumedSession != "s-1" || status.SessionID != "s-2" {
+		t.Errorf("status = %+v", status)
+	}
+	if !strings.HasSuffix(strings.Join(status.Args, " "), "--verbose --resume s-1") {
+		t.Errorf("Args = %q", status.Args)
+	}
+
+	prompt, _ := os.ReadFile(filepath.Join(dir, "prompt.txt"))
+	if !strings.Contains(string(prompt), "계획 파일: "+planPath) || strings.Contains(string(prompt), "아주 긴 계획 본문") {
+		t.Errorf("resume prompt should reference the plan instead of re-sending it:\n%s", prompt)
+	}
+	out, _ := os.ReadFile(result.OutputPath)
+	if !strings.Contains(string(out), "🔁 이어서 실행한 세션: s-1") || !strings.Contains(string(out), "계획대로 수정했습니다") {
+		t.Errorf("execution result:\n%s", out)
+	}
+
+	if _, err := claude.ResumePlan(planPath, " ", dir); err == nil {
+		t.Error("expected error for empty session ID")
+	}
+}
+
+// TestAnalyzeAndGeneratePlan_SettingsChangedWhileStarting은 실행을 시작하는 도중 설정이 바뀌어도
+// 한 실행의 CLI 인자와 출력 형식이 같은 설정에서 나오는지 검증한다.
+func TestAnalyzeAndGeneratePlan_SettingsChangedWhileStarting(t *testing.T) {
+	claude, dir := newStubAdapter(t, "exit 0\n")
+	mdPath := filepath.Join(dir, "PROJ-5.md")
+	if err := os.WriteFile(mdPath, []byte("# PROJ-5"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if i%2 == 0 {
+				claude.SetOutputFormat(OutputFormatText)
+			} else {
+				claude.SetOutputFormat(OutputFormatStreamJSON)
+			}
+			claude.SetModel("test-model")
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		run, err := claude.AnalyzeAndGeneratePlan(mdPath, "prompt", dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		status := waitRunFinished(t, run.StatusPath)
+		args := strings.Join(status.Args, " ")
+		if !strings.Contains(args, "--output-format "+status.OutputFormat) {
+			t.Errorf("args %q do not match output format %q", args, status.OutputFormat)
+		}
+	}
+	<-done
+}
+
+func TestResumePlan_SessionExpired(t *testing.T) {
+	claude, dir := newStubAdapter(t, `echo "No conversation found with session ID: gone" >&2
+exit 1
+`)
+	planPath := filepath.Join(dir, "PROJ-4_plan.md")
+	if err := os.WriteFile(planPath, []byte("# plan"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := claude.ResumePlan(planPath, "gone", dir)
+	if err != nil {
+		t.Fatalf("ResumePlan() error = %v", err)
+	}
+	status := waitRunFinished(t, result.StatusPath)
+	if !status.Failed() || !status.SessionExpired {
+		t.Errorf("missing session should be recorded as expired: %+v", status)
+	}
+
+	// 세션을 이어서 실행하지 않은 실패는 만료로 보지 않는다
+	result, err = claude.ExecutePlan(planPath, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := waitRunFinished(t, result.StatusPath); status.SessionExpired {
+		t.Errorf("new session run should not be marked expired: %+v", status)
+	}
+}
+
+// TestRecoverAgentRuns는 앱이 실행 도중 종료되어 Finished=false로 남은 실행을 다음 시작 때
+// 저장된 stdout/stderr로 마무리하고, 아직 실행 중인 프로세스의 상태는 건드리지 않는지 검증한다.
+func TestRecoverAgentRuns(t *testing.T) {
+	outputDir := t.TempDir()
+	issueDir := filepath.Join(outputDir, "PROJ-1")
+	if err := os.MkdirAll(issueDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) string {
+		path := filepath.Join(issueDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// 이미 끝난 프로세스의 PID
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot run true: %v", err)
+	}
+	gonePID := cmd.Process.Pid
+
+	mdPath := write("PROJ-1.md", "# PROJ-1\n\n이슈 본문\n")
+	settingsPath := write("PROJ-1_plan_settings.json", "{}")
+	plan := &RunStatus{
+		PID:          gonePID,
+		OutputFormat: OutputFormatStreamJSON,
+		WorkDir:      outputDir,
+		OutputPath:   filepath.Join(issueDir, "PROJ-1_plan.md"),
+		StdoutPath: write("PROJ-1_plan_stdout.txt", `{"type":"system","subtype":"init","session_id":"s-9","model":"m"}
+{"type":"result","subtype":"success","session_id":"s-9","result":"버튼 수정 필요","usage":{"input_tokens":3,"output_tokens":4}}
+`),
+		StderrPath:   write("PROJ-1_plan_stderr.txt", ""),
+		StartedAt:    time.Now().Add(-time.Minute),
+		Kind:         runKindPlan,
+		Agent:        "Claude Code",
+		SourcePath:   mdPath,
+		CleanupPaths: []string{settingsPath},
+	}
+	planStatusPath := filepath.Join(issueDir, "PROJ-1_plan_status.json")
+	execution := &RunStatus{
+		PID:          gonePID,
+		OutputFormat: OutputFormatStreamJSON,
+		OutputPath:   filepath.Join(issueDir, "PROJ-1_execution.md"),
+		StdoutPath:   write("PROJ-1_exec_stdout.txt", `{"type":"assistant","message":{"content":[{"type":"text","text":"수정 중"}]}}`+"\n"),
+		StderrPath:   write("PROJ-1_exec_stderr.txt", "killed\n"),
+		StartedAt:    time.Now().Add(-time.Minute),
+		Kind:         runKindExecution,
+		Agent:        "Claude",
+	}
+	execStatusPath := filepath.Join(issueDir, "PROJ-1_exec_status.json")
+	running := &RunStatus{PID: os.Getpid(), StdoutPath: write("PROJ-1_stdout.txt", ""), Kind: runKindAnalysis}
+	runningStatusPath := filepath.Join(issueDir, "PROJ-1_status.json")
+	for path, status := range map[string]*RunStatus{planStatusPath: plan, execStatusPath: execution, runningStatusPath: running} {
+		if err := writeRunStatus(path, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recovered := RecoverAgentRuns(outputDir)
+
+	if len(recovered) != 2 {
+		t.Fatalf("expected the two runs whose process is gone to be recovered, got %v", recovered)
+	}
+	planStatus, _ := readRunStatus(planStatusPath)
+	if !planStatus.Finished || planStatus.Failed() || planStatus.SessionID != "s-9" || planStatus.Usage == nil {
+		t.Errorf("plan status = %+v", planStatus)
+	}
+	planDoc, err := os.ReadFile(plan.OutputPath)
+	if err != nil || !strings.Contains(string(planDoc), "이슈 본문") || !strings.Contains(string(planDoc), "버튼 수정 필요") {
+		t.Errorf("expected plan assembled from the issue document and saved output, got %q (%v)", planDoc, err)
+	}
+	if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+		t.Errorf("expected cleanup files removed, got %v", err)
+	}
+	execStatus, _ := readRunStatus(execStatusPath)
+	if !execStatus.Finished || !execStatus.Failed() {
+		t.Errorf("expected a run without a result event to be recorded as failed, got %+v", execStatus)
+	}
+	if execDoc, _ := os.ReadFile(execution.OutputPath); !strings.Contains(string(execDoc), "수정 중") {
+		t.Errorf("expected partial output in the execution document, got %q", execDoc)
+	}
+	if status, _ := readRunStatus(runningStatusPath); status.Finished {
+		t.Error("expected a run whose process is still alive to be left alone")
+	}
+}
+
+func TestStartAgentRun_MissingCLI(t *testing.T) {
+	dir := t.TempDir()
+	settings := filepath.Join(dir, "settings.json")
+	os.WriteFile(settings, []byte("{}"), 0600)
+
+	_, err := startAgentRun(agentRun{
+		cliPath:  filepath.Join(dir, "no-such-claude"),
+		workDir:  dir,
+		basePath: filepath.Join(dir, "run"),
+		cleanup:  []string{settings},
+	})
+	if err == nil {
+		t.Fatal("expected error for missing CLI")
+	}
+	if _, statErr := os.Stat(settings); !os.IsNotExist(statErr) {
+		t.Errorf("cleanup files should be removed when the run cannot start")
+	}
+}
+
+func TestResumeSessionMissing(t *testing.T) {
+	const notFound = "No conversation found with session ID: gone"
+	assistant := `{"type":"assistant","message":{"content":[{"type":"text","text":"로그에 ` + notFound + ` 문구가 있습니다"}]}}`
+	tool := `{"type":"user","message":{"content":[{"type":"tool_result","content":"` + notFound + `"}]}}`
+	errorResult := `{"type":"result","subtype":"error_during_execution","is_error":true,"result":"` + notFound + `"}`
+
+	tests := []struct {
+		name   string
+		format string
+		stdout string
+		stderr string
+		want   bool
+	}{
+		{"text stderr", OutputFormatText, "", notFound, true},
+		{"text model output", OutputFormatText, notFound, "", false},
+		{"stream-json stderr", OutputFormatStreamJSON, "", notFound, true},
+		{"stream-json result error", OutputFormatStreamJSON, errorResult, "", true},
+		{"stream-json assistant text", OutputFormatStreamJSON, assistant, "", false},
+		{"stream-json tool output", OutputFormatStreamJSON, tool + "\n" + errorResult, "", false},
+		{"stream-json failed after assistant", OutputFormatStreamJSON, assistant, notFound, false},
+		{"stream-json other failure", OutputFormatStreamJSON, `{"type":"result","is_error":true,"result":"rate limited"}`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resumeSessionMissing(tt.format, []byte(tt.stdout), []byte(tt.stderr)); got != tt.want {
+				t.Errorf("resumeSessionMissing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripFeatureUsageBlocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"no block", "a\nb\n", "a\nb\n"},
+		{"block removed", "a\n───── Feature Usage\nused: x\n─────────\nb\n", "a\nb\n"},
+		{"short rule kept", "a\n───\nb\n", "a\n───\nb\n"},
+		{"unterminated block", "a\n─────\nrest\n", "a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripFeatureUsageBlocks(tt.input); got != tt.want {
+				t.Errorf("stripFeatureUsageBlocks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package adapter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+)
+
+// AttachmentCache stores downloaded attachments by content hash so re-runs and other channels
+// loading the same issue can reuse them without downloading again.
+//
+// 디렉토리 구조:
+//
+//	<dir>/objects/<sha256 앞 2자리>/<sha256>  첨부파일 내용
+//	<dir>/index/<Jira 첨부파일 ID>_<크기>    해당 첨부파일의 sha256
+type AttachmentCache struct {
+	dir string
+}
+
+// NewAttachmentCache creates a cache rooted at dir (e.g. <output>/.cache/attachments)
+func NewAttachmentCache(dir string) *AttachmentCache {
+	return &AttachmentCache{dir: dir}
+}
+
+// Lookup returns the content hash and object path of a cached attachment.
+// Jira 첨부파일은 수정할 수 없으므로 ID와 크기가 같으면 같은 내용으로 본다.
+func (c *AttachmentCache) Lookup(att domain.Attachment) (hash, objectPath string, ok bool) {
+	indexPath, ok := c.indexPath(att)
+	if !ok {
+		return "", "", false
+	}
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return "", "", false
+	}
+	hash = strings.TrimSpace(string(data))
+	objectPath = c.objectPath(hash)
+	info, err := os.Stat(objectPath)
+	if err != nil || (att.Size > 0 && info.Size() != att.Size) {
+		// 객체가 지워졌거나 손상된 경우 색인을 무시하고 다시 받는다
+		return "", "", false
+	}
+	return hash, objectPath, true
+}
+
+// Store adds the file at path (already hashed as hash) to the cache and indexes it under the attachment.
+// path는 그대로 남으며, 같은 파일 시스템이면 하드 링크로 공유한다.
+func (c *AttachmentCache) Store(att domain.Attachment, path, hash string) error {
+	objectPath := c.objectPath(hash)
+	if _, err := os.Stat(objectPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+		if err := linkOrCopy(path, objectPath); err != nil {
+			return fmt.Errorf("failed to store cache object: %w", err)
+		}
+	}
+
+	indexPath, ok := c.indexPath(att)
+	if !ok {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0755); err != nil {
+		return fmt.Errorf("failed to create cache index: %w", err)
+	}
+	return writeFileAtomic(indexPath, []byte(hash+"\n"))
+}
+
+// Materialize places the cached object at dst, keeping dst untouched if it is already the same file
+func (c *AttachmentCache) Materialize(objectPath, dst string) error {
+	if dstInfo, err := os.Stat(dst); err == nil {
+		if srcInfo, err := os.Stat(objectPath); err == nil && os.SameFile(srcInfo, dstInfo) {
+			return nil
+		}
+	}
+	return linkOrCopy(objectPath, dst)
+}
+
+func (c *AttachmentCache) indexPath(att domain.Attachment) (string, bool) {
+	if att.ID == "" || strings.ContainsAny(att.ID, `/\`) || att.ID == "." || att.ID == ".." {
+		return "", false
+	}
+	return filepath.Join(c.dir, "index", fmt.Sprintf("%s_%d", att.ID, att.Size)), true
+}
+
+func (c *AttachmentCache) objectPath(hash string) string {
+	prefix := hash
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(c.dir, "objects", prefix, hash)
+}
+
+// hashingWriter computes the SHA-256 of everything written through it
+type hashingWriter struct {
+	w    io.Writer
+	hash hash.Hash
+}
+
+func newHashingWriter(w io.Writer) *hashingWriter {
+	return &hashingWriter{w: w, hash: sha256.New()}
+}
+
+func (hw *hashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.hash.Write(p[:n])
+	return n, err
+}
+
+// Sum returns the hex-encoded SHA-256 of the bytes written so far
+func (hw *hashingWriter) Sum() string {
+	return hex.EncodeToString(hw.hash.Sum(nil))
+}
+
+// linkSeq makes temporary link names unique across concurrent workers
+var linkSeq uint64
+
+// linkOrCopy atomically places a hard link (or, across file systems, a copy) of src at dst
+func linkOrCopy(src, dst string) error {
+	tmp := fmt.Sprintf("%s.%d-%d.link", dst, os.Getpid(), atomic.AddUint64(&linkSeq, 1))
+	if err := os.Link(src, tmp); err != nil {
+		logger.Debug("linkOrCopy: hard link failed, copying instead: %v", err)
+		if err := copyFile(src, tmp); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.part")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
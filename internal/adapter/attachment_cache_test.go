@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// TestAttachmentCache_LookupIgnoresMissingObject는 캐시 객체가 지워지면 색인이 있어도 miss로 처리하는지 검증한다.
+func TestAttachmentCache_LookupIgnoresMissingObject(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewAttachmentCache(filepath.Join(dir, "cache"))
+	att := domain.Attachment{ID: "42", Filename: "shot.png", Size: 5}
+
+	src := filepath.Join(dir, "shot.png")
+	if err := os.WriteFile(src, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hw := newHashingWriter(io.Discard)
+	hw.Write([]byte("hello"))
+	hash := hw.Sum()
+	if err := cache.Store(att, src, hash); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	gotHash, objectPath, ok := cache.Lookup(att)
+	if !ok || gotHash != hash {
+		t.Fatalf("expected cache hit with %s, got %s (%v)", hash, gotHash, ok)
+	}
+
+	// 원본을 지워도 캐시 객체는 남아 있어야 한다
+	os.Remove(src)
+	dst := filepath.Join(dir, "restored.png")
+	if err := cache.Materialize(objectPath, dst); err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "hello" {
+		t.Errorf("unexpected restored content %q", data)
+	}
+
+	os.Remove(objectPath)
+	if _, _, ok := cache.Lookup(att); ok {
+		t.Error("expected miss after the cached object was removed")
+	}
+	if _, _, ok := cache.Lookup(domain.Attachment{ID: "../42", Size: 5}); ok {
+		t.Error("expected miss for an attachment ID with a path separator")
+	}
+}
@@ -1,19 +1,38 @@
 package adapter
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
 	"jira-ai-generator/internal/port"
 )
 
+// DefaultDownloadWorkers is the number of attachments downloaded in parallel by default
+const DefaultDownloadWorkers = 3
+
+// downloadProgressStep is the minimum number of bytes between progress reports for one file
+const downloadProgressStep = 512 << 10
+
+// ErrAttachmentTooLarge is returned in DownloadResult.Error for attachments over the size limit
+var ErrAttachmentTooLarge = errors.New("attachment exceeds size limit")
+
 // AttachmentDownloader implements port.AttachmentDownloader
 type AttachmentDownloader struct {
 	jiraRepo  port.JiraRepository
 	outputDir string
+	cache     *AttachmentCache // nil이면 캐시를 쓰지 않음
+
+	mu      sync.RWMutex // 설정 화면에서 다운로드 도중에 바뀔 수 있음
+	maxSize int64        // 0이면 제한 없음
+	workers int
 }
 
 // NewAttachmentDownloader creates a new attachment downloader
@@ -21,11 +40,44 @@ func NewAttachmentDownloader(jiraRepo port.JiraRepository, outputDir string) *At
 	return &AttachmentDownloader{
 		jiraRepo:  jiraRepo,
 		outputDir: outputDir,
+		workers:   DefaultDownloadWorkers,
 	}
 }
 
-// DownloadAll downloads all media attachments for an issue
-func (d *AttachmentDownloader) DownloadAll(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error) {
+// SetMaxSize sets the largest attachment (in bytes) that will be downloaded; 0 disables the limit
+func (d *AttachmentDownloader) SetMaxSize(maxSize int64) {
+	if maxSize < 0 {
+		maxSize = 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.maxSize = maxSize
+}
+
+// SetWorkers sets how many attachments are downloaded in parallel
+func (d *AttachmentDownloader) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.workers = workers
+}
+
+// SetCache enables reusing previously downloaded attachments from cache
+func (d *AttachmentDownloader) SetCache(cache *AttachmentCache) {
+	d.cache = cache
+}
+
+// DownloadAll downloads the supported attachments (images, videos, text files and zip archives) of an issue.
+// 파일은 임시 파일로 스트리밍한 뒤 이름을 바꿔 저장하므로 중단되어도 쓰다 만 파일이 남지 않는다.
+// ctx가 취소되면 남은 첨부파일은 건너뛰고 그때까지 끝난 결과와 ctx.Err()를 함께 반환한다.
+func (d *AttachmentDownloader) DownloadAll(ctx context.Context, issueKey string, attachments []domain.Attachment, onProgress func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+	// 한 번의 다운로드에는 시작할 때의 설정만 적용한다
+	d.mu.RLock()
+	maxSize, workers := d.maxSize, d.workers
+	d.mu.RUnlock()
+
 	// Convert to absolute path for AI accessibility
 	absOutputDir, err := filepath.Abs(d.outputDir)
 	if err != nil {
@@ -37,43 +89,298 @@ func (d *AttachmentDownloader) DownloadAll(issueKey string, attachments []domain
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	var results []domain.DownloadResult
-
+	var media []domain.Attachment
 	for _, att := range attachments {
-		if !isMediaFile(att.MimeType) {
-			continue
+		if domain.ClassifyAttachment(att.MimeType, att.Filename) != domain.AttachmentUnsupported {
+			media = append(media, att)
 		}
+	}
 
-		result := domain.DownloadResult{
-			Attachment: att,
-			IsVideo:    isVideoFile(att.MimeType),
+	localNames := assignLocalNames(issueKey, media)
+	results := make([]domain.DownloadResult, len(media))
+	finished := make([]bool, len(media))
+	progress := &downloadProgress{total: len(media), onProgress: onProgress}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(media); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, ok := d.download(ctx, filepath.Join(issueDir, localNames[i]), media[i], maxSize, progress)
+				results[i] = result
+				finished[i] = ok
+			}
+		}()
+	}
+
+feed:
+	for i := range media {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		data, err := d.jiraRepo.DownloadAttachment(att.URL)
-		if err != nil {
-			result.Error = err
-			results = append(results, result)
-			continue
+	// 취소로 끝나지 못한 항목은 결과에서 제외한다
+	var completed []domain.DownloadResult
+	for i, result := range results {
+		if finished[i] {
+			completed = append(completed, result)
 		}
+	}
+	if err := ctx.Err(); err != nil {
+		return completed, err
+	}
+	return completed, nil
+}
 
-		localPath := filepath.Join(issueDir, att.Filename)
-		if err := os.WriteFile(localPath, data, 0644); err != nil {
-			result.Error = err
-			results = append(results, result)
-			continue
+// download fetches one attachment into localPath, skipping it when it is larger than maxSize (0이면 제한 없음).
+// ok is false when it was interrupted by cancellation.
+func (d *AttachmentDownloader) download(ctx context.Context, localPath string, att domain.Attachment, maxSize int64, progress *downloadProgress) (domain.DownloadResult, bool) {
+	kind := domain.ClassifyAttachment(att.MimeType, att.Filename)
+	result := domain.DownloadResult{
+		Attachment: att,
+		IsVideo:    kind == domain.AttachmentVideo,
+		Kind:       kind,
+	}
+	if ctx.Err() != nil {
+		return result, false
+	}
+	if maxSize > 0 && att.Size > maxSize {
+		result.Error = fmt.Errorf("%w: %s (%s > %s)", ErrAttachmentTooLarge, att.Filename, formatBytes(att.Size), formatBytes(maxSize))
+		progress.fileDone(att)
+		return result, true
+	}
+
+	if d.cache != nil {
+		if hash, objectPath, ok := d.cache.Lookup(att); ok {
+			if err := d.cache.Materialize(objectPath, localPath); err == nil {
+				logger.Debug("AttachmentDownloader: cache hit, id=%s, file=%s", att.ID, att.Filename)
+				progress.fileDone(att)
+				result.LocalPath = localPath
+				result.ContentHash = hash
+				result.FromCache = true
+				return result, true
+			}
 		}
+	}
 
-		result.LocalPath = localPath
-		results = append(results, result)
+	hash, err := d.streamToFile(ctx, localPath, att, maxSize, progress)
+	if ctx.Err() != nil {
+		return result, false
+	}
+	progress.fileDone(att)
+	if err != nil {
+		result.Error = err
+		return result, true
 	}
+	if d.cache != nil {
+		if err := d.cache.Store(att, localPath, hash); err != nil {
+			logger.Debug("AttachmentDownloader: failed to cache %s: %v", att.Filename, err)
+		}
+	}
+	result.LocalPath = localPath
+	result.ContentHash = hash
+	return result, true
+}
+
+// streamToFile streams the attachment into a temporary file, renames it into place and returns its SHA-256
+func (d *AttachmentDownloader) streamToFile(ctx context.Context, path string, att domain.Attachment, maxSize int64, progress *downloadProgress) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.part")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmp.Name()
+
+	hasher := newHashingWriter(tmp)
+	var dst io.Writer = hasher
+	if maxSize > 0 {
+		// Jira가 알려준 크기가 없거나 틀린 경우에도 상한을 넘기지 않는다
+		dst = &limitedWriter{w: dst, remaining: maxSize, filename: att.Filename, limit: maxSize}
+	}
+	dst = &progressWriter{w: dst, att: att, progress: progress}
 
-	return results, nil
+	_, err = d.jiraRepo.DownloadAttachment(ctx, att.URL, dst)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	return hasher.Sum(), nil
 }
 
-func isMediaFile(mimeType string) bool {
-	return strings.HasPrefix(mimeType, "image/") || strings.HasPrefix(mimeType, "video/")
+// downloadProgress serializes progress reports from parallel workers
+type downloadProgress struct {
+	mu         sync.Mutex
+	completed  int
+	total      int
+	onProgress func(domain.DownloadProgress)
+}
+
+func (p *downloadProgress) report(att domain.Attachment, bytesDone int64) {
+	if p.onProgress == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onProgress(domain.DownloadProgress{
+		Filename:   att.Filename,
+		BytesDone:  bytesDone,
+		BytesTotal: att.Size,
+		Completed:  p.completed,
+		Total:      p.total,
+	})
 }
 
-func isVideoFile(mimeType string) bool {
-	return strings.HasPrefix(mimeType, "video/")
+func (p *downloadProgress) fileDone(att domain.Attachment) {
+	p.mu.Lock()
+	p.completed++
+	p.mu.Unlock()
+	p.report(att, att.Size)
+}
+
+// progressWriter reports bytes written at most every downloadProgressStep bytes
+type progressWriter struct {
+	w        io.Writer
+	att      domain.Attachment
+	progress *downloadProgress
+	written  int64
+	reported int64
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	if pw.written-pw.reported >= downloadProgressStep {
+		pw.reported = pw.written
+		pw.progress.report(pw.att, pw.written)
+	}
+	return n, err
+}
+
+// limitedWriter fails with ErrAttachmentTooLarge once more than limit bytes are written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+	filename  string
+	limit     int64
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > lw.remaining {
+		return 0, fmt.Errorf("%w: %s (> %s)", ErrAttachmentTooLarge, lw.filename, formatBytes(lw.limit))
+	}
+	n, err := lw.w.Write(p)
+	lw.remaining -= int64(n)
+	return n, err
+}
+
+// maxFilenameBytes keeps local names well under common file system limits (255 bytes)
+const maxFilenameBytes = 150
+
+// safeFilename turns an attachment filename from Jira into a single, harmless path element.
+// 디렉토리 구분자와 제어 문자, Windows 예약 문자를 '_'로 바꾸고, 앞의 점을 떼어 숨김 파일이나 ".."이 되지 않게 한다.
+func safeFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ". ")
+	name = strings.TrimRight(name, ". ")
+	if name == "" {
+		return "attachment"
+	}
+
+	if len(name) > maxFilenameBytes {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		stem := []rune(strings.TrimSuffix(name, ext))
+		for len(string(stem))+len(ext) > maxFilenameBytes {
+			stem = stem[:len(stem)-1]
+		}
+		name = string(stem) + ext
+	}
+	return name
+}
+
+// assignLocalNames returns a safe, unique local file name for each attachment.
+// 이름이 겹치면 먼저 나온 첨부파일이 원래 이름을 쓰고, 나머지는 Jira 첨부파일 ID(없으면 번호)를 앞에 붙인다.
+// Jira가 돌려주는 순서가 같으면 실행할 때마다 같은 이름이 나온다.
+// 생성 문서(<KEY>.md, <KEY>_plan.md 등)와 frames, processed 디렉토리 이름은 첨부파일에 주지 않으며,
+// 프레임과 음성 인식 캐시가 확장자를 뺀 이름으로 저장되므로 동영상끼리는 확장자를 빼고도 겹치지 않게 한다.
+func assignLocalNames(issueKey string, attachments []domain.Attachment) []string {
+	names := make([]string, len(attachments))
+	used := make(map[string]bool)
+	for _, reserved := range append(domain.GeneratedOutputNames(issueKey), "frames", "processed") {
+		used[strings.ToLower(reserved)] = true
+	}
+	videoStems := make(map[string]bool)
+	// 대소문자를 구분하지 않는 파일 시스템(macOS, Windows)에서도 겹치지 않도록 소문자로 비교한다
+	claim := func(name string, video bool) bool {
+		key := strings.ToLower(name)
+		stem := strings.TrimSuffix(key, filepath.Ext(key))
+		if used[key] || (video && videoStems[stem]) {
+			return false
+		}
+		used[key] = true
+		if video {
+			videoStems[stem] = true
+		}
+		return true
+	}
+
+	for i, att := range attachments {
+		name := safeFilename(att.Filename)
+		video := domain.ClassifyAttachment(att.MimeType, att.Filename) == domain.AttachmentVideo
+		if claim(name, video) {
+			names[i] = name
+			continue
+		}
+		if att.ID != "" {
+			if prefixed := safeFilename(att.ID + "_" + name); claim(prefixed, video) {
+				names[i] = prefixed
+				continue
+			}
+		}
+		ext := filepath.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		for n := 2; ; n++ {
+			if numbered := fmt.Sprintf("%s (%d)%s", stem, n, ext); claim(numbered, video) {
+				names[i] = numbered
+				break
+			}
+		}
+	}
+	return names
+}
+
+// formatBytes formats a byte count as a human-readable size
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
@@ -0,0 +1,364 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
+)
+
+// TestAttachmentDownloader_DownloadAll_Cancelled는 취소 이후 남은 첨부파일을 받지 않고
+// 임시 파일 없이 완료된 파일만 남기는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_Cancelled(t *testing.T) {
+	outputDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requested []string
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			requested = append(requested, url)
+			if url == "https://jira/2" {
+				// 일부를 쓴 뒤 중단된 다운로드
+				dst.Write([]byte("partial"))
+				cancel()
+				return 7, ctx.Err()
+			}
+			n, err := dst.Write([]byte("data"))
+			return int64(n), err
+		},
+	}
+	attachments := []domain.Attachment{
+		{Filename: "one.png", MimeType: "image/png", URL: "https://jira/1"},
+		{Filename: "two.mp4", MimeType: "video/mp4", URL: "https://jira/2"},
+		{Filename: "three.png", MimeType: "image/png", URL: "https://jira/3"},
+	}
+
+	downloader := NewAttachmentDownloader(jira, outputDir)
+	downloader.SetWorkers(1)
+	results, err := downloader.DownloadAll(ctx, "TEST-1", attachments, nil)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(requested) != 2 {
+		t.Errorf("expected download to stop after cancellation, requested %v", requested)
+	}
+	if len(results) != 1 || filepath.Base(results[0].LocalPath) != "one.png" {
+		t.Fatalf("expected only the completed attachment in results, got %+v", results)
+	}
+	entries, _ := os.ReadDir(filepath.Join(outputDir, "TEST-1"))
+	if len(entries) != 1 || entries[0].Name() != "one.png" {
+		t.Errorf("expected only one.png on disk, got %v", entries)
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_Parallel은 작업자 수만큼 병렬로 받고 결과 순서를 유지하는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_Parallel(t *testing.T) {
+	var inFlight, peak int32
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return io.Copy(dst, bytes.NewReader([]byte(url)))
+		},
+	}
+	var attachments []domain.Attachment
+	for _, name := range []string{"a.png", "b.png", "c.png", "d.png", "e.png", "f.png"} {
+		attachments = append(attachments, domain.Attachment{Filename: name, MimeType: "image/png", URL: "https://jira/" + name})
+	}
+
+	downloader := NewAttachmentDownloader(jira, t.TempDir())
+	downloader.SetWorkers(3)
+	results, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, nil)
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&peak); got < 2 || got > 3 {
+		t.Errorf("expected 2-3 concurrent downloads, got %d", got)
+	}
+	if len(results) != len(attachments) {
+		t.Fatalf("expected %d results, got %d", len(attachments), len(results))
+	}
+	for i, result := range results {
+		if result.Attachment.Filename != attachments[i].Filename {
+			t.Errorf("result %d: expected %s, got %s", i, attachments[i].Filename, result.Attachment.Filename)
+		}
+		data, err := os.ReadFile(result.LocalPath)
+		if err != nil || string(data) != attachments[i].URL {
+			t.Errorf("result %d: unexpected content %q (%v)", i, data, err)
+		}
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_MaxSize는 크기 상한을 넘는 첨부파일을 건너뛰고
+// 크기 정보가 틀린 경우에도 스트리밍 중에 중단하는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_MaxSize(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			mu.Lock()
+			requested = append(requested, url)
+			mu.Unlock()
+			return io.Copy(dst, bytes.NewReader(make([]byte, 2048)))
+		},
+	}
+	attachments := []domain.Attachment{
+		{Filename: "huge.mp4", MimeType: "video/mp4", Size: 10 << 20, URL: "https://jira/huge"},
+		{Filename: "lying.png", MimeType: "image/png", Size: 100, URL: "https://jira/lying"},
+	}
+	outputDir := t.TempDir()
+
+	downloader := NewAttachmentDownloader(jira, outputDir)
+	downloader.SetMaxSize(1024)
+	results, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, nil)
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+
+	if len(requested) != 1 || requested[0] != "https://jira/lying" {
+		t.Errorf("expected oversized attachment to be skipped before download, requested %v", requested)
+	}
+	for _, result := range results {
+		if !errors.Is(result.Error, ErrAttachmentTooLarge) {
+			t.Errorf("%s: expected ErrAttachmentTooLarge, got %v", result.Attachment.Filename, result.Error)
+		}
+		if result.LocalPath != "" {
+			t.Errorf("%s: expected no local path, got %s", result.Attachment.Filename, result.LocalPath)
+		}
+	}
+	if entries, _ := os.ReadDir(filepath.Join(outputDir, "TEST-1")); len(entries) != 0 {
+		t.Errorf("expected no files on disk, got %v", entries)
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_SettingsSnapshot은 다운로드 도중 설정이 바뀌어도
+// 시작할 때의 크기 제한을 끝까지 적용하는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_SettingsSnapshot(t *testing.T) {
+	var downloader *AttachmentDownloader
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			downloader.SetMaxSize(1)
+			downloader.SetWorkers(1)
+			n, err := dst.Write([]byte("larger than one byte"))
+			return int64(n), err
+		},
+	}
+	attachments := []domain.Attachment{
+		{Filename: "one.png", MimeType: "image/png", URL: "https://jira/1", Size: 20},
+		{Filename: "two.png", MimeType: "image/png", URL: "https://jira/2", Size: 20},
+	}
+
+	downloader = NewAttachmentDownloader(jira, t.TempDir())
+	downloader.SetMaxSize(100)
+	results, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, nil)
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("expected %s to use the limit at start, got %v", result.Attachment.Filename, result.Error)
+		}
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_Progress는 파일별 진행률과 완료 개수를 보고하는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_Progress(t *testing.T) {
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			// WriterTo를 숨겨 실제 네트워크처럼 여러 번에 나눠 쓰게 한다
+			return io.Copy(dst, struct{ io.Reader }{bytes.NewReader(make([]byte, 2*downloadProgressStep))})
+		},
+	}
+	attachments := []domain.Attachment{
+		{Filename: "a.mp4", MimeType: "video/mp4", Size: 2 * downloadProgressStep, URL: "https://jira/a"},
+		{Filename: "b.mp4", MimeType: "video/mp4", Size: 2 * downloadProgressStep, URL: "https://jira/b"},
+		{Filename: "setup.exe", MimeType: "application/octet-stream", URL: "https://jira/setup"},
+	}
+
+	var reports []domain.DownloadProgress
+	downloader := NewAttachmentDownloader(jira, t.TempDir())
+	downloader.SetWorkers(1)
+	_, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, func(p domain.DownloadProgress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+
+	if len(reports) == 0 {
+		t.Fatal("expected progress reports")
+	}
+	sawPartial := false
+	for _, p := range reports {
+		if p.Total != 2 {
+			t.Errorf("expected total 2 media files, got %+v", p)
+		}
+		if p.BytesDone > 0 && p.BytesDone < p.BytesTotal {
+			sawPartial = true
+		}
+	}
+	if !sawPartial {
+		t.Errorf("expected an in-progress byte report, got %+v", reports)
+	}
+	if last := reports[len(reports)-1]; last.Completed != 2 || last.Filename != "b.mp4" {
+		t.Errorf("expected final report for b.mp4 with 2 completed, got %+v", last)
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_Cache는 다시 실행하거나 다른 채널에서 같은 이슈를 받을 때
+// Jira에 다시 요청하지 않고 캐시에서 파일을 가져오는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_Cache(t *testing.T) {
+	var calls int32
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			atomic.AddInt32(&calls, 1)
+			return io.Copy(dst, bytes.NewReader([]byte("video-bytes")))
+		},
+	}
+	attachments := []domain.Attachment{
+		{ID: "10001", Filename: "clip.mp4", MimeType: "video/mp4", Size: 11, URL: "https://jira/10001"},
+	}
+	outputDir := t.TempDir()
+	downloader := NewAttachmentDownloader(jira, outputDir)
+	downloader.SetCache(NewAttachmentCache(filepath.Join(outputDir, ".cache", "attachments")))
+
+	first, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, nil)
+	if err != nil {
+		t.Fatalf("first DownloadAll failed: %v", err)
+	}
+	if first[0].FromCache || first[0].ContentHash == "" {
+		t.Fatalf("expected a fresh download with content hash, got %+v", first[0])
+	}
+
+	// 같은 이슈 재실행과 다른 출력 위치(다른 채널)로의 복사 모두 캐시를 사용해야 한다
+	for _, issueKey := range []string{"TEST-1", "TEST-1-copy"} {
+		results, err := downloader.DownloadAll(context.Background(), issueKey, attachments, nil)
+		if err != nil {
+			t.Fatalf("DownloadAll(%s) failed: %v", issueKey, err)
+		}
+		if !results[0].FromCache || results[0].ContentHash != first[0].ContentHash {
+			t.Errorf("%s: expected cache hit with same hash, got %+v", issueKey, results[0])
+		}
+		if data, err := os.ReadFile(results[0].LocalPath); err != nil || string(data) != "video-bytes" {
+			t.Errorf("%s: unexpected content %q (%v)", issueKey, data, err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected a single download, got %d", got)
+	}
+
+	// 크기가 바뀌면 다른 첨부파일로 보고 다시 받는다
+	attachments[0].Size = 12
+	if _, err := downloader.DownloadAll(context.Background(), "TEST-1", attachments, nil); err != nil {
+		t.Fatalf("DownloadAll after size change failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected re-download after size change, got %d downloads", got)
+	}
+}
+
+// TestAttachmentDownloader_DownloadAll_SafeNames는 경로 조작이 담긴 파일명이 이슈 폴더 밖에 쓰이지 않고
+// 같은 이름의 첨부파일이 서로 덮어쓰지 않는지 검증한다.
+func TestAttachmentDownloader_DownloadAll_SafeNames(t *testing.T) {
+	jira := &mock.JiraRepository{
+		DownloadAttachmentFunc: func(ctx context.Context, url string, dst io.Writer) (int64, error) {
+			return io.Copy(dst, bytes.NewReader([]byte(url)))
+		},
+	}
+	attachments := []domain.Attachment{
+		{ID: "1", Filename: "../../evil.png", MimeType: "image/png", URL: "https://jira/1"},
+		{ID: "2", Filename: "shot.png", MimeType: "image/png", URL: "https://jira/2"},
+		{ID: "3", Filename: "shot.png", MimeType: "image/png", URL: "https://jira/3"},
+		{ID: "4", Filename: "SHOT.png", MimeType: "image/png", URL: "https://jira/4"},
+	}
+	outputDir := t.TempDir()
+
+	results, err := NewAttachmentDownloader(jira, outputDir).DownloadAll(context.Background(), "TEST-1", attachments, nil)
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+
+	issueDir := filepath.Join(outputDir, "TEST-1")
+	want := []string{"_.._evil.png", "shot.png", "3_shot.png", "4_SHOT.png"}
+	for i, result := range results {
+		if filepath.Dir(result.LocalPath) != issueDir {
+			t.Errorf("%s: written outside the issue folder: %s", attachments[i].Filename, result.LocalPath)
+		}
+		if got := filepath.Base(result.LocalPath); got != want[i] {
+			t.Errorf("%s: expected local name %s, got %s", attachments[i].Filename, want[i], got)
+		}
+		if data, _ := os.ReadFile(result.LocalPath); string(data) != attachments[i].URL {
+			t.Errorf("%s: clobbered content %q", attachments[i].Filename, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "evil.png")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing written outside the issue folder, got %v", err)
+	}
+}
+
+// TestAssignLocalNames_ReservedAndVideoStems는 생성 문서/디렉토리 이름을 피하고,
+// 확장자만 다른 동영상이 같은 프레임 이름을 쓰지 않도록 이름을 나누는지 검증한다.
+func TestAssignLocalNames_ReservedAndVideoStems(t *testing.T) {
+	attachments := []domain.Attachment{
+		{ID: "1", Filename: "TEST-1_plan.md", MimeType: "text/markdown"},
+		{ID: "2", Filename: "frames", MimeType: "text/plain"},
+		{ID: "3", Filename: "a.mp4", MimeType: "video/mp4"},
+		{ID: "4", Filename: "a.mov", MimeType: "video/quicktime"},
+		{ID: "5", Filename: "a.png", MimeType: "image/png"},
+		{Filename: "A.webm", MimeType: "video/webm"},
+	}
+
+	got := assignLocalNames("TEST-1", attachments)
+
+	want := []string{"1_TEST-1_plan.md", "2_frames", "a.mp4", "4_a.mov", "a.png", "A (2).webm"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s: expected local name %s, got %s", attachments[i].Filename, want[i], got[i])
+		}
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	long := strings.Repeat("가", 100) + ".png"
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"shot.png", "shot.png"},
+		{"..", "attachment"},
+		{"", "attachment"},
+		{".hidden", "hidden"},
+		{`C:\temp\a.png`, "C__temp_a.png"},
+		{"a\x00b?.png", "a_b_.png"},
+		{"trailing. ", "trailing"},
+	}
+	for _, tt := range tests {
+		if got := safeFilename(tt.name); got != tt.want {
+			t.Errorf("safeFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := safeFilename(long); len(got) > maxFilenameBytes || !strings.HasSuffix(got, ".png") || !utf8.ValidString(got) {
+		t.Errorf("expected long name truncated to %d bytes keeping extension, got %q (%d bytes)", maxFilenameBytes, got, len(got))
+	}
+}
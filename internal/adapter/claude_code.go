@@ -8,7 +8,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/logger"
 )
 
@@ -20,27 +22,25 @@ const (
 	PhaseExecute                      // Phase 2: 계획 실행 → _execution.md 생성
 )
 
-// AnalysisResult contains the result of starting an analysis
-type AnalysisResult struct {
-	OutputPath string
-	ScriptPath string
-	PID        int
-}
+// timestampLayout is the time format written into result files
+const timestampLayout = "2006-01-02 15:04:05"
+
+// ClaudeCodeAdapter implements port.AIAnalyzer with the Claude Code CLI
+type ClaudeCodeAdapter struct {
+	cliPath string
+	enabled bool
 
-// PlanResult는 Phase 1 (분석 및 계획 생성) 결과를 담는 구조체
-type PlanResult struct {
-	PlanPath   string // _plan.md 파일 경로
-	ScriptPath string // 실행 스크립트 경로
-	LogPath    string // 로그 파일 경로
-	PID        int    // 백그라운드 프로세스 ID
+	mu             sync.RWMutex // 설정 화면에서 실행을 시작하는 도중에 바뀔 수 있음
+	model          string
+	hookScriptPath string
+	outputFormat   string // OutputFormatStreamJSON 또는 OutputFormatText
 }
 
-// ClaudeCodeAdapter implements Claude Code CLI integration
-type ClaudeCodeAdapter struct {
-	cliPath        string
-	enabled        bool
+// claudeRunConfig is the snapshot of the settings used to start one run
+type claudeRunConfig struct {
 	model          string
 	hookScriptPath string
+	outputFormat   string
 }
 
 // NewClaudeCodeAdapter creates a new Claude Code adapter
@@ -53,6 +53,7 @@ func NewClaudeCodeAdapter(cliPath string, enabled bool, model, hookScriptPath st
 		enabled:        enabled,
 		model:          model,
 		hookScriptPath: hookScriptPath,
+		outputFormat:   OutputFormatStreamJSON,
 	}
 }
 
@@ -73,29 +74,87 @@ func IsHookConfigurationError(err error) bool {
 
 // GetModel returns the configured model
 func (c *ClaudeCodeAdapter) GetModel() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.model
 }
 
 // SetModel updates the model
 func (c *ClaudeCodeAdapter) SetModel(model string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.model = model
 }
 
 // SetHookScriptPath updates the project-specific hook script path.
 func (c *ClaudeCodeAdapter) SetHookScriptPath(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.hookScriptPath = path
 }
 
 // GetHookScriptPath returns the configured hook script path.
 func (c *ClaudeCodeAdapter) GetHookScriptPath() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.hookScriptPath
 }
 
+// SetOutputFormat sets the CLI output format (OutputFormatStreamJSON 또는 OutputFormatText, 그 외 값은 stream-json)
+func (c *ClaudeCodeAdapter) SetOutputFormat(format string) {
+	if format != OutputFormatText {
+		format = OutputFormatStreamJSON
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputFormat = format
+}
+
+// GetOutputFormat returns the configured CLI output format
+func (c *ClaudeCodeAdapter) GetOutputFormat() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.outputFormat
+}
+
+// Name returns the backend name
+func (c *ClaudeCodeAdapter) Name() string {
+	return domain.AIBackendClaude
+}
+
 // IsEnabled returns whether Claude integration is enabled
 func (c *ClaudeCodeAdapter) IsEnabled() bool {
 	return c.enabled
 }
 
+// Cancel stops the Claude CLI process of a run
+func (c *ClaudeCodeAdapter) Cancel(run *domain.AIRun) error {
+	return cancelAgentRun(run)
+}
+
+// RunStatus reads the status file of a run
+func (c *ClaudeCodeAdapter) RunStatus(run *domain.AIRun) (*domain.AIRunStatus, error) {
+	return agentRunStatus(run)
+}
+
+// runConfig returns the settings for a new run.
+// 실행 하나는 시작할 때 읽은 값만 사용하여 CLI 인자와 출력 형식이 어긋나지 않게 한다.
+func (c *ClaudeCodeAdapter) runConfig() claudeRunConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return claudeRunConfig{model: c.model, hookScriptPath: c.hookScriptPath, outputFormat: c.outputFormat}
+}
+
+// cliArgs returns the CLI arguments shared by every run (프롬프트 제외).
+// --print와 stream-json을 함께 쓰려면 --verbose가 필요하다.
+func (cfg claudeRunConfig) cliArgs(settingsPath string) []string {
+	args := []string{"--settings", settingsPath, "--model", cfg.model, "--output-format", cfg.outputFormat}
+	if cfg.outputFormat == OutputFormatStreamJSON {
+		args = append(args, "--verbose")
+	}
+	return args
+}
+
 // resolveWorkDir은 workDir을 절대 경로로 변환한다. 비어있으면 에러를 반환한다.
 func resolveWorkDir(workDir string) (string, error) {
 	if workDir == "" {
@@ -136,12 +195,12 @@ func validatePathForShell(path string) error {
 }
 
 // prepareHookSettingsFile은 프로젝트 전용 Hook 스크립트를 검증하고 임시 settings 파일을 생성한다.
-func (c *ClaudeCodeAdapter) prepareHookSettingsFile(settingsPath string) error {
-	if strings.TrimSpace(c.hookScriptPath) == "" {
+func prepareHookSettingsFile(hookScriptPath, settingsPath string) error {
+	if strings.TrimSpace(hookScriptPath) == "" {
 		return &HookConfigurationError{Reason: "hook_script_path가 비어 있습니다. 설정에서 Hook 스크립트 경로를 입력해주세요"}
 	}
 
-	absHookPath, err := filepath.Abs(strings.TrimSpace(c.hookScriptPath))
+	absHookPath, err := filepath.Abs(strings.TrimSpace(hookScriptPath))
 	if err != nil {
 		return &HookConfigurationError{Reason: fmt.Sprintf("Hook 스크립트 절대 경로 변환 실패: %v", err)}
 	}
@@ -201,8 +260,8 @@ func (c *ClaudeCodeAdapter) prepareHookSettingsFile(settingsPath string) error {
 	return nil
 }
 
-// AnalyzeIssue launches Claude as a detached background process
-func (c *ClaudeCodeAdapter) AnalyzeIssue(mdFilePath, prompt, workDir string) (*AnalysisResult, error) {
+// AnalyzeIssue starts Claude in the background and writes the result to <md>_analysis.md when it exits
+func (c *ClaudeCodeAdapter) AnalyzeIssue(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
 	defer logger.DebugFunc("AnalyzeIssue")()
 	logger.Debug("AnalyzeIssue: mdPath=%s, workDir=%s", mdFilePath, workDir)
 
@@ -210,6 +269,7 @@ func (c *ClaudeCodeAdapter) AnalyzeIssue(mdFilePath, prompt, workDir string) (*A
 		logger.Debug("AnalyzeIssue: Claude integration is not enabled")
 		return nil, fmt.Errorf("Claude integration is not enabled")
 	}
+	cfg := c.runConfig()
 
 	effectiveDir, err := resolveWorkDir(workDir)
 	if err != nil {
@@ -230,100 +290,48 @@ func (c *ClaudeCodeAdapter) AnalyzeIssue(mdFilePath, prompt, workDir string) (*A
 	}
 
 	// Output path for analysis result
-	outputPath := strings.TrimSuffix(mdFilePath, ".md") + "_analysis.md"
-
-	// Create a temporary prompt file (to avoid shell escaping issues)
-	promptFile := strings.TrimSuffix(mdFilePath, ".md") + "_prompt.txt"
-	settingsPath := strings.TrimSuffix(mdFilePath, ".md") + "_settings.json"
-	fullPrompt := fmt.Sprintf("%s\n\n---\n%s", prompt, string(mdContent))
-	if err := os.WriteFile(promptFile, []byte(fullPrompt), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write prompt file: %w", err)
-	}
-	if err := c.prepareHookSettingsFile(settingsPath); err != nil {
+	basePath := strings.TrimSuffix(mdFilePath, ".md")
+	outputPath := basePath + "_analysis.md"
+	settingsPath := basePath + "_settings.json"
+	if err := prepareHookSettingsFile(cfg.hookScriptPath, settingsPath); err != nil {
 		return nil, err
 	}
 
-	// Create a wrapper script for background execution
-	// Create wrapper script - use file content directly as argument
-	scriptPath := strings.TrimSuffix(mdFilePath, ".md") + "_run.sh"
-	logFile := strings.TrimSuffix(mdFilePath, ".md") + "_log.txt"
-	scriptContent := fmt.Sprintf(`#!/bin/bash
-exec > "%s" 2>&1
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Starting Claude analysis..."
-echo "Working directory: %s"
-cd "%s"
-echo "Prompt file: %s"
-echo "Output file: %s"
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Running Claude..."
-%s --settings '%s' --model %s --print "$(cat '%s')" --output-format text > /tmp/claude_output_$$.txt 2>&1
-CLAUDE_EXIT=$?
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Claude exited with code: $CLAUDE_EXIT"
-echo "Output size: $(wc -c < /tmp/claude_output_$$.txt) bytes"
-echo ""
-echo "=== Claude Output ==="
-cat /tmp/claude_output_$$.txt
-echo "=== End Output ==="
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Writing final output..."
-
-echo "# Claude 분석 결과" > "%s"
-echo "" >> "%s"
-echo "📅 생성 시간: $(date '+%%Y-%%m-%%d %%H:%%M:%%S')" >> "%s"
-echo "📁 프로젝트: %s" >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-if [ $CLAUDE_EXIT -ne 0 ]; then
-    echo "❌ Claude 오류 발생 (exit code: $CLAUDE_EXIT)" >> "%s"
-    echo "" >> "%s"
-fi
-cat /tmp/claude_output_$$.txt >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-echo "✅ 분석 완료: $(date '+%%Y-%%m-%%d %%H:%%M:%%S')" >> "%s"
-
-rm -f /tmp/claude_output_$$.txt "%s" "%s" "%s"
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Done!"
-`, logFile, effectiveDir, effectiveDir, promptFile, outputPath, c.cliPath, settingsPath, c.model, promptFile, outputPath, outputPath, outputPath, effectiveDir, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, outputPath, promptFile, scriptPath, settingsPath)
-
-	if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
-		return nil, fmt.Errorf("failed to write script: %w", err)
-	}
-
-	// Launch script as a completely detached background process
-	cmd := exec.Command("nohup", "bash", scriptPath)
-	cmd.Dir = effectiveDir
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start background process: %w", err)
-	}
-
-	// Don't wait for the process - let it run independently
-	go func() {
-		cmd.Wait() // Clean up process but don't block
-	}()
+	status, err := startAgentRun(agentRun{
+		agent:      "Claude",
+		label:      "분석",
+		cliPath:    c.cliPath,
+		args:       cfg.cliArgs(settingsPath),
+		promptArgs: []string{"--print", fmt.Sprintf("%s\n\n---\n%s", prompt, string(mdContent))},
+		format:     cfg.outputFormat,
+		workDir:    effectiveDir,
+		basePath:   basePath,
+		outputPath: outputPath,
+		cleanup:    []string{settingsPath},
+		kind:       runKindAnalysis,
+		docAgent:   "Claude",
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Printf("[Claude] Background process started (PID: %d)\n", cmd.Process.Pid)
+	fmt.Printf("[Claude] Background process started (PID: %d)\n", status.PID)
 	fmt.Printf("[Claude] Results will be saved to: %s\n", outputPath)
-	fmt.Printf("[Claude] Log file: %s\n", logFile)
-	fmt.Printf("[Claude] You can close this app - analysis will continue in background.\n")
+	fmt.Printf("[Claude] Status file: %s\n", basePath+"_status.json")
 
-	logger.Debug("AnalyzeIssue: completed successfully, PID=%d, output=%s", cmd.Process.Pid, outputPath)
+	logger.Debug("AnalyzeIssue: completed successfully, PID=%d, output=%s", status.PID, outputPath)
 
-	// Return the result with PID and paths
-	return &AnalysisResult{
+	return &domain.AIRun{
+		Backend:    domain.AIBackendClaude,
 		OutputPath: outputPath,
-		ScriptPath: scriptPath,
-		PID:        cmd.Process.Pid,
+		StatusPath: basePath + "_status.json",
+		LogPath:    status.StderrPath,
+		PID:        status.PID,
 	}, nil
 }
 
 // SendToClaudeAsync sends the analysis request asynchronously
-func (c *ClaudeCodeAdapter) SendToClaudeAsync(mdFilePath, prompt, workDir string, onComplete func(*AnalysisResult, error)) {
+func (c *ClaudeCodeAdapter) SendToClaudeAsync(mdFilePath, prompt, workDir string, onComplete func(*domain.AIRun, error)) {
 	go func() {
 		result, err := c.AnalyzeIssue(mdFilePath, prompt, workDir)
 		if onComplete != nil {
@@ -452,10 +460,15 @@ func BuildAnalysisPlanPrompt(issueKey, mdPath string) string {
 		issueKey, mdPath)
 }
 
+// GeneratePlan implements port.AIAnalyzer with AnalyzeAndGeneratePlan
+func (c *ClaudeCodeAdapter) GeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
+	return c.AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir)
+}
+
 // AnalyzeAndGeneratePlan은 Phase 1: 읽기 전용 분석을 실행하고 _plan.md를 생성한다.
 // 기존 AnalyzeIssue와 유사하지만, 결과를 Jira 컨텍스트 + 분석 결과 + 실행 지시사항으로
 // 구조화된 plan 파일로 조립한다.
-func (c *ClaudeCodeAdapter) AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir string) (*PlanResult, error) {
+func (c *ClaudeCodeAdapter) AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
 	defer logger.DebugFunc("AnalyzeAndGeneratePlan")()
 	logger.Debug("AnalyzeAndGeneratePlan: mdPath=%s, workDir=%s", mdFilePath, workDir)
 
@@ -463,6 +476,7 @@ func (c *ClaudeCodeAdapter) AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir s
 		logger.Debug("AnalyzeAndGeneratePlan: Claude integration is not enabled")
 		return nil, fmt.Errorf("Claude integration is not enabled")
 	}
+	cfg := c.runConfig()
 
 	effectiveDir, err := resolveWorkDir(workDir)
 	if err != nil {
@@ -485,77 +499,48 @@ func (c *ClaudeCodeAdapter) AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir s
 	// 파일 경로 설정
 	basePath := strings.TrimSuffix(mdFilePath, ".md")
 	planPath := basePath + "_plan.md"
-	promptFile := basePath + "_plan_prompt.txt"
 	settingsPath := basePath + "_plan_settings.json"
-	scriptPath := basePath + "_plan_run.sh"
-	logFile := basePath + "_plan_log.txt"
-
-	// 프롬프트 파일 작성
-	fullPrompt := fmt.Sprintf("%s\n\n---\n%s", prompt, string(mdContent))
-	if err := os.WriteFile(promptFile, []byte(fullPrompt), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write prompt file: %w", err)
+	if err := prepareHookSettingsFile(cfg.hookScriptPath, settingsPath); err != nil {
+		return nil, err
 	}
-	if err := c.prepareHookSettingsFile(settingsPath); err != nil {
+
+	// Claude 실행 → 종료 후 Jira 컨텍스트 + 분석 결과 + 실행 지시사항을 plan 파일로 조립
+	status, err := startAgentRun(agentRun{
+		agent:      "Claude",
+		label:      "Phase 1",
+		cliPath:    c.cliPath,
+		args:       cfg.cliArgs(settingsPath),
+		promptArgs: []string{"--print", fmt.Sprintf("%s\n\n---\n%s", prompt, string(mdContent))},
+		format:     cfg.outputFormat,
+		workDir:    effectiveDir,
+		basePath:   basePath + "_plan",
+		outputPath: planPath,
+		cleanup:    []string{settingsPath},
+		kind:       runKindPlan,
+		docAgent:   "Claude Code",
+		sourcePath: mdFilePath,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// 래퍼 스크립트 생성: Claude 실행 → 결과를 plan 파일로 조립
-	scriptContent := fmt.Sprintf(`#!/bin/bash
-exec > "%s" 2>&1
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Phase 1: 분석 및 계획 생성 시작..."
-echo "Working directory: %s"
-cd "%s"
-echo "Prompt file: %s"
-echo "Plan file: %s"
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Running Claude (Phase 1 - 분석)..."
-%s --settings '%s' --model %s --print "$(cat '%s')" --output-format text > /tmp/claude_plan_$$.txt 2>&1
-CLAUDE_EXIT=$?
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Claude exited with code: $CLAUDE_EXIT"
-echo "Output size: $(wc -c < /tmp/claude_plan_$$.txt) bytes"
-echo ""
-echo "=== Claude Output ==="
-cat /tmp/claude_plan_$$.txt
-echo "=== End Output ==="
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Building plan file..."
-
-# plan 파일 헤더 작성
-cat > "%s" << 'PLAN_HEADER'
-# Claude Code 실행 계획
-
-> 이 파일은 Claude Code에 직접 전달하여 자동 수정을 실행할 수 있는 구조화된 계획입니다.
-> 아래 "실행 지시사항" 섹션의 지침에 따라 코드를 수정하세요.
-
-PLAN_HEADER
-
-# Jira 이슈 컨텍스트 추가
-echo "## Jira 이슈 컨텍스트" >> "%s"
-echo "" >> "%s"
-cat "%s" >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-
-# AI 분석 결과 추가
-echo "## AI 분석 결과" >> "%s"
-echo "" >> "%s"
-echo "생성 시간: $(date '+%%Y-%%m-%%d %%H:%%M:%%S')" >> "%s"
-echo "프로젝트: %s" >> "%s"
-echo "" >> "%s"
-if [ $CLAUDE_EXIT -ne 0 ]; then
-    echo "⚠️ Claude 분석 중 오류 발생 (exit code: $CLAUDE_EXIT)" >> "%s"
-    echo "" >> "%s"
-fi
-# bkit Feature Usage 섹션 제거 (─────로 시작하는 블록)
-sed '/^─\{5,\}/,/^─\{5,\}$/d' /tmp/claude_plan_$$.txt >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-
-# 실행 지시사항 추가
-cat >> "%s" << 'EXEC_SECTION'
+	fmt.Printf("[Claude] Phase 1 시작됨 (PID: %d)\n", status.PID)
+	fmt.Printf("[Claude] Plan 파일: %s\n", planPath)
+	fmt.Printf("[Claude] 상태 파일: %s\n", basePath+"_plan_status.json")
+
+	logger.Debug("AnalyzeAndGeneratePlan: completed successfully, PID=%d, planPath=%s", status.PID, planPath)
+
+	return &domain.AIRun{
+		Backend:    domain.AIBackendClaude,
+		OutputPath: planPath,
+		StatusPath: basePath + "_plan_status.json",
+		LogPath:    status.StderrPath,
+		PID:        status.PID,
+	}, nil
+}
 
+// planExecutionSection is appended to every plan file so it can be handed to the agent as-is
+const planExecutionSection = `
 ## 실행 지시사항
 
 위 분석 결과를 바탕으로 다음을 수행하세요:
@@ -570,63 +555,92 @@ cat >> "%s" << 'EXEC_SECTION'
 - 불필요한 리팩토링은 하지 마세요.
 - 수정할 수 없는 항목은 이유를 설명하세요.
 
-EXEC_SECTION
-
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Plan file created: %s"
-rm -f /tmp/claude_plan_$$.txt "%s" "%s"
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Phase 1 완료!"
-`,
-		logFile, effectiveDir, effectiveDir, promptFile, planPath,
-		c.cliPath, settingsPath, c.model, promptFile,
-		planPath,
-		planPath, planPath, mdFilePath, planPath, planPath, planPath, planPath,
-		planPath, planPath, planPath, effectiveDir, planPath, planPath,
-		planPath, planPath, planPath, planPath, planPath,
-		planPath,
-		planPath, planPath,
-		promptFile, settingsPath)
-
-	if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
-		return nil, fmt.Errorf("failed to write script: %w", err)
-	}
+`
+
+// buildAnalysisDocument assembles the _analysis.md file from the agent's response
+func buildAnalysisDocument(agent, workDir string, status *RunStatus, stdout, stderr []byte) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s 분석 결과\n\n", agent)
+	fmt.Fprintf(&b, "📅 생성 시간: %s\n", status.FinishedAt.Format(timestampLayout))
+	fmt.Fprintf(&b, "📁 프로젝트: %s\n\n---\n\n", workDir)
+	b.WriteString(failureNote("❌", agent, status, stderr))
+	b.WriteString(responseText(status.OutputFormat, stdout))
+	fmt.Fprintf(&b, "\n---\n\n✅ 분석 완료: %s\n", status.FinishedAt.Format(timestampLayout))
+	return []byte(b.String())
+}
 
-	// 백그라운드 프로세스로 실행
-	cmd := exec.Command("nohup", "bash", scriptPath)
-	cmd.Dir = effectiveDir
-	cmd.Stdout = nil
-	cmd.Stderr = nil
+// buildPlanDocument assembles the _plan.md file: 헤더, Jira 이슈 컨텍스트, AI 분석 결과, 실행 지시사항
+func buildPlanDocument(agent, issueMarkdown, workDir string, status *RunStatus, stdout, stderr []byte) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s 실행 계획\n\n", agent)
+	fmt.Fprintf(&b, "> 이 파일은 %s에 직접 전달하여 자동 수정을 실행할 수 있는 구조화된 계획입니다.\n", agent)
+	b.WriteString("> 아래 \"실행 지시사항\" 섹션의 지침에 따라 코드를 수정하세요.\n\n")
+
+	b.WriteString("## Jira 이슈 컨텍스트\n\n")
+	b.WriteString(issueMarkdown)
+	b.WriteString("\n---\n\n")
+
+	b.WriteString("## AI 분석 결과\n\n")
+	fmt.Fprintf(&b, "생성 시간: %s\n", status.FinishedAt.Format(timestampLayout))
+	fmt.Fprintf(&b, "프로젝트: %s\n\n", workDir)
+	b.WriteString(failureNote("⚠️", agent, status, stderr))
+	b.WriteString(stripFeatureUsageBlocks(responseText(status.OutputFormat, stdout)))
+	b.WriteString("\n---\n\n")
+
+	b.WriteString(planExecutionSection)
+	return []byte(b.String())
+}
 
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start background process: %w", err)
-	}
+// buildExecutionDocument assembles the _execution.md file from the agent's response
+func buildExecutionDocument(agent, workDir string, status *RunStatus, stdout, stderr []byte) []byte {
+	var b strings.Builder
+	b.WriteString("# 실행 결과\n\n")
+	fmt.Fprintf(&b, "📅 생성 시간: %s\n", status.FinishedAt.Format(timestampLayout))
+	fmt.Fprintf(&b, "📁 프로젝트: %s\n", workDir)
+	if status.ResumedSession != "" {
+		fmt.Fprintf(&b, "🔁 이어서 실행한 세션: %s\n", status.ResumedSession)
+	}
+	b.WriteString("\n---\n\n")
+	b.WriteString(failureNote("❌", agent, status, stderr))
+	b.WriteString(responseText(status.OutputFormat, stdout))
+	fmt.Fprintf(&b, "\n---\n\n✅ 실행 완료: %s\n", status.FinishedAt.Format(timestampLayout))
+	return []byte(b.String())
+}
 
-	go func() {
-		cmd.Wait()
-	}()
+// BuildResumePlanPrompt는 플랜을 생성한 세션을 이어서 실행할 때 전달하는 프롬프트를 생성한다.
+// 분석 내용은 세션에 남아 있으므로 plan 파일 전체 대신 경로와 실행 지시사항만 보낸다.
+func BuildResumePlanPrompt(planPath string) string {
+	return fmt.Sprintf(`앞에서 작성한 분석 결과와 수정 계획을 그대로 적용해주세요.
 
-	fmt.Printf("[Claude] Phase 1 시작됨 (PID: %d)\n", cmd.Process.Pid)
-	fmt.Printf("[Claude] Plan 파일: %s\n", planPath)
-	fmt.Printf("[Claude] 로그 파일: %s\n", logFile)
+계획 파일: %s
+(계획 파일이 이후에 수정되었을 수 있으니, 분석 내용과 다른 부분이 있으면 계획 파일을 따르세요.)
+%s`, planPath, planExecutionSection)
+}
 
-	logger.Debug("AnalyzeAndGeneratePlan: completed successfully, PID=%d, planPath=%s", cmd.Process.Pid, planPath)
+// ExecutePlan은 Phase 2: plan 파일을 Claude Code에 전달하여 실제 코드 수정을 실행한다.
+func (c *ClaudeCodeAdapter) ExecutePlan(planPath, workDir string) (*domain.AIRun, error) {
+	return c.executePlan(planPath, "", workDir)
+}
 
-	return &PlanResult{
-		PlanPath:   planPath,
-		ScriptPath: scriptPath,
-		LogPath:    logFile,
-		PID:        cmd.Process.Pid,
-	}, nil
+// ResumePlan은 plan을 생성한 Claude 세션을 --resume으로 이어서 계획을 실행한다.
+// 세션이 없거나 만료되면 CLI가 실패하고 상태 파일에 session_expired가 기록되므로, 호출자는 ExecutePlan으로 다시 실행하면 된다.
+func (c *ClaudeCodeAdapter) ResumePlan(planPath, sessionID, workDir string) (*domain.AIRun, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, fmt.Errorf("session ID is empty")
+	}
+	return c.executePlan(planPath, strings.TrimSpace(sessionID), workDir)
 }
 
-// ExecutePlan은 Phase 2: plan 파일을 Claude Code에 전달하여 실제 코드 수정을 실행한다.
-func (c *ClaudeCodeAdapter) ExecutePlan(planPath, workDir string) (*AnalysisResult, error) {
+// executePlan은 sessionID가 비어 있으면 plan 파일 전체를, 아니면 세션을 이어서 실행 지시만 전달한다.
+func (c *ClaudeCodeAdapter) executePlan(planPath, sessionID, workDir string) (*domain.AIRun, error) {
 	defer logger.DebugFunc("ExecutePlan")()
-	logger.Debug("ExecutePlan: planPath=%s, workDir=%s", planPath, workDir)
+	logger.Debug("ExecutePlan: planPath=%s, sessionID=%s, workDir=%s", planPath, sessionID, workDir)
 
 	if !c.enabled {
 		logger.Debug("ExecutePlan: Claude integration is not enabled")
 		return nil, fmt.Errorf("Claude integration is not enabled")
 	}
+	cfg := c.runConfig()
 
 	effectiveDir, err := resolveWorkDir(workDir)
 	if err != nil {
@@ -647,100 +661,55 @@ func (c *ClaudeCodeAdapter) ExecutePlan(planPath, workDir string) (*AnalysisResu
 	// 파일 경로 설정
 	basePath := strings.TrimSuffix(planPath, "_plan.md")
 	executionPath := basePath + "_execution.md"
-	promptFile := basePath + "_exec_prompt.txt"
 	settingsPath := basePath + "_exec_settings.json"
-	scriptPath := basePath + "_exec_run.sh"
-	logFile := basePath + "_exec_log.txt"
-
-	// 프롬프트 파일 작성
-	if err := os.WriteFile(promptFile, planContent, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write prompt file: %w", err)
-	}
-	if err := c.prepareHookSettingsFile(settingsPath); err != nil {
+	if err := prepareHookSettingsFile(cfg.hookScriptPath, settingsPath); err != nil {
 		return nil, err
 	}
 
-	// 래퍼 스크립트 생성
-	scriptContent := fmt.Sprintf(`#!/bin/bash
-exec > "%s" 2>&1
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Phase 2: 계획 실행 시작..."
-echo "Working directory: %s"
-cd "%s"
-echo "Prompt file: %s"
-echo "Output file: %s"
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Running Claude (Phase 2 - 실행)..."
-%s --settings '%s' --model %s --print "$(cat '%s')" --output-format text > /tmp/claude_exec_$$.txt 2>&1
-CLAUDE_EXIT=$?
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Claude exited with code: $CLAUDE_EXIT"
-echo "Output size: $(wc -c < /tmp/claude_exec_$$.txt) bytes"
-echo ""
-echo "=== Claude Output ==="
-cat /tmp/claude_exec_$$.txt
-echo "=== End Output ==="
-echo ""
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Writing execution result..."
-
-echo "# 실행 결과" > "%s"
-echo "" >> "%s"
-echo "📅 생성 시간: $(date '+%%Y-%%m-%%d %%H:%%M:%%S')" >> "%s"
-echo "📁 프로젝트: %s" >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-if [ $CLAUDE_EXIT -ne 0 ]; then
-    echo "❌ Claude 오류 발생 (exit code: $CLAUDE_EXIT)" >> "%s"
-    echo "" >> "%s"
-fi
-cat /tmp/claude_exec_$$.txt >> "%s"
-echo "" >> "%s"
-echo "---" >> "%s"
-echo "" >> "%s"
-echo "✅ 실행 완료: $(date '+%%Y-%%m-%%d %%H:%%M:%%S')" >> "%s"
-
-rm -f /tmp/claude_exec_$$.txt "%s" "%s" "%s"
-echo "[$(date '+%%Y-%%m-%%d %%H:%%M:%%S')] Phase 2 완료!"
-`,
-		logFile, effectiveDir, effectiveDir, promptFile, executionPath,
-		c.cliPath, settingsPath, c.model, promptFile,
-		executionPath, executionPath, executionPath, effectiveDir, executionPath,
-		executionPath, executionPath, executionPath,
-		executionPath, executionPath,
-		executionPath, executionPath, executionPath, executionPath, executionPath,
-		promptFile, scriptPath, settingsPath)
-
-	if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
-		return nil, fmt.Errorf("failed to write script: %w", err)
-	}
-
-	// 백그라운드 프로세스로 실행
-	cmd := exec.Command("nohup", "bash", scriptPath)
-	cmd.Dir = effectiveDir
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start background process: %w", err)
+	args := cfg.cliArgs(settingsPath)
+	prompt := string(planContent)
+	if sessionID != "" {
+		args = append(args, "--resume", sessionID)
+		prompt = BuildResumePlanPrompt(planPath)
+		fmt.Printf("[Claude] 세션 이어서 실행: %s\n", sessionID)
+	}
+
+	status, err := startAgentRun(agentRun{
+		agent:      "Claude",
+		label:      "Phase 2",
+		cliPath:    c.cliPath,
+		args:       args,
+		promptArgs: []string{"--print", prompt},
+		format:     cfg.outputFormat,
+		workDir:    effectiveDir,
+		basePath:   basePath + "_exec",
+		outputPath: executionPath,
+		cleanup:    []string{settingsPath},
+		resumed:    sessionID,
+		kind:       runKindExecution,
+		docAgent:   "Claude",
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	go func() {
-		cmd.Wait()
-	}()
-
-	fmt.Printf("[Claude] Phase 2 시작됨 (PID: %d)\n", cmd.Process.Pid)
+	fmt.Printf("[Claude] Phase 2 시작됨 (PID: %d)\n", status.PID)
 	fmt.Printf("[Claude] 실행 결과: %s\n", executionPath)
 
-	logger.Debug("ExecutePlan: completed successfully, PID=%d, executionPath=%s", cmd.Process.Pid, executionPath)
+	logger.Debug("ExecutePlan: completed successfully, PID=%d, executionPath=%s", status.PID, executionPath)
 
-	return &AnalysisResult{
-		OutputPath: executionPath,
-		ScriptPath: scriptPath,
-		PID:        cmd.Process.Pid,
+	return &domain.AIRun{
+		Backend:        domain.AIBackendClaude,
+		OutputPath:     executionPath,
+		StatusPath:     basePath + "_exec_status.json",
+		LogPath:        status.StderrPath,
+		PID:            status.PID,
+		ResumedSession: sessionID,
 	}, nil
 }
 
 // SendPlanToClaudeAsync는 Phase 1 분석을 비동기적으로 실행한다.
-func (c *ClaudeCodeAdapter) SendPlanToClaudeAsync(mdFilePath, prompt, workDir string, onComplete func(*PlanResult, error)) {
+func (c *ClaudeCodeAdapter) SendPlanToClaudeAsync(mdFilePath, prompt, workDir string, onComplete func(*domain.AIRun, error)) {
 	go func() {
 		result, err := c.AnalyzeAndGeneratePlan(mdFilePath, prompt, workDir)
 		if onComplete != nil {
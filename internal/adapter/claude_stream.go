@@ -0,0 +1,342 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// Claude CLI output formats
+const (
+	OutputFormatText       = "text"        // 종료 후 최종 응답만 출력
+	OutputFormatStreamJSON = "stream-json" // 한 줄에 하나씩 JSON 이벤트를 출력 (진행 상황 실시간 표시)
+)
+
+// OutputFormats lists the selectable output formats
+var OutputFormats = []string{OutputFormatStreamJSON, OutputFormatText}
+
+// StreamEventKind classifies an event parsed from stream-json output
+type StreamEventKind string
+
+const (
+	StreamEventInit       StreamEventKind = "init"        // 세션 시작 (모델, 세션 ID)
+	StreamEventText       StreamEventKind = "text"        // assistant 텍스트
+	StreamEventToolUse    StreamEventKind = "tool_use"    // 도구 호출 (Read, Edit, Bash 등)
+	StreamEventToolResult StreamEventKind = "tool_result" // 도구 실행 결과
+	StreamEventResult     StreamEventKind = "result"      // 최종 결과 (응답, 사용량, 비용)
+)
+
+// StreamEvent is one event of the Claude CLI stream-json output
+type StreamEvent struct {
+	Kind      StreamEventKind
+	SessionID string
+	Model     string                // init
+	Text      string                // text, tool_result, result
+	ToolName  string                // tool_use
+	ToolInput string                // tool_use: 파일 경로, 명령 등 입력 요약
+	IsError   bool                  // tool_result, result
+	Usage     *domain.AnalysisUsage // result: 토큰 사용량, 비용, 소요 시간, 턴 수
+}
+
+// maxSummaryRunes limits how much of a text or tool result is shown in a one-line summary
+const maxSummaryRunes = 160
+
+// Summary describes the event in one line for logs and progress messages
+func (e StreamEvent) Summary() string {
+	switch e.Kind {
+	case StreamEventInit:
+		return fmt.Sprintf("세션 시작 (%s)", e.Model)
+	case StreamEventText:
+		return "💬 " + truncateLine(e.Text)
+	case StreamEventToolUse:
+		if e.ToolInput == "" {
+			return "🔧 " + e.ToolName
+		}
+		return fmt.Sprintf("🔧 %s %s", e.ToolName, truncateLine(e.ToolInput))
+	case StreamEventToolResult:
+		if e.IsError {
+			return "⚠️ 도구 오류: " + truncateLine(e.Text)
+		}
+		return fmt.Sprintf("도구 결과 (%d자)", utf8.RuneCountInString(e.Text))
+	case StreamEventResult:
+		status := "✅ 완료"
+		if e.IsError {
+			status = "❌ 실패"
+		}
+		if e.Usage == nil {
+			return status
+		}
+		return fmt.Sprintf("%s (턴 %d, 입력 %d · 출력 %d 토큰, $%.4f)",
+			status, e.Usage.NumTurns, e.Usage.InputTokens, e.Usage.OutputTokens, e.Usage.CostUSD)
+	}
+	return string(e.Kind)
+}
+
+// truncateLine returns the first line of s, cut to maxSummaryRunes
+func truncateLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i] + " …"
+	}
+	if r := []rune(s); len(r) > maxSummaryRunes {
+		s = string(r[:maxSummaryRunes]) + "…"
+	}
+	return s
+}
+
+// streamMessage is the subset of a stream-json line that we use
+type streamMessage struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	Model     string `json:"model"`
+	Message   struct {
+		Content []streamContent `json:"content"`
+	} `json:"message"`
+
+	// result
+	Result       string  `json:"result"`
+	IsError      bool    `json:"is_error"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	DurationMS   int64   `json:"duration_ms"`
+	NumTurns     int     `json:"num_turns"`
+	Usage        struct {
+		InputTokens              int64 `json:"input_tokens"`
+		OutputTokens             int64 `json:"output_tokens"`
+		CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+	} `json:"usage"`
+}
+
+type streamContent struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Name    string          `json:"name"`
+	Input   json.RawMessage `json:"input"`
+	Content json.RawMessage `json:"content"` // tool_result: 문자열 또는 [{type, text}] 배열
+	IsError bool            `json:"is_error"`
+}
+
+// ParseStreamLine parses one line of stream-json output into events.
+// assistant 메시지 하나에 텍스트와 도구 호출이 함께 있을 수 있어 여러 이벤트를 반환한다.
+// JSON이 아니거나 관심 없는 줄은 nil을 반환한다.
+func ParseStreamLine(line []byte) []StreamEvent {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil
+	}
+	var msg streamMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return nil
+	}
+
+	switch msg.Type {
+	case "system":
+		if msg.Subtype != "init" {
+			return nil
+		}
+		return []StreamEvent{{Kind: StreamEventInit, SessionID: msg.SessionID, Model: msg.Model}}
+	case "assistant":
+		var events []StreamEvent
+		for _, c := range msg.Message.Content {
+			switch c.Type {
+			case "text":
+				if strings.TrimSpace(c.Text) != "" {
+					events = append(events, StreamEvent{Kind: StreamEventText, SessionID: msg.SessionID, Text: c.Text})
+				}
+			case "tool_use":
+				events = append(events, StreamEvent{Kind: StreamEventToolUse, SessionID: msg.SessionID, ToolName: c.Name, ToolInput: summarizeToolInput(c.Input)})
+			}
+		}
+		return events
+	case "user":
+		var events []StreamEvent
+		for _, c := range msg.Message.Content {
+			if c.Type == "tool_result" {
+				events = append(events, StreamEvent{Kind: StreamEventToolResult, SessionID: msg.SessionID, Text: toolResultText(c.Content), IsError: c.IsError})
+			}
+		}
+		return events
+	case "result":
+		return []StreamEvent{{
+			Kind:      StreamEventResult,
+			SessionID: msg.SessionID,
+			Text:      msg.Result,
+			IsError:   msg.IsError || (msg.Subtype != "" && msg.Subtype != "success"),
+			Usage: &domain.AnalysisUsage{
+				InputTokens:         msg.Usage.InputTokens,
+				OutputTokens:        msg.Usage.OutputTokens,
+				CacheCreationTokens: msg.Usage.CacheCreationInputTokens,
+				CacheReadTokens:     msg.Usage.CacheReadInputTokens,
+				CostUSD:             msg.TotalCostUSD,
+				DurationMS:          msg.DurationMS,
+				NumTurns:            msg.NumTurns,
+			},
+		}}
+	}
+	return nil
+}
+
+// toolInputKeys are the input fields that best describe a tool call, in order of preference
+var toolInputKeys = []string{"file_path", "notebook_path", "path", "command", "pattern", "url", "query", "description", "prompt"}
+
+// summarizeToolInput picks the most descriptive field of a tool input (예: Read의 file_path, Bash의 command)
+func summarizeToolInput(raw json.RawMessage) string {
+	var input map[string]interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &input) != nil {
+		return ""
+	}
+	for _, key := range toolInputKeys {
+		if s, ok := input[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// toolResultText flattens tool_result content, which is either a string or a list of text blocks
+func toolResultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var blocks []streamContent
+	if json.Unmarshal(raw, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// responseText returns the final response in the CLI stdout.
+// stream-json이면 result 이벤트의 응답을, result 이벤트가 없으면(중간에 종료된 경우) 그때까지의 assistant 텍스트를 쓰고,
+// JSON 이벤트가 하나도 없으면(CLI 오류 메시지 등) 출력을 그대로 반환한다.
+func responseText(format string, stdout []byte) string {
+	if format != OutputFormatStreamJSON {
+		return string(stdout)
+	}
+	var texts []string
+	parsed := false
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		events := ParseStreamLine(line)
+		for _, e := range events {
+			parsed = true
+			switch e.Kind {
+			case StreamEventResult:
+				if e.Text != "" {
+					return e.Text
+				}
+			case StreamEventText:
+				texts = append(texts, e.Text)
+			}
+		}
+	}
+	if !parsed {
+		return string(stdout)
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// resultUsage returns the usage of the last result event in stream-json stdout (없으면 nil)
+func resultUsage(format string, stdout []byte) *domain.AnalysisUsage {
+	if format != OutputFormatStreamJSON {
+		return nil
+	}
+	var usage *domain.AnalysisUsage
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		for _, e := range ParseStreamLine(line) {
+			if e.Kind == StreamEventResult && e.Usage != nil {
+				usage = e.Usage
+			}
+		}
+	}
+	return usage
+}
+
+// lastResultEvent returns the last result event in stream-json stdout
+func lastResultEvent(stdout []byte) (StreamEvent, bool) {
+	var result StreamEvent
+	found := false
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		for _, e := range ParseStreamLine(line) {
+			if e.Kind == StreamEventResult {
+				result, found = e, true
+			}
+		}
+	}
+	return result, found
+}
+
+// resultSessionID returns the last session ID reported in stream-json stdout (없으면 빈 문자열)
+func resultSessionID(format string, stdout []byte) string {
+	if format != OutputFormatStreamJSON {
+		return ""
+	}
+	sessionID := ""
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		for _, e := range ParseStreamLine(line) {
+			if e.SessionID != "" {
+				sessionID = e.SessionID
+			}
+		}
+	}
+	return sessionID
+}
+
+// StreamTailer reads events appended to a stream-json stdout file while the CLI is running
+type StreamTailer struct {
+	path    string
+	offset  int64
+	partial []byte
+}
+
+// NewStreamTailer creates a tailer that starts at the beginning of path
+func NewStreamTailer(path string) *StreamTailer {
+	return &StreamTailer{path: path}
+}
+
+// Poll returns the events of the lines completed since the last call.
+// 아직 줄바꿈으로 끝나지 않은 마지막 줄은 다음 호출까지 남겨 둔다.
+func (t *StreamTailer) Poll() []StreamEvent {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return nil
+	}
+	data, err := io.ReadAll(f)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	t.offset += int64(len(data))
+
+	data = append(t.partial, data...)
+	last := bytes.LastIndexByte(data, '\n')
+	if last < 0 {
+		t.partial = data
+		return nil
+	}
+	t.partial = append([]byte{}, data[last+1:]...)
+
+	var events []StreamEvent
+	for _, line := range bytes.Split(data[:last], []byte("\n")) {
+		events = append(events, ParseStreamLine(line)...)
+	}
+	return events
+}
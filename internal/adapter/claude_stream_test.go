@@ -0,0 +1,173 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+)
+
+func TestParseStreamLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []StreamEvent
+	}{
+		{
+			name: "init",
+			line: `{"type":"system","subtype":"init","session_id":"s-1","model":"claude-sonnet-4"}`,
+			want: []StreamEvent{{Kind: StreamEventInit, SessionID: "s-1", Model: "claude-sonnet-4"}},
+		},
+		{
+			name: "assistant text and tool call",
+			line: `{"type":"assistant","session_id":"s-1","message":{"content":[{"type":"text","text":"파일을 확인합니다"},{"type":"tool_use","id":"t1","name":"Edit","input":{"file_path":"/src/a.go","old_string":"x"}}]}}`,
+			want: []StreamEvent{
+				{Kind: StreamEventText, SessionID: "s-1", Text: "파일을 확인합니다"},
+				{Kind: StreamEventToolUse, SessionID: "s-1", ToolName: "Edit", ToolInput: "/src/a.go"},
+			},
+		},
+		{
+			name: "bash command",
+			line: `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./...","description":"run tests"}}]}}`,
+			want: []StreamEvent{{Kind: StreamEventToolUse, ToolName: "Bash", ToolInput: "go test ./..."}},
+		},
+		{
+			name: "tool result blocks",
+			line: `{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","is_error":true,"content":[{"type":"text","text":"permission denied"}]}]}}`,
+			want: []StreamEvent{{Kind: StreamEventToolResult, Text: "permission denied", IsError: true}},
+		},
+		{
+			name: "not json",
+			line: "Error: something went wrong",
+		},
+		{
+			name: "other system event",
+			line: `{"type":"system","subtype":"compact_boundary"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseStreamLine([]byte(tt.line))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseStreamLine() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseStreamLine_Result(t *testing.T) {
+	line := `{"type":"result","subtype":"success","is_error":false,"session_id":"s-1","result":"끝","total_cost_usd":0.1234,"duration_ms":5000,"num_turns":3,"usage":{"input_tokens":100,"output_tokens":50,"cache_creation_input_tokens":10,"cache_read_input_tokens":2000}}`
+	events := ParseStreamLine([]byte(line))
+	if len(events) != 1 || events[0].Kind != StreamEventResult {
+		t.Fatalf("ParseStreamLine() = %+v", events)
+	}
+	e := events[0]
+	if e.Text != "끝" || e.IsError || e.SessionID != "s-1" {
+		t.Errorf("result event = %+v", e)
+	}
+	want := domain.AnalysisUsage{InputTokens: 100, OutputTokens: 50, CacheCreationTokens: 10, CacheReadTokens: 2000, CostUSD: 0.1234, DurationMS: 5000, NumTurns: 3}
+	if *e.Usage != want {
+		t.Errorf("Usage = %+v, want %+v", *e.Usage, want)
+	}
+	if got := e.Summary(); got != "✅ 완료 (턴 3, 입력 100 · 출력 50 토큰, $0.1234)" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	failed := ParseStreamLine([]byte(`{"type":"result","subtype":"error_max_turns","is_error":false}`))
+	if len(failed) != 1 || !failed[0].IsError {
+		t.Errorf("error subtype should be reported as error: %+v", failed)
+	}
+}
+
+func TestStreamEvent_Summary(t *testing.T) {
+	tests := []struct {
+		event StreamEvent
+		want  string
+	}{
+		{StreamEvent{Kind: StreamEventToolUse, ToolName: "Read", ToolInput: "/src/a.go"}, "🔧 Read /src/a.go"},
+		{StreamEvent{Kind: StreamEventToolUse, ToolName: "TodoWrite"}, "🔧 TodoWrite"},
+		{StreamEvent{Kind: StreamEventText, Text: "첫 줄\n둘째 줄"}, "💬 첫 줄 …"},
+		{StreamEvent{Kind: StreamEventToolResult, Text: "12345"}, "도구 결과 (5자)"},
+		{StreamEvent{Kind: StreamEventToolResult, Text: "denied", IsError: true}, "⚠️ 도구 오류: denied"},
+	}
+	for _, tt := range tests {
+		if got := tt.event.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestResponseText(t *testing.T) {
+	stream := strings.Join([]string{
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"중간 설명"}]}}`,
+		`{"type":"result","subtype":"success","result":"최종 응답"}`,
+	}, "\n")
+	if got := responseText(OutputFormatStreamJSON, []byte(stream)); got != "최종 응답" {
+		t.Errorf("responseText(result) = %q", got)
+	}
+
+	interrupted := `{"type":"assistant","message":{"content":[{"type":"text","text":"하나"}]}}` + "\n" +
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"둘"}]}}` + "\n"
+	if got := responseText(OutputFormatStreamJSON, []byte(interrupted)); got != "하나\n\n둘" {
+		t.Errorf("responseText(no result) = %q", got)
+	}
+
+	plain := "Error: unknown option --verbose\n"
+	if got := responseText(OutputFormatStreamJSON, []byte(plain)); got != plain {
+		t.Errorf("responseText(plain) = %q, want raw output", got)
+	}
+	if got := responseText(OutputFormatText, []byte(stream)); got != stream {
+		t.Errorf("responseText(text) should return stdout as is")
+	}
+}
+
+func TestResultSessionID(t *testing.T) {
+	stream := strings.Join([]string{
+		`{"type":"system","subtype":"init","session_id":"s-1"}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"설명"}]}}`,
+		`{"type":"result","subtype":"success","session_id":"s-2","result":"끝"}`,
+	}, "\n")
+	if got := resultSessionID(OutputFormatStreamJSON, []byte(stream)); got != "s-2" {
+		t.Errorf("resultSessionID() = %q, want last reported session", got)
+	}
+	if got := resultSessionID(OutputFormatText, []byte(stream)); got != "" {
+		t.Errorf("resultSessionID(text) = %q, want empty", got)
+	}
+}
+
+func TestStreamTailer_Poll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_stdout.txt")
+	tailer := NewStreamTailer(path)
+	if events := tailer.Poll(); events != nil {
+		t.Fatalf("Poll() before file exists = %+v", events)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// 줄 중간까지만 쓰인 이벤트는 다음 Poll까지 보류한다
+	f.WriteString(`{"type":"system","subtype":"init","model":"m"}` + "\n" + `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Gr`)
+	events := tailer.Poll()
+	if len(events) != 1 || events[0].Kind != StreamEventInit {
+		t.Fatalf("first Poll() = %+v", events)
+	}
+
+	f.WriteString(`ep","input":{"pattern":"TODO"}}]}}` + "\n")
+	events = tailer.Poll()
+	if len(events) != 1 || events[0].ToolName != "Grep" || events[0].ToolInput != "TODO" {
+		t.Fatalf("second Poll() = %+v", events)
+	}
+	if events := tailer.Poll(); len(events) != 0 {
+		t.Errorf("Poll() without new data = %+v", events)
+	}
+}
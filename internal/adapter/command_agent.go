@@ -0,0 +1,141 @@
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+)
+
+// commandAgentName is shown in logs and result files of the command agent
+const commandAgentName = "AI 에이전트"
+
+// CommandAgentAdapter implements port.AIAnalyzer with any CLI coding agent driven by a command template.
+//
+// 템플릿의 {prompt_file}은 프롬프트를 담은 파일 경로로, {workdir}은 프로젝트 경로로 바뀐다.
+// {prompt_file}이 없으면 프롬프트를 표준 입력으로 전달하고, 표준 출력 전체를 응답으로 사용한다.
+// Claude와 달리 Hook으로 1차 분석을 읽기 전용으로 강제하지 않는다.
+//
+//	aider --yes-always --no-auto-commits --message-file {prompt_file}
+//	codex exec --cd {workdir} -
+type CommandAgentAdapter struct {
+	mu      sync.RWMutex
+	command string
+}
+
+// NewCommandAgentAdapter creates an agent adapter for the given command template (empty disables it)
+func NewCommandAgentAdapter(command string) *CommandAgentAdapter {
+	return &CommandAgentAdapter{command: strings.TrimSpace(command)}
+}
+
+// SetCommand updates the command template
+func (a *CommandAgentAdapter) SetCommand(command string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.command = strings.TrimSpace(command)
+}
+
+// Name returns the backend name
+func (a *CommandAgentAdapter) Name() string {
+	return domain.AIBackendCLI
+}
+
+// IsEnabled reports whether a command template is configured
+func (a *CommandAgentAdapter) IsEnabled() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.command != ""
+}
+
+// Cancel stops the agent process of a run
+func (a *CommandAgentAdapter) Cancel(run *domain.AIRun) error {
+	return cancelAgentRun(run)
+}
+
+// RunStatus reads the status file of a run
+func (a *CommandAgentAdapter) RunStatus(run *domain.AIRun) (*domain.AIRunStatus, error) {
+	return agentRunStatus(run)
+}
+
+// GeneratePlan runs the agent with the prompt and issue document, then assembles <base>_plan.md
+func (a *CommandAgentAdapter) GeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
+	mdContent, err := os.ReadFile(mdFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MD file: %w", err)
+	}
+	basePath := strings.TrimSuffix(mdFilePath, ".md")
+	return a.start("Phase 1", fmt.Sprintf("%s\n\n---\n%s", prompt, string(mdContent)), workDir, basePath+"_plan", basePath+"_plan.md",
+		runKindPlan, mdFilePath)
+}
+
+// ExecutePlan runs the agent with the plan file as prompt, then assembles <base>_execution.md
+func (a *CommandAgentAdapter) ExecutePlan(planPath, workDir string) (*domain.AIRun, error) {
+	planContent, err := os.ReadFile(planPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	basePath := strings.TrimSuffix(planPath, "_plan.md")
+	return a.start("Phase 2", string(planContent), workDir, basePath+"_exec", basePath+"_execution.md",
+		runKindExecution, "")
+}
+
+// ResumePlan is not supported: 명령 템플릿 에이전트는 세션을 이어갈 방법이 정해져 있지 않다
+func (a *CommandAgentAdapter) ResumePlan(planPath, sessionID, workDir string) (*domain.AIRun, error) {
+	return nil, domain.ErrSessionUnsupported
+}
+
+// start expands the command template and starts the agent in the background.
+// kind는 종료 후 조립할 결과 파일 종류, sourcePath는 plan에 넣을 Jira 이슈 문서다.
+func (a *CommandAgentAdapter) start(label, prompt, workDir, basePath, outputPath, kind, sourcePath string) (*domain.AIRun, error) {
+	a.mu.RLock()
+	command := a.command
+	a.mu.RUnlock()
+	if command == "" {
+		return nil, fmt.Errorf("agent command is not configured")
+	}
+
+	effectiveDir, err := resolveWorkDir(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	run := agentRun{
+		agent:      commandAgentName,
+		label:      label,
+		format:     OutputFormatText,
+		workDir:    effectiveDir,
+		basePath:   basePath,
+		outputPath: outputPath,
+		kind:       kind,
+		docAgent:   commandAgentName,
+		sourcePath: sourcePath,
+	}
+
+	promptPath := basePath + "_prompt.txt"
+	if strings.Contains(command, "{prompt_file}") {
+		if err := os.WriteFile(promptPath, []byte(prompt), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write prompt file: %w", err)
+		}
+		run.cleanup = []string{promptPath}
+	} else {
+		run.stdin = prompt
+	}
+	args := expandCommand(command, map[string]string{"{prompt_file}": promptPath, "{workdir}": effectiveDir})
+	run.cliPath, run.args = args[0], args[1:]
+
+	logger.Debug("CommandAgentAdapter.start: %s, command=%q, workDir=%s", label, args, effectiveDir)
+	status, err := startAgentRun(run)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AIRun{
+		Backend:    domain.AIBackendCLI,
+		OutputPath: outputPath,
+		StatusPath: basePath + "_status.json",
+		LogPath:    status.StderrPath,
+		PID:        status.PID,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+)
+
+func TestCommandAgentAdapter_GeneratePlanWithPromptFile(t *testing.T) {
+	dir := t.TempDir()
+	cli := writeStubCLI(t, dir, `echo "args: $1 $3"
+echo "pwd: $(pwd)"
+cat "$2"
+`)
+	mdPath := filepath.Join(dir, "PROJ-7.md")
+	if err := os.WriteFile(mdPath, []byte("# PROJ-7\n\n로그인 실패\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	agent := NewCommandAgentAdapter(cli + " --message-file {prompt_file} {workdir}")
+	if !agent.IsEnabled() || agent.Name() != domain.AIBackendCLI {
+		t.Fatalf("agent should be enabled with a command")
+	}
+	run, err := agent.GeneratePlan(mdPath, "분석해 주세요", dir)
+	if err != nil {
+		t.Fatalf("GeneratePlan() error = %v", err)
+	}
+	if run.Backend != domain.AIBackendCLI || run.OutputPath != filepath.Join(dir, "PROJ-7_plan.md") || run.PID <= 0 {
+		t.Errorf("run = %+v", run)
+	}
+
+	status := waitRunFinished(t, run.StatusPath)
+	if status.Failed() {
+		t.Fatalf("status = %+v", status)
+	}
+	promptPath := filepath.Join(dir, "PROJ-7_plan_prompt.txt")
+	if strings.Join(status.Args, " ") != "--message-file "+promptPath+" "+dir {
+		t.Errorf("Args = %q", status.Args)
+	}
+	if _, err := os.Stat(promptPath); !os.IsNotExist(err) {
+		t.Errorf("prompt file should be removed after the run, stat err = %v", err)
+	}
+
+	plan, err := os.ReadFile(run.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# AI 에이전트 실행 계획",
+		"pwd: " + dir,
+		"분석해 주세요\n\n---\n# PROJ-7\n\n로그인 실패\n",
+		"## 실행 지시사항",
+	} {
+		if !strings.Contains(string(plan), want) {
+			t.Errorf("plan missing %q:\n%s", want, plan)
+		}
+	}
+}
+
+func TestCommandAgentAdapter_ExecutePlanOverStdin(t *testing.T) {
+	dir := t.TempDir()
+	cli := writeStubCLI(t, dir, `echo "received: $(cat)"
+`)
+	planPath := filepath.Join(dir, "PROJ-8_plan.md")
+	if err := os.WriteFile(planPath, []byte("계획 본문"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := NewCommandAgentAdapter(cli+" exec -").ExecutePlan(planPath, dir)
+	if err != nil {
+		t.Fatalf("ExecutePlan() error = %v", err)
+	}
+	waitRunFinished(t, run.StatusPath)
+
+	out, err := os.ReadFile(filepath.Join(dir, "PROJ-8_execution.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "received: 계획 본문") || !strings.Contains(string(out), "✅ 실행 완료") {
+		t.Errorf("execution result:\n%s", out)
+	}
+}
+
+func TestCommandAgentAdapter_NotConfigured(t *testing.T) {
+	agent := NewCommandAgentAdapter("  ")
+	if agent.IsEnabled() {
+		t.Error("agent without command should be disabled")
+	}
+	if _, err := agent.ExecutePlan(filepath.Join(t.TempDir(), "x_plan.md"), "/tmp"); err == nil {
+		t.Error("expected error without command")
+	}
+	if _, err := agent.ResumePlan("x_plan.md", "s-1", "/tmp"); !errors.Is(err, domain.ErrSessionUnsupported) {
+		t.Errorf("ResumePlan() error = %v, want ErrSessionUnsupported", err)
+	}
+}
@@ -0,0 +1,213 @@
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// fakeAgentName is shown in result files written by FakeAIAnalyzer
+const fakeAgentName = "Fake"
+
+// FakeAIAnalyzer implements port.AIAnalyzer without starting a process, for tests.
+// 실행을 시작하면 고정된 응답으로 결과 파일과 종료된 상태 파일을 곧바로 쓴다 (PID는 0).
+// Hold가 true이면 Finish 또는 Cancel을 호출할 때까지 실행 중 상태로 남겨 둔다.
+// 실행마다 세션 ID(fake-session-N)를 상태 파일에 기록하며, ResumePlan은 이 인스턴스가 만든 세션만 이어서 실행하고
+// 모르는 세션이면 session_expired로 실패한다.
+type FakeAIAnalyzer struct {
+	Response string                // 결과 파일에 넣을 응답
+	Stderr   string                // stderr 파일 내용
+	ExitCode int                   // 0이 아니면 실패한 실행으로 기록
+	Usage    *domain.AnalysisUsage // 상태 파일에 기록할 사용량
+	StartErr error                 // nil이 아니면 실행을 시작하지 않고 반환
+	Hold     bool                  // true이면 Finish 또는 Cancel 전까지 실행 중 상태로 둠
+
+	mu        sync.Mutex
+	runs      []*domain.AIRun
+	cancelled []*domain.AIRun
+	sessions  map[string]bool                              // 발급한 세션 ID
+	pending   map[string]func(exitCode int, errMsg string) // StatusPath → 종료 처리
+}
+
+// NewFakeAIAnalyzer creates a fake analyzer that answers every run with response
+func NewFakeAIAnalyzer(response string) *FakeAIAnalyzer {
+	return &FakeAIAnalyzer{Response: response}
+}
+
+// Name returns the backend name
+func (f *FakeAIAnalyzer) Name() string {
+	return domain.AIBackendFake
+}
+
+// IsEnabled always returns true
+func (f *FakeAIAnalyzer) IsEnabled() bool {
+	return true
+}
+
+// Runs returns the runs started so far, in order
+func (f *FakeAIAnalyzer) Runs() []*domain.AIRun {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*domain.AIRun{}, f.runs...)
+}
+
+// Cancelled returns the runs stopped with Cancel while they were held
+func (f *FakeAIAnalyzer) Cancelled() []*domain.AIRun {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*domain.AIRun{}, f.cancelled...)
+}
+
+// GeneratePlan writes <base>_plan.md with the fixed response
+func (f *FakeAIAnalyzer) GeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
+	mdContent, err := os.ReadFile(mdFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MD file: %w", err)
+	}
+	basePath := strings.TrimSuffix(mdFilePath, ".md")
+	return f.start(basePath+"_plan", basePath+"_plan.md", "", func(status *RunStatus, stdout, stderr []byte) []byte {
+		return buildPlanDocument(fakeAgentName, string(mdContent), workDir, status, stdout, stderr)
+	})
+}
+
+// ExecutePlan writes <base>_execution.md with the fixed response
+func (f *FakeAIAnalyzer) ExecutePlan(planPath, workDir string) (*domain.AIRun, error) {
+	if _, err := os.Stat(planPath); err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	basePath := strings.TrimSuffix(planPath, "_plan.md")
+	return f.start(basePath+"_exec", basePath+"_execution.md", "", func(status *RunStatus, stdout, stderr []byte) []byte {
+		return buildExecutionDocument(fakeAgentName, workDir, status, stdout, stderr)
+	})
+}
+
+// ResumePlan continues a session started by this fake (모르는 세션이면 session_expired로 실패)
+func (f *FakeAIAnalyzer) ResumePlan(planPath, sessionID, workDir string) (*domain.AIRun, error) {
+	if _, err := os.Stat(planPath); err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	basePath := strings.TrimSuffix(planPath, "_plan.md")
+	return f.start(basePath+"_exec", basePath+"_execution.md", sessionID, func(status *RunStatus, stdout, stderr []byte) []byte {
+		return buildExecutionDocument(fakeAgentName, workDir, status, stdout, stderr)
+	})
+}
+
+// Finish completes a held run with the configured exit code
+func (f *FakeAIAnalyzer) Finish(run *domain.AIRun) error {
+	finish := f.takePending(run)
+	if finish == nil {
+		return fmt.Errorf("run is not in progress: %s", run.StatusPath)
+	}
+	finish(f.ExitCode, "")
+	return nil
+}
+
+// Cancel marks a held run as finished by a signal (exit code -1)
+func (f *FakeAIAnalyzer) Cancel(run *domain.AIRun) error {
+	finish := f.takePending(run)
+	if finish == nil {
+		return nil
+	}
+	f.mu.Lock()
+	f.cancelled = append(f.cancelled, run)
+	f.mu.Unlock()
+	finish(-1, "cancelled")
+	return nil
+}
+
+// RunStatus reads the status file written for a run
+func (f *FakeAIAnalyzer) RunStatus(run *domain.AIRun) (*domain.AIRunStatus, error) {
+	return agentRunStatus(run)
+}
+
+func (f *FakeAIAnalyzer) takePending(run *domain.AIRun) func(int, string) {
+	if run == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	finish := f.pending[run.StatusPath]
+	delete(f.pending, run.StatusPath)
+	return finish
+}
+
+func (f *FakeAIAnalyzer) start(basePath, outputPath, resumed string, assemble func(status *RunStatus, stdout, stderr []byte) []byte) (*domain.AIRun, error) {
+	if f.StartErr != nil {
+		return nil, f.StartErr
+	}
+
+	f.mu.Lock()
+	expired := resumed != "" && !f.sessions[resumed]
+	if f.sessions == nil {
+		f.sessions = make(map[string]bool)
+	}
+	sessionID := fmt.Sprintf("fake-session-%d", len(f.sessions)+1)
+	f.sessions[sessionID] = true
+	f.mu.Unlock()
+
+	run := &domain.AIRun{
+		Backend:        domain.AIBackendFake,
+		OutputPath:     outputPath,
+		StatusPath:     basePath + "_status.json",
+		LogPath:        basePath + "_stderr.txt",
+		ResumedSession: resumed,
+	}
+	status := &RunStatus{
+		OutputFormat:   OutputFormatText,
+		OutputPath:     outputPath,
+		StdoutPath:     basePath + "_stdout.txt",
+		StderrPath:     run.LogPath,
+		StartedAt:      time.Now(),
+		ResumedSession: resumed,
+	}
+	response, stderr := f.Response, f.Stderr
+	if expired {
+		response, stderr = "", fmt.Sprintf("No conversation found with session ID: %s", resumed)
+	}
+	if err := os.WriteFile(status.StdoutPath, []byte(response), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(status.StderrPath, []byte(stderr), 0644); err != nil {
+		return nil, err
+	}
+	if err := writeRunStatus(run.StatusPath, status); err != nil {
+		return nil, err
+	}
+
+	finish := func(exitCode int, errMsg string) {
+		status.FinishedAt = time.Now()
+		status.DurationMS = status.FinishedAt.Sub(status.StartedAt).Milliseconds()
+		status.ExitCode = exitCode
+		status.Error = errMsg
+		status.Usage = f.Usage
+		if expired && exitCode == 0 {
+			status.ExitCode = 1
+		}
+		status.SessionID = sessionID
+		status.SessionExpired = expired
+		if err := writeFileAtomic(outputPath, assemble(status, []byte(response), []byte(stderr))); err != nil {
+			status.Error = err.Error()
+		}
+		status.Finished = true
+		writeRunStatus(run.StatusPath, status)
+	}
+
+	f.mu.Lock()
+	f.runs = append(f.runs, run)
+	if f.Hold {
+		if f.pending == nil {
+			f.pending = make(map[string]func(int, string))
+		}
+		f.pending[run.StatusPath] = finish
+	}
+	f.mu.Unlock()
+
+	if !f.Hold {
+		finish(f.ExitCode, "")
+	}
+	return run, nil
+}
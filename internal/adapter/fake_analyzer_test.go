@@ -0,0 +1,119 @@
+package adapter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/port"
+)
+
+var _ port.AIAnalyzer = (*FakeAIAnalyzer)(nil)
+
+func TestFakeAIAnalyzer_PlanAndExecute(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "PROJ-9.md")
+	if err := os.WriteFile(mdPath, []byte("# PROJ-9\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := NewFakeAIAnalyzer("### ISSUE_SUMMARY\n고정 응답\n")
+	fake.Usage = &domain.AnalysisUsage{InputTokens: 1, OutputTokens: 2, CostUSD: 0.01}
+
+	plan, err := fake.GeneratePlan(mdPath, "prompt", dir)
+	if err != nil {
+		t.Fatalf("GeneratePlan() error = %v", err)
+	}
+	status, err := readRunStatus(plan.StatusPath)
+	if err != nil || !status.Finished || status.Failed() || status.RunUsage() != *fake.Usage {
+		t.Fatalf("status = %+v, err = %v", status, err)
+	}
+	content, _ := os.ReadFile(plan.OutputPath)
+	if !strings.Contains(string(content), "고정 응답") || !strings.Contains(string(content), "## Jira 이슈 컨텍스트\n\n# PROJ-9\n") {
+		t.Errorf("plan:\n%s", content)
+	}
+
+	exec, err := fake.ExecutePlan(plan.OutputPath, dir)
+	if err != nil {
+		t.Fatalf("ExecutePlan() error = %v", err)
+	}
+	if exec.OutputPath != filepath.Join(dir, "PROJ-9_execution.md") {
+		t.Errorf("OutputPath = %q", exec.OutputPath)
+	}
+	if runs := fake.Runs(); len(runs) != 2 || runs[0] != plan || runs[1] != exec {
+		t.Errorf("Runs() = %+v", runs)
+	}
+
+	fake.StartErr = errors.New("boom")
+	if _, err := fake.ExecutePlan(plan.OutputPath, dir); err == nil {
+		t.Error("expected StartErr")
+	}
+}
+
+func TestFakeAIAnalyzer_HoldAndCancel(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "PROJ-10_plan.md")
+	if err := os.WriteFile(planPath, []byte("plan"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &FakeAIAnalyzer{Response: "done", Hold: true}
+	run, err := fake.ExecutePlan(planPath, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := readRunStatus(run.StatusPath); status == nil || status.Finished {
+		t.Fatalf("held run should still be running: %+v", status)
+	}
+
+	if err := fake.Cancel(run); err != nil {
+		t.Fatal(err)
+	}
+	status, _ := readRunStatus(run.StatusPath)
+	if status == nil || !status.Finished || status.ExitCode != -1 || !status.Failed() {
+		t.Errorf("cancelled status = %+v", status)
+	}
+	if len(fake.Cancelled()) != 1 {
+		t.Errorf("Cancelled() = %+v", fake.Cancelled())
+	}
+	if err := fake.Finish(run); err == nil {
+		t.Error("Finish after Cancel should fail")
+	}
+}
+
+func TestFakeAIAnalyzer_ResumePlan(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "PROJ-11.md")
+	if err := os.WriteFile(mdPath, []byte("# PROJ-11\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := NewFakeAIAnalyzer("ok")
+	plan, err := fake.GeneratePlan(mdPath, "prompt", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	planStatus, _ := readRunStatus(plan.StatusPath)
+	if planStatus == nil || planStatus.SessionID == "" {
+		t.Fatalf("plan run should report a session ID: %+v", planStatus)
+	}
+
+	resumed, err := fake.ResumePlan(plan.OutputPath, planStatus.SessionID, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := readRunStatus(resumed.StatusPath); status == nil || status.Failed() || status.ResumedSession != planStatus.SessionID {
+		t.Errorf("resumed status = %+v", status)
+	}
+
+	expired, err := fake.ResumePlan(plan.OutputPath, "unknown", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := fake.RunStatus(expired); status == nil || !status.Failed() || !status.SessionExpired {
+		t.Errorf("unknown session should fail as expired: %+v", status)
+	}
+}
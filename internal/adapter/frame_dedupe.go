@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"image"
+	"image/png"
+	"math/bits"
+	"os"
+
+	"jira-ai-generator/internal/logger"
+)
+
+// dHashWidth x dHashHeight 격자의 가로 방향 밝기 차이로 64비트 해시를 만든다
+const (
+	dHashWidth  = 9
+	dHashHeight = 8
+)
+
+// dHash computes the 64-bit difference hash of img.
+// 이미지를 9x8 회색조로 줄인 뒤 각 칸이 오른쪽 칸보다 밝으면 1로 두므로, 밝기나 압축 노이즈가 조금 달라도 해시는 거의 같다.
+func dHash(img image.Image) uint64 {
+	var cells [dHashHeight][dHashWidth]float64
+	var counts [dHashHeight][dHashWidth]int
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return 0
+	}
+	// 칸마다 픽셀 평균을 내어 (박스 필터) 축소한다
+	for y := 0; y < h; y++ {
+		cy := y * dHashHeight / h
+		for x := 0; x < w; x++ {
+			cx := x * dHashWidth / w
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			cells[cy][cx] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[cy][cx]++
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth-1; x++ {
+			hash <<= 1
+			if cellMean(cells[y][x], counts[y][x]) > cellMean(cells[y][x+1], counts[y][x+1]) {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+func cellMean(sum float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// hammingDistance returns the number of differing bits between two hashes
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// dedupeFrames drops frames whose dHash is within maxDistance of the previous kept frame and deletes them from disk.
+// 멈춘 화면을 녹화한 동영상처럼 같은 장면이 이어질 때 첫 프레임만 남긴다. 읽을 수 없는 프레임은 그대로 둔다.
+func dedupeFrames(frames []string, maxDistance int) []string {
+	var kept []string
+	var prev uint64
+	havePrev := false
+	for _, frame := range frames {
+		hash, err := frameHash(frame)
+		if err != nil {
+			logger.Debug("dedupeFrames: cannot hash %s: %v", frame, err)
+			kept = append(kept, frame)
+			continue
+		}
+		if havePrev && hammingDistance(prev, hash) <= maxDistance {
+			os.Remove(frame)
+			continue
+		}
+		kept = append(kept, frame)
+		prev, havePrev = hash, true
+	}
+	if dropped := len(frames) - len(kept); dropped > 0 {
+		logger.Debug("dedupeFrames: dropped %d of %d near-duplicate frames", dropped, len(frames))
+	}
+	return kept
+}
+
+// frameHash decodes a PNG frame and returns its dHash
+func frameHash(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return 0, err
+	}
+	return dHash(img), nil
+}
@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// gradientImage draws a horizontal gradient; offset shifts the brightness like a small encoding difference
+func gradientImage(w, h int, increasing bool, offset int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := x * 200 / w
+			if !increasing {
+				v = 200 - v
+			}
+			img.SetGray(x, y, color.Gray{Y: uint8(v + offset)})
+		}
+	}
+	return img
+}
+
+func writePNG(t *testing.T, path string, img image.Image) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDHash(t *testing.T) {
+	base := dHash(gradientImage(640, 360, true, 0))
+	brighter := dHash(gradientImage(320, 180, true, 20))
+	reversed := dHash(gradientImage(640, 360, false, 0))
+
+	if d := hammingDistance(base, brighter); d != 0 {
+		t.Errorf("expected brightness and size changes to keep the hash, distance %d", d)
+	}
+	if d := hammingDistance(base, reversed); d < 32 {
+		t.Errorf("expected different images to be far apart, distance %d", d)
+	}
+}
+
+func TestDedupeFrames(t *testing.T) {
+	dir := t.TempDir()
+	frames := []string{
+		filepath.Join(dir, "clip_frame_0001_0ms.png"),
+		filepath.Join(dir, "clip_frame_0002_1000ms.png"),
+		filepath.Join(dir, "clip_frame_0003_2000ms.png"),
+		filepath.Join(dir, "clip_frame_0004_3000ms.png"),
+		filepath.Join(dir, "clip_frame_0005_4000ms.png"),
+	}
+	writePNG(t, frames[0], gradientImage(64, 36, true, 0))
+	writePNG(t, frames[1], gradientImage(64, 36, true, 5)) // 멈춘 화면
+	writePNG(t, frames[2], gradientImage(64, 36, false, 0))
+	writePNG(t, frames[3], gradientImage(64, 36, true, 0)) // 직전 프레임과 다르므로 남긴다
+	os.WriteFile(frames[4], []byte("not a png"), 0644)     // 읽을 수 없는 프레임은 남긴다
+
+	kept := dedupeFrames(frames, 4)
+
+	want := []string{frames[0], frames[2], frames[3], frames[4]}
+	if len(kept) != len(want) {
+		t.Fatalf("expected %v, got %v", want, kept)
+	}
+	for i := range want {
+		if kept[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, kept)
+		}
+	}
+	if _, err := os.Stat(frames[1]); !os.IsNotExist(err) {
+		t.Errorf("expected dropped frame to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/image/draw"
+
+	"jira-ai-generator/internal/logger"
+)
+
+// processedDirName is the directory, next to the original, that holds prepared copies of images
+const processedDirName = "processed"
+
+// maxImagePixels caps the size of images that are decoded (RGBA로 풀면 약 200MB, 압축 폭탄 방지)
+const maxImagePixels = 50_000_000
+
+// ImageOptions controls how screenshots and frames are prepared before being referenced in documents
+type ImageOptions struct {
+	MaxDimension    int   // 긴 변의 최대 픽셀 (넘으면 줄임, 0이면 줄이지 않음)
+	ConvertMinBytes int64 // 이보다 큰 PNG는 JPEG로 바꿔 보고 더 작으면 JPEG를 씀 (0이면 변환 안 함)
+	JPEGQuality     int   // JPEG 품질 (1~100)
+}
+
+// DefaultImageOptions returns the options used when nothing is configured.
+// 1568px는 Claude가 이미지를 줄이지 않고 받는 긴 변 크기다.
+func DefaultImageOptions() ImageOptions {
+	return ImageOptions{
+		MaxDimension:    1568,
+		ConvertMinBytes: 1 << 20,
+		JPEGQuality:     85,
+	}
+}
+
+// ImagePreprocessor implements port.ImageProcessor.
+// 원본은 그대로 두고, 바꿀 것이 있으면 원본 옆 processed/ 디렉토리에 사본을 만든다.
+type ImagePreprocessor struct {
+	mu   sync.RWMutex
+	opts ImageOptions
+}
+
+// NewImagePreprocessor creates an image preprocessor
+func NewImagePreprocessor(opts ImageOptions) *ImagePreprocessor {
+	p := &ImagePreprocessor{}
+	p.SetOptions(opts)
+	return p
+}
+
+// SetOptions updates the preprocessing options
+func (p *ImagePreprocessor) SetOptions(opts ImageOptions) {
+	if opts.MaxDimension < 0 {
+		opts.MaxDimension = 0
+	}
+	if opts.ConvertMinBytes < 0 {
+		opts.ConvertMinBytes = 0
+	}
+	if opts.JPEGQuality < 1 || opts.JPEGQuality > 100 {
+		opts.JPEGQuality = DefaultImageOptions().JPEGQuality
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.opts = opts
+}
+
+// Process downscales, compresses and strips metadata (EXIF 등) from a PNG or JPEG image.
+// 바꿀 것이 없으면 원본 경로를, 아니면 사본 경로를 반환한다. JPEG로 바꾼 사본은 원래 이름 뒤에 ".jpg"가 붙는다.
+// PNG/JPEG가 아닌 파일(GIF 등)과 maxImagePixels를 넘는 이미지는 그대로 둔다.
+func (p *ImagePreprocessor) Process(ctx context.Context, imagePath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	p.mu.RLock()
+	opts := p.opts
+	p.mu.RUnlock()
+
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return imagePath, nil
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		logger.Debug("ImagePreprocessor: skipping %s (%dx%d exceeds %d pixels)", imagePath, cfg.Width, cfg.Height, maxImagePixels)
+		return imagePath, nil
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+	resize := opts.MaxDimension > 0 && (cfg.Width > opts.MaxDimension || cfg.Height > opts.MaxDimension)
+	convert := format == "png" && opts.ConvertMinBytes > 0 && int64(len(data)) > opts.ConvertMinBytes
+
+	out, changed := stripImageMetadata(format, data)
+	if resize || orientation != 1 {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode image: %w", err)
+		}
+		img = applyOrientation(img, orientation)
+		if resize {
+			img = downscale(img, opts.MaxDimension)
+		}
+		if out, err = encodeImage(format, img, opts.JPEGQuality); err != nil {
+			return "", err
+		}
+		changed = true
+	}
+
+	ext := ""
+	if convert {
+		img, err := png.Decode(bytes.NewReader(out))
+		if err == nil && isOpaque(img) {
+			// 투명한 부분이 있는 PNG는 JPEG로 바꾸면 배경이 깨지므로 그대로 둔다
+			if converted, err := encodeImage("jpeg", img, opts.JPEGQuality); err == nil && len(converted) < len(out) {
+				out, ext, changed = converted, ".jpg", true
+			}
+		}
+	}
+	if !changed {
+		return imagePath, nil
+	}
+
+	processedPath := filepath.Join(filepath.Dir(imagePath), processedDirName, filepath.Base(imagePath)+ext)
+	if err := os.MkdirAll(filepath.Dir(processedPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create processed directory: %w", err)
+	}
+	if err := writeFileAtomic(processedPath, out); err != nil {
+		return "", fmt.Errorf("failed to write processed image: %w", err)
+	}
+	return processedPath, nil
+}
+
+// encodeImage encodes img as png or jpeg
+func encodeImage(format string, img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	} else {
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// downscale shrinks img so that its longer side is maxDimension
+func downscale(img image.Image, maxDimension int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w >= h {
+		h = max(1, h*maxDimension/w)
+		w = maxDimension
+	} else {
+		w = max(1, w*maxDimension/h)
+		h = maxDimension
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// isOpaque reports whether img has no transparent pixels
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// applyOrientation rotates/flips img according to the EXIF orientation (1~8),
+// so the image still looks upright once the EXIF data is removed
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 좌우 반전
+				dx, dy = w-1-x, y
+			case 3: // 180도
+				dx, dy = w-1-x, h-1-y
+			case 4: // 상하 반전
+				dx, dy = x, h-1-y
+			case 5: // 대각선 반전
+				dx, dy = y, x
+			case 6: // 시계 방향 90도
+				dx, dy = h-1-y, x
+			case 7: // 반대 대각선 반전
+				dx, dy = h-1-y, w-1-x
+			case 8: // 반시계 방향 90도
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG file (1 if missing)
+func jpegOrientation(data []byte) int {
+	orientation := 1
+	walkJPEGSegments(data, func(marker byte, payload []byte) bool {
+		if marker != 0xE1 || !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return true
+		}
+		if o := exifOrientation(payload[6:]); o > 0 {
+			orientation = o
+		}
+		return false
+	})
+	return orientation
+}
+
+// exifOrientation reads the orientation tag (0x0112) from IFD0 of a TIFF-formatted EXIF block
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) || offset < 8 {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// walkJPEGSegments calls fn for each marker segment before the image data until fn returns false
+func walkJPEGSegments(data []byte, fn func(marker byte, payload []byte) bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return
+		}
+		if !fn(marker, data[i+4:i+2+length]) {
+			return
+		}
+		i += 2 + length
+	}
+}
+
+// stripImageMetadata removes EXIF, XMP, comments and text chunks without re-encoding the image.
+// changed가 false면 지울 메타데이터가 없거나 형식을 해석할 수 없는 경우다.
+func stripImageMetadata(format string, data []byte) (out []byte, changed bool) {
+	switch format {
+	case "jpeg":
+		return stripJPEGMetadata(data)
+	case "png":
+		return stripPNGMetadata(data)
+	}
+	return data, false
+}
+
+func stripJPEGMetadata(data []byte) ([]byte, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data, false
+	}
+	out := []byte{0xFF, 0xD8}
+	i := 2
+	changed := false
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return data, false
+		}
+		marker := data[i+1]
+		if marker == 0xDA {
+			break
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return data, false
+		}
+		// APP1(EXIF/XMP), APP13(Photoshop/IPTC), COM은 버리고 ICC(APP2), Adobe(APP14) 등 색 정보는 남긴다
+		if marker == 0xE1 || marker == 0xED || marker == 0xFE {
+			changed = true
+		} else {
+			out = append(out, data[i:i+2+length]...)
+		}
+		i += 2 + length
+	}
+	if !changed {
+		return data, false
+	}
+	return append(out, data[i:]...), true
+}
+
+// pngMetadataChunks are ancillary PNG chunks that carry metadata rather than pixels or color information
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+func stripPNGMetadata(data []byte) ([]byte, bool) {
+	const signatureLen = 8
+	if len(data) < signatureLen {
+		return data, false
+	}
+	out := append([]byte{}, data[:signatureLen]...)
+	changed := false
+	for i := signatureLen; i < len(data); {
+		if i+8 > len(data) {
+			return data, false
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length // 길이(4) + 종류(4) + 데이터 + CRC(4)
+		if length < 0 || end > len(data) {
+			return data, false
+		}
+		if pngMetadataChunks[string(data[i+4:i+8])] {
+			changed = true
+		} else {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	if !changed {
+		return data, false
+	}
+	return out, true
+}
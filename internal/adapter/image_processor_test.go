@@ -0,0 +1,206 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// noiseImage returns an opaque image that compresses poorly as PNG (like a photo or a busy screenshot)
+func noiseImage(w, h int) *image.RGBA {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(rng.Intn(256))
+		if i%4 == 3 {
+			img.Pix[i] = 255
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGTextChunk inserts a tEXt chunk right after IHDR
+func withPNGTextChunk(data []byte) []byte {
+	const ihdrEnd = 8 + 12 + 13
+	payload := []byte("Comment\x00secret")
+	chunk := make([]byte, 8, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, payload...)
+	chunk = append(chunk, 0, 0, 0, 0) // CRC는 메타데이터 제거 후 남지 않으므로 검사하지 않는다
+	out := append([]byte{}, data[:ihdrEnd]...)
+	out = append(out, chunk...)
+	return append(out, data[ihdrEnd:]...)
+}
+
+// withEXIFOrientation inserts an APP1 EXIF segment with the given orientation after SOI
+func withEXIFOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	ifd := make([]byte, 2+12+4)
+	binary.LittleEndian.PutUint16(ifd, 1)
+	binary.LittleEndian.PutUint16(ifd[2:], 0x0112)
+	binary.LittleEndian.PutUint16(ifd[4:], 3) // SHORT
+	binary.LittleEndian.PutUint32(ifd[6:], 1)
+	binary.LittleEndian.PutUint16(ifd[10:], orientation)
+	payload := append(append([]byte("Exif\x00\x00"), tiff...), ifd...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func decodeFile(t *testing.T, path string) (image.Image, string) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, format, err := image.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+	return img, format
+}
+
+func TestImagePreprocessor_DownscalesIntoProcessedCopy(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "shot.png")
+	original := encodePNG(t, image.NewGray(image.Rect(0, 0, 3840, 2160)))
+	os.WriteFile(src, original, 0644)
+
+	p := NewImagePreprocessor(ImageOptions{MaxDimension: 1568})
+	out, err := p.Process(context.Background(), src)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if out != filepath.Join(dir, "processed", "shot.png") {
+		t.Errorf("unexpected output path %s", out)
+	}
+	img, format := decodeFile(t, out)
+	if format != "png" || img.Bounds().Dx() != 1568 || img.Bounds().Dy() != 882 {
+		t.Errorf("expected 1568x882 png, got %s %v", format, img.Bounds())
+	}
+	if data, _ := os.ReadFile(src); !bytes.Equal(data, original) {
+		t.Error("expected original to stay untouched")
+	}
+}
+
+func TestImagePreprocessor_KeepsSmallCleanImage(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "small.png")
+	os.WriteFile(src, encodePNG(t, image.NewGray(image.Rect(0, 0, 100, 50))), 0644)
+
+	out, err := NewImagePreprocessor(DefaultImageOptions()).Process(context.Background(), src)
+	if err != nil || out != src {
+		t.Errorf("expected original path, got %s (%v)", out, err)
+	}
+}
+
+// TestImagePreprocessor_SkipsOversizedImage는 헤더의 크기가 픽셀 수 제한을 넘으면 디코딩하지 않고 원본을 쓰는지 검증한다.
+func TestImagePreprocessor_SkipsOversizedImage(t *testing.T) {
+	data := encodePNG(t, image.NewGray(image.Rect(0, 0, 10, 10)))
+	// IHDR의 가로/세로를 8000x8000(64MP)으로 바꾸고 CRC를 다시 계산한다 (실제 픽셀 데이터는 없음)
+	binary.BigEndian.PutUint32(data[16:20], 8000)
+	binary.BigEndian.PutUint32(data[20:24], 8000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	src := filepath.Join(t.TempDir(), "huge.png")
+	os.WriteFile(src, data, 0644)
+
+	out, err := NewImagePreprocessor(DefaultImageOptions()).Process(context.Background(), src)
+	if err != nil || out != src {
+		t.Errorf("expected original path without decoding, got %s (%v)", out, err)
+	}
+}
+
+func TestImagePreprocessor_StripsPNGTextWithoutReencoding(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "note.png")
+	clean := encodePNG(t, image.NewGray(image.Rect(0, 0, 100, 50)))
+	os.WriteFile(src, withPNGTextChunk(clean), 0644)
+
+	out, err := NewImagePreprocessor(DefaultImageOptions()).Process(context.Background(), src)
+	if err != nil || out == src {
+		t.Fatalf("expected processed copy, got %s (%v)", out, err)
+	}
+	data, _ := os.ReadFile(out)
+	if !bytes.Equal(data, clean) {
+		t.Error("expected only the text chunk to be removed")
+	}
+}
+
+func TestImagePreprocessor_AppliesAndStripsEXIFOrientation(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "photo.jpg")
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, img, nil)
+	os.WriteFile(src, withEXIFOrientation(buf.Bytes(), 6), 0644)
+
+	if got := jpegOrientation(withEXIFOrientation(buf.Bytes(), 6)); got != 6 {
+		t.Fatalf("jpegOrientation = %d, want 6", got)
+	}
+
+	out, err := NewImagePreprocessor(DefaultImageOptions()).Process(context.Background(), src)
+	if err != nil || out == src {
+		t.Fatalf("expected processed copy, got %s (%v)", out, err)
+	}
+	rotated, _ := decodeFile(t, out)
+	if rotated.Bounds().Dx() != 20 || rotated.Bounds().Dy() != 40 {
+		t.Errorf("expected rotated 20x40 image, got %v", rotated.Bounds())
+	}
+	data, _ := os.ReadFile(out)
+	if jpegOrientation(data) != 1 || bytes.Contains(data, []byte("Exif\x00\x00")) {
+		t.Error("expected EXIF to be removed")
+	}
+}
+
+func TestImagePreprocessor_ConvertsLargeOpaquePNGToJPEG(t *testing.T) {
+	dir := t.TempDir()
+	opaque := filepath.Join(dir, "busy.png")
+	os.WriteFile(opaque, encodePNG(t, noiseImage(300, 200)), 0644)
+
+	transparentImg := noiseImage(300, 200)
+	transparentImg.Pix[3] = 0
+	transparent := filepath.Join(dir, "overlay.png")
+	os.WriteFile(transparent, encodePNG(t, transparentImg), 0644)
+
+	p := NewImagePreprocessor(ImageOptions{ConvertMinBytes: 1024})
+	out, err := p.Process(context.Background(), opaque)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if filepath.Base(out) != "busy.png.jpg" {
+		t.Fatalf("expected JPEG copy, got %s", out)
+	}
+	if _, format := decodeFile(t, out); format != "jpeg" {
+		t.Errorf("expected jpeg, got %s", format)
+	}
+
+	out, err = p.Process(context.Background(), transparent)
+	if err != nil || out != transparent {
+		t.Errorf("expected transparent PNG to be kept, got %s (%v)", out, err)
+	}
+}
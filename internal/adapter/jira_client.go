@@ -1,38 +1,179 @@
 package adapter
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/logger"
 )
 
+// AuthMode selects how the Jira client authenticates requests
+type AuthMode string
+
+const (
+	AuthModeBasic  AuthMode = "basic"  // Jira Cloud: 이메일 + API 토큰 (Basic)
+	AuthModeBearer AuthMode = "bearer" // Jira Server/Data Center: Personal Access Token (Bearer)
+)
+
+// REST API versions: Cloud는 v3(ADF), Server/Data Center는 v2(wiki markup)만 제공한다
+const (
+	APIVersionAuto   = "auto"
+	APIVersionCloud  = "3"
+	APIVersionServer = "2"
+)
+
 // JiraClient implements port.JiraRepository
 type JiraClient struct {
 	baseURL    string
 	email      string
 	apiKey     string
+	authMode   AuthMode
 	httpClient *http.Client
+	retry      *RetryTransport
+
+	fieldsMu     sync.RWMutex // 설정 화면에서 요청 처리 중에 바뀔 수 있음
+	customFields []domain.CustomField
+
+	versionMu  sync.Mutex
+	apiVersion string // 빈 문자열이면 첫 요청 시 serverInfo로 감지
 }
 
-// NewJiraClient creates a new Jira client
+// NewJiraClient creates a new Jira client (Jira Cloud, basic auth, REST v3 by default).
+// 요청은 RetryTransport를 거치므로 429/5xx 응답은 DefaultRetryPolicy에 따라 재시도된다.
 func NewJiraClient(baseURL, email, apiKey string) *JiraClient {
+	retry := NewRetryTransport(nil, DefaultRetryPolicy())
 	return &JiraClient{
-		baseURL: strings.TrimSuffix(baseURL, "/"),
-		email:   email,
-		apiKey:  apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		email:      email,
+		apiKey:     apiKey,
+		authMode:   AuthModeBasic,
+		apiVersion: APIVersionCloud,
+		retry:      retry,
+		// 제한 시간은 재시도 대기를 포함하지 않도록 RetryPolicy.AttemptTimeout으로 시도마다 적용한다
+		httpClient: &http.Client{Transport: retry},
 	}
 }
 
+// SetRetryPolicy sets retry/backoff and per-host concurrency limits for Jira requests
+func (c *JiraClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry.SetPolicy(policy)
+}
+
+// SetRetryObserver registers a callback invoked whenever a request is about to be retried
+func (c *JiraClient) SetRetryObserver(onRetry func(RetryEvent)) {
+	c.retry.SetObserver(onRetry)
+}
+
+// SetAuthMode sets the authentication mode (basic email+token or bearer PAT)
+func (c *JiraClient) SetAuthMode(mode AuthMode) {
+	if mode == "" {
+		mode = AuthModeBasic
+	}
+	c.authMode = mode
+}
+
+// SetAPIVersion pins the REST API version ("2" or "3"), or "auto" to detect it from serverInfo
+func (c *JiraClient) SetAPIVersion(version string) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if version == APIVersionAuto {
+		version = ""
+	}
+	c.apiVersion = version
+}
+
+// APIVersion returns the REST API version in use, detecting it on first use
+func (c *JiraClient) APIVersion(ctx context.Context) string {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if c.apiVersion == "" {
+		version := c.detectAPIVersion(ctx)
+		if ctx.Err() != nil {
+			// 취소로 감지하지 못한 결과는 저장하지 않고 다음 요청에서 다시 감지한다
+			return version
+		}
+		c.apiVersion = version
+	}
+	return c.apiVersion
+}
+
+// detectAPIVersion asks serverInfo (available on both deployments under v2) for the deployment type.
+// 감지에 실패하면 Atlassian Cloud 도메인 여부로 추정한다.
+func (c *JiraClient) detectAPIVersion(ctx context.Context) string {
+	fallback := APIVersionServer
+	if parsed, err := url.Parse(c.baseURL); err == nil && strings.HasSuffix(parsed.Hostname(), ".atlassian.net") {
+		fallback = APIVersionCloud
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/rest/api/2/serverInfo", nil)
+	if err != nil {
+		return fallback
+	}
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.Debug("detectAPIVersion: serverInfo failed, using v%s: %v", fallback, err)
+		return fallback
+	}
+	defer resp.Body.Close()
+
+	var info struct {
+		DeploymentType string `json:"deploymentType"`
+	}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&info) != nil || info.DeploymentType == "" {
+		logger.Debug("detectAPIVersion: serverInfo unusable (status %d), using v%s", resp.StatusCode, fallback)
+		return fallback
+	}
+
+	version := APIVersionServer
+	if strings.EqualFold(info.DeploymentType, "Cloud") {
+		version = APIVersionCloud
+	}
+	logger.Debug("detectAPIVersion: deploymentType=%s, using v%s", info.DeploymentType, version)
+	return version
+}
+
+// restURL builds a REST API endpoint for the resolved API version
+func (c *JiraClient) restURL(ctx context.Context, format string, args ...interface{}) string {
+	return fmt.Sprintf("%s/rest/api/%s", c.baseURL, c.APIVersion(ctx)) + fmt.Sprintf(format, args...)
+}
+
+// jiraBodyToMarkdown converts a rich-text field to Markdown: v3 returns ADF, v2 returns wiki markup
+func jiraBodyToMarkdown(body interface{}) string {
+	if wiki, ok := body.(string); ok {
+		return wikiToMarkdown(wiki)
+	}
+	return adfToMarkdown(body)
+}
+
+// SetCustomFields sets the custom fields (ID and display name) to extract from issues
+func (c *JiraClient) SetCustomFields(fields []domain.CustomField) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	c.customFields = fields
+}
+
+// getCustomFields returns the custom fields configured at the time of the call
+func (c *JiraClient) getCustomFields() []domain.CustomField {
+	c.fieldsMu.RLock()
+	defer c.fieldsMu.RUnlock()
+	return c.customFields
+}
+
 // issueResponse represents the Jira API issue response
 type issueResponse struct {
 	Key    string `json:"key"`
@@ -46,16 +187,77 @@ type issueResponse struct {
 			Size     int64  `json:"size"`
 			Content  string `json:"content"`
 		} `json:"attachment"`
+		Comment    commentPage      `json:"comment"`
+		Parent     *linkedIssueRef  `json:"parent"`
+		Subtasks   []linkedIssueRef `json:"subtasks"`
+		IssueLinks []struct {
+			Type struct {
+				Inward  string `json:"inward"`
+				Outward string `json:"outward"`
+			} `json:"type"`
+			InwardIssue  *linkedIssueRef `json:"inwardIssue"`
+			OutwardIssue *linkedIssueRef `json:"outwardIssue"`
+		} `json:"issuelinks"`
+		Status      namedField   `json:"status"`
+		Priority    namedField   `json:"priority"`
+		Reporter    userField    `json:"reporter"`
+		Assignee    userField    `json:"assignee"`
+		Labels      []string     `json:"labels"`
+		Components  []namedField `json:"components"`
+		FixVersions []namedField `json:"fixVersions"`
+	} `json:"fields"`
+}
+
+// namedField represents a Jira field object identified by name (status, priority, component, version)
+type namedField struct {
+	Name string `json:"name"`
+}
+
+// userField represents a Jira user field
+type userField struct {
+	DisplayName string `json:"displayName"`
+}
+
+// fieldNames returns the names of the given field objects
+func fieldNames(fields []namedField) []string {
+	var names []string
+	for _, f := range fields {
+		if f.Name != "" {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// linkedIssueRef represents the abbreviated issue embedded in parent/subtasks/issuelinks
+type linkedIssueRef struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
 	} `json:"fields"`
 }
 
+// toRelated converts the embedded issue into a related issue with the given relation
+func (c *JiraClient) toRelated(ref *linkedIssueRef, relation string) domain.RelatedIssue {
+	return domain.RelatedIssue{
+		Key:      ref.Key,
+		Summary:  ref.Fields.Summary,
+		Status:   ref.Fields.Status.Name,
+		Relation: relation,
+		Link:     fmt.Sprintf("%s/browse/%s", c.baseURL, ref.Key),
+	}
+}
+
 // GetIssue fetches a Jira issue by its key
-func (c *JiraClient) GetIssue(issueKey string) (*domain.JiraIssue, error) {
+func (c *JiraClient) GetIssue(ctx context.Context, issueKey string) (*domain.JiraIssue, error) {
 	logger.Debug("GetIssue: issueKey=%s, baseURL=%s", issueKey, c.baseURL)
-	url := fmt.Sprintf("%s/rest/api/3/issue/%s", c.baseURL, issueKey)
-	logger.Debug("GetIssue: requesting URL=%s", url)
+	endpoint := c.restURL(ctx, "/issue/%s", url.PathEscape(issueKey))
+	logger.Debug("GetIssue: requesting URL=%s", endpoint)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		logger.Debug("GetIssue: failed to create request: %v", err)
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -63,32 +265,67 @@ func (c *JiraClient) GetIssue(issueKey string) (*domain.JiraIssue, error) {
 
 	c.setAuthHeader(req)
 	req.Header.Set("Accept", "application/json")
-	logger.Debug("GetIssue: auth header set for email=%s", c.email)
+	logger.Debug("GetIssue: auth header set (mode=%s)", c.authMode)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issue: %w", err)
 	}
-	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	// 코멘트를 이어서 조회하기 전에 본문을 닫아 호스트별 동시 요청 슬롯을 돌려준다
+	resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
 
 	var issueResp issueResponse
-	if err := json.NewDecoder(resp.Body).Decode(&issueResp); err != nil {
+	if err := json.Unmarshal(body, &issueResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	fields := issueResp.Fields
 	issue := &domain.JiraIssue{
 		Key:         issueResp.Key,
-		Summary:     issueResp.Fields.Summary,
-		Description: parseADFToText(issueResp.Fields.Description),
+		Summary:     fields.Summary,
+		Description: jiraBodyToMarkdown(fields.Description),
 		Link:        fmt.Sprintf("%s/browse/%s", c.baseURL, issueResp.Key),
+		Metadata: domain.IssueMetadata{
+			Status:      fields.Status.Name,
+			Priority:    fields.Priority.Name,
+			Reporter:    fields.Reporter.DisplayName,
+			Assignee:    fields.Assignee.DisplayName,
+			Labels:      fields.Labels,
+			Components:  fieldNames(fields.Components),
+			FixVersions: fieldNames(fields.FixVersions),
+		},
 	}
 
-	for _, att := range issueResp.Fields.Attachment {
+	if customFields := c.getCustomFields(); len(customFields) > 0 {
+		// 커스텀 필드는 ID가 프로젝트마다 다르므로 fields를 맵으로 한 번 더 디코딩하여 설정된 ID만 꺼낸다.
+		var raw struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("failed to decode custom fields: %w", err)
+		}
+		for _, field := range customFields {
+			value := formatCustomFieldValue(raw.Fields[field.ID])
+			if value == "" {
+				continue
+			}
+			issue.Metadata.CustomFields = append(issue.Metadata.CustomFields, domain.CustomField{
+				ID:    field.ID,
+				Name:  field.Name,
+				Value: value,
+			})
+		}
+	}
+
+	for _, att := range fields.Attachment {
 		issue.Attachments = append(issue.Attachments, domain.Attachment{
 			ID:       att.ID,
 			Filename: att.Filename,
@@ -98,96 +335,535 @@ func (c *JiraClient) GetIssue(issueKey string) (*domain.JiraIssue, error) {
 		})
 	}
 
+	if parent := fields.Parent; parent != nil && parent.Key != "" {
+		issue.Related = append(issue.Related, c.toRelated(parent, domain.RelationParent))
+	}
+	for i := range fields.Subtasks {
+		issue.Related = append(issue.Related, c.toRelated(&fields.Subtasks[i], domain.RelationSubtask))
+	}
+	for _, link := range fields.IssueLinks {
+		if link.InwardIssue != nil {
+			issue.Related = append(issue.Related, c.toRelated(link.InwardIssue, link.Type.Inward))
+		}
+		if link.OutwardIssue != nil {
+			issue.Related = append(issue.Related, c.toRelated(link.OutwardIssue, link.Type.Outward))
+		}
+	}
+
+	comments := fields.Comment.Comments
+	// 이슈 응답에 포함되는 코멘트는 일부만 잘려 올 수 있으므로 부족하면 코멘트 API로 전체를 다시 조회한다.
+	if fields.Comment.Total > len(comments) {
+		all, err := c.getComments(ctx, issueKey)
+		if err != nil {
+			return nil, err
+		}
+		comments = all
+	}
+	for _, cm := range comments {
+		issue.Comments = append(issue.Comments, cm.toDomain())
+	}
+
 	return issue, nil
 }
 
-// DownloadAttachment downloads an attachment from Jira
-func (c *JiraClient) DownloadAttachment(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// GetIssueBrief fetches only the summary and description of an issue.
+// 관련 이슈 설명을 채울 때 쓰며, 코멘트 페이지나 첨부파일 목록을 받지 않도록 fields를 제한한다.
+func (c *JiraClient) GetIssueBrief(ctx context.Context, issueKey string) (*domain.JiraIssue, error) {
+	query := url.Values{}
+	query.Set("fields", "summary,description")
+	endpoint := c.restURL(ctx, "/issue/%s?%s", url.PathEscape(issueKey), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var issueResp struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary     string      `json:"summary"`
+			Description interface{} `json:"description"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issueResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &domain.JiraIssue{
+		Key:         issueResp.Key,
+		Summary:     issueResp.Fields.Summary,
+		Description: jiraBodyToMarkdown(issueResp.Fields.Description),
+		Link:        fmt.Sprintf("%s/browse/%s", c.baseURL, issueResp.Key),
+	}, nil
+}
+
+// formatCustomFieldValue converts a custom field value into a display string.
+// 선택 목록({value}), 사용자({displayName}), 버전/컴포넌트({name}), 다중 값 배열, 계단식 선택(child), ADF 텍스트 영역을 지원한다.
+func formatCustomFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if formatted := formatCustomFieldValue(item); formatted != "" {
+				parts = append(parts, formatted)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			return adfToMarkdown(v)
+		}
+		for _, key := range []string{"value", "displayName", "name", "key"} {
+			if text, ok := v[key].(string); ok && text != "" {
+				if child := formatCustomFieldValue(v["child"]); child != "" {
+					return text + " > " + child
+				}
+				return text
+			}
+		}
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// commentPage represents a page of comments (embedded in the issue or from the comment API)
+type commentPage struct {
+	StartAt    int               `json:"startAt"`
+	MaxResults int               `json:"maxResults"`
+	Total      int               `json:"total"`
+	Comments   []commentResponse `json:"comments"`
+}
+
+// commentPageSize is the page size used when listing all comments of an issue
+const commentPageSize = 100
+
+// getComments fetches all comments of an issue in creation order
+func (c *JiraClient) getComments(ctx context.Context, issueKey string) ([]commentResponse, error) {
+	var comments []commentResponse
+	for {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(len(comments)))
+		query.Set("maxResults", strconv.Itoa(commentPageSize))
+		query.Set("orderBy", "created")
+		endpoint := c.restURL(ctx, "/issue/%s/comment?%s", url.PathEscape(issueKey), query.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setAuthHeader(req)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comments: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		}
+
+		var page commentPage
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		comments = append(comments, page.Comments...)
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			break
+		}
+	}
+
+	logger.Debug("getComments: issueKey=%s, fetched %d comments", issueKey, len(comments))
+	return comments, nil
+}
+
+// searchResponse represents the Jira API search response
+type searchResponse struct {
+	StartAt    int `json:"startAt"`
+	MaxResults int `json:"maxResults"`
+	Total      int `json:"total"`
+	Issues     []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	} `json:"issues"`
+}
+
+// SearchIssues fetches a single page of issues matching the JQL query
+func (c *JiraClient) SearchIssues(ctx context.Context, jql string, startAt, maxResults int) (*domain.IssueSearchResult, error) {
+	logger.Debug("SearchIssues: jql=%s, startAt=%d, maxResults=%d", jql, startAt, maxResults)
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+	query.Set("fields", "summary")
+	endpoint := c.restURL(ctx, "/search?%s", query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download attachment: %w", err)
+		return nil, fmt.Errorf("failed to search issues: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download failed (status %d)", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var searchResp searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	result := &domain.IssueSearchResult{
+		StartAt:    searchResp.StartAt,
+		MaxResults: searchResp.MaxResults,
+		Total:      searchResp.Total,
+	}
+	for _, item := range searchResp.Issues {
+		result.Issues = append(result.Issues, domain.JiraIssue{
+			Key:     item.Key,
+			Summary: item.Fields.Summary,
+			Link:    fmt.Sprintf("%s/browse/%s", c.baseURL, item.Key),
+		})
 	}
 
-	return io.ReadAll(resp.Body)
+	logger.Debug("SearchIssues: received %d issues (total=%d)", len(result.Issues), result.Total)
+	return result, nil
 }
 
-func (c *JiraClient) setAuthHeader(req *http.Request) {
-	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.apiKey))
-	req.Header.Set("Authorization", "Basic "+auth)
+// DownloadAttachment streams an attachment from Jira into dst without buffering it in memory
+func (c *JiraClient) DownloadAttachment(ctx context.Context, url string, dst io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download failed (status %d)", resp.StatusCode)
+	}
+
+	written, err := io.Copy(dst, resp.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return written, nil
 }
 
-// parseADFToText converts Atlassian Document Format to plain text
-func parseADFToText(adf interface{}) string {
-	if adf == nil {
-		return ""
+// commentRequest represents the Jira API comment create/update request body (ADF for v3, wiki string for v2)
+type commentRequest struct {
+	Body interface{} `json:"body"`
+}
+
+// commentResponse represents the Jira API comment response
+type commentResponse struct {
+	ID     string `json:"id"`
+	Author struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId"`   // Cloud (v3)
+		AccountType string `json:"accountType"` // Cloud (v3)에서만 제공
+		Name        string `json:"name"`        // Server/Data Center (v2) 사용자 이름
+	} `json:"author"`
+	Body    interface{} `json:"body"`
+	Created string      `json:"created"`
+}
+
+// jiraTimeLayout is the timestamp format used by the Jira REST API
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// toDomain converts the API comment into a domain comment with a Markdown body
+func (r commentResponse) toDomain() domain.Comment {
+	comment := domain.Comment{
+		ID:          r.ID,
+		Author:      r.Author.DisplayName,
+		AuthorID:    r.Author.AccountID,
+		AuthorIsBot: r.Author.AccountType == "app",
+		Body:        jiraBodyToMarkdown(r.Body),
+	}
+	if comment.AuthorID == "" {
+		comment.AuthorID = r.Author.Name
+	}
+	if created, err := time.Parse(jiraTimeLayout, r.Created); err == nil {
+		comment.Created = created
+	}
+	return comment
+}
+
+// AddComment posts a Markdown comment (converted to ADF) to the issue and returns the comment ID
+func (c *JiraClient) AddComment(ctx context.Context, issueKey, markdown string) (string, error) {
+	logger.Debug("AddComment: issueKey=%s, length=%d", issueKey, len(markdown))
+	endpoint := c.restURL(ctx, "/issue/%s/comment", url.PathEscape(issueKey))
+
+	resp, err := c.sendComment(ctx, "POST", endpoint, markdown)
+	if err != nil {
+		return "", fmt.Errorf("failed to add comment: %w", err)
 	}
+	defer resp.Body.Close()
 
-	adfMap, ok := adf.(map[string]interface{})
-	if !ok {
-		return fmt.Sprintf("%v", adf)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var commentResp commentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&commentResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	var result strings.Builder
-	parseADFNode(adfMap, &result)
-	return strings.TrimSpace(result.String())
+	logger.Debug("AddComment: created commentID=%s", commentResp.ID)
+	return commentResp.ID, nil
 }
 
-func parseADFNode(node map[string]interface{}, result *strings.Builder) {
-	nodeType, _ := node["type"].(string)
+// UpdateComment replaces the body of an existing comment with the given Markdown
+func (c *JiraClient) UpdateComment(ctx context.Context, issueKey, commentID, markdown string) error {
+	logger.Debug("UpdateComment: issueKey=%s, commentID=%s, length=%d", issueKey, commentID, len(markdown))
+	endpoint := c.restURL(ctx, "/issue/%s/comment/%s", url.PathEscape(issueKey), url.PathEscape(commentID))
 
-	// Handle media nodes - insert placeholder for later replacement
-	if nodeType == "mediaGroup" || nodeType == "mediaSingle" {
-		if content, ok := node["content"].([]interface{}); ok {
-			for _, child := range content {
-				if childMap, ok := child.(map[string]interface{}); ok {
-					if childMap["type"] == "media" {
-						if attrs, ok := childMap["attrs"].(map[string]interface{}); ok {
-							if filename, ok := attrs["alt"].(string); ok && filename != "" {
-								result.WriteString(fmt.Sprintf("\n{{MEDIA:%s}}\n", filename))
-							} else if id, ok := attrs["id"].(string); ok {
-								result.WriteString(fmt.Sprintf("\n{{MEDIA_ID:%s}}\n", id))
-							}
-						}
-					}
-				}
-			}
-		}
-		return
+	resp, err := c.sendComment(ctx, "PUT", endpoint, markdown)
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
 	}
+	defer resp.Body.Close()
 
-	if content, ok := node["content"].([]interface{}); ok {
-		for _, child := range content {
-			if childMap, ok := child.(map[string]interface{}); ok {
-				parseADFNode(childMap, result)
-			}
-		}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("comment %s on %s: %w", commentID, issueKey, domain.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// sendComment sends a comment request with the Markdown body converted to ADF (v3) or wiki markup (v2)
+func (c *JiraClient) sendComment(ctx context.Context, method, endpoint, markdown string) (*http.Response, error) {
+	request := commentRequest{Body: markdownToADF(markdown)}
+	if c.APIVersion(ctx) == APIVersionServer {
+		request.Body = markdownToWiki(markdown)
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode comment: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	if nodeType == "text" {
-		if text, ok := node["text"].(string); ok {
-			result.WriteString(text)
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.httpClient.Do(req)
+}
+
+// UploadAttachment uploads a file to the issue as a multipart attachment
+func (c *JiraClient) UploadAttachment(ctx context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error) {
+	logger.Debug("UploadAttachment: issueKey=%s, filename=%s", issueKey, filename)
+	endpoint := c.restURL(ctx, "/issue/%s/attachments", url.PathEscape(issueKey))
+
+	// 파일 전체를 메모리에 올리지 않도록 multipart 본문을 파이프로 스트리밍한다.
+	bodyReader, bodyWriter := io.Pipe()
+	form := multipart.NewWriter(bodyWriter)
+	go func() {
+		part, err := form.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, content)
 		}
+		if err == nil {
+			err = form.Close()
+		}
+		bodyWriter.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bodyReader)
+	if err != nil {
+		bodyReader.CloseWithError(err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		bodyReader.CloseWithError(err)
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var uploaded []struct {
+		ID       string `json:"id"`
+		Filename string `json:"filename"`
+		MimeType string `json:"mimeType"`
+		Size     int64  `json:"size"`
+		Content  string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&uploaded); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(uploaded) == 0 {
+		return nil, fmt.Errorf("upload response contained no attachments")
+	}
+
+	logger.Debug("UploadAttachment: uploaded attachmentID=%s", uploaded[0].ID)
+	return &domain.Attachment{
+		ID:       uploaded[0].ID,
+		Filename: uploaded[0].Filename,
+		MimeType: uploaded[0].MimeType,
+		Size:     uploaded[0].Size,
+		URL:      uploaded[0].Content,
+	}, nil
+}
+
+// transitionsResponse represents the Jira API transitions response
+type transitionsResponse struct {
+	Transitions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		To   struct {
+			Name string `json:"name"`
+		} `json:"to"`
+	} `json:"transitions"`
+}
+
+// GetTransitions lists the workflow transitions currently available for the issue
+func (c *JiraClient) GetTransitions(ctx context.Context, issueKey string) ([]domain.Transition, error) {
+	logger.Debug("GetTransitions: issueKey=%s", issueKey)
+	endpoint := c.restURL(ctx, "/issue/%s/transitions", url.PathEscape(issueKey))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transitions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var transResp transitionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&transResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	transitions := make([]domain.Transition, 0, len(transResp.Transitions))
+	for _, t := range transResp.Transitions {
+		transitions = append(transitions, domain.Transition{
+			ID:       t.ID,
+			Name:     t.Name,
+			ToStatus: t.To.Name,
+		})
+	}
+
+	logger.Debug("GetTransitions: found %d transitions", len(transitions))
+	return transitions, nil
+}
+
+// DoTransition moves the issue through the given workflow transition
+func (c *JiraClient) DoTransition(ctx context.Context, issueKey, transitionID string) error {
+	logger.Debug("DoTransition: issueKey=%s, transitionID=%s", issueKey, transitionID)
+	endpoint := c.restURL(ctx, "/issue/%s/transitions", url.PathEscape(issueKey))
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode transition: %w", err)
 	}
 
-	if nodeType == "paragraph" || nodeType == "listItem" || nodeType == "heading" || nodeType == "hardBreak" {
-		result.WriteString("\n")
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to transition issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
-	if nodeType == "bulletList" || nodeType == "orderedList" {
-		result.WriteString("\n")
+	return nil
+}
+
+func (c *JiraClient) setAuthHeader(req *http.Request) {
+	if c.authMode == AuthModeBearer {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		return
 	}
+	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.apiKey))
+	req.Header.Set("Authorization", "Basic "+auth)
 }
 
 // ExtractIssueKeyFromURL extracts the issue key from a Jira URL
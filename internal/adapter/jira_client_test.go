@@ -0,0 +1,505 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// TestJiraClient_SearchIssues는 JQL 검색 요청 파라미터와 응답 매핑을 검증한다.
+func TestJiraClient_SearchIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("jql") != "labels = ai-candidate" {
+			t.Errorf("unexpected jql: %s", query.Get("jql"))
+		}
+		if query.Get("startAt") != "50" || query.Get("maxResults") != "25" {
+			t.Errorf("unexpected paging params: startAt=%s maxResults=%s", query.Get("startAt"), query.Get("maxResults"))
+		}
+		if r.Header.Get("Authorization") == "" {
+			t.Error("expected Authorization header")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"startAt":50,"maxResults":25,"total":52,"issues":[
+			{"key":"TEST-1","fields":{"summary":"first"}},
+			{"key":"TEST-2","fields":{"summary":"second"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL+"/", "user@example.com", "token")
+	result, err := client.SearchIssues(context.Background(), "labels = ai-candidate", 50, 25)
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+
+	if result.Total != 52 || result.StartAt != 50 {
+		t.Errorf("unexpected paging result: %+v", result)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(result.Issues))
+	}
+	if result.Issues[1].Summary != "second" {
+		t.Errorf("expected summary 'second', got %q", result.Issues[1].Summary)
+	}
+	if result.Issues[0].Link != server.URL+"/browse/TEST-1" {
+		t.Errorf("unexpected link: %s", result.Issues[0].Link)
+	}
+}
+
+// TestJiraClient_SearchIssues_APIError는 비정상 상태 코드를 에러로 반환하는지 검증한다.
+func TestJiraClient_SearchIssues_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages":["invalid jql"]}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	if _, err := client.SearchIssues(context.Background(), "invalid ===", 0, 10); err == nil {
+		t.Fatal("expected error for bad request")
+	}
+}
+
+// TestJiraClient_AddComment는 코멘트 생성 요청이 ADF 본문으로 전송되고 ID를 반환하는지 검증한다.
+func TestJiraClient_AddComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/TEST-1/comment" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+		var payload struct {
+			Body adfNode `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload.Body.Type != "doc" || payload.Body.Content[0].Type != "heading" {
+			t.Errorf("expected ADF doc with heading, got %+v", payload.Body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10042"}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	commentID, err := client.AddComment(context.Background(), "TEST-1", "## Plan\n\nbody")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if commentID != "10042" {
+		t.Errorf("expected comment ID 10042, got %s", commentID)
+	}
+}
+
+// TestJiraClient_UpdateComment_NotFound는 삭제된 코멘트 갱신 시 domain.ErrNotFound를 반환하는지 검증한다.
+func TestJiraClient_UpdateComment_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/3/issue/TEST-1/comment/10042" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	err := client.UpdateComment(context.Background(), "TEST-1", "10042", "updated")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestJiraClient_UploadAttachment는 multipart 업로드 요청과 XSRF 우회 헤더를 검증한다.
+func TestJiraClient_UploadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/TEST-1/attachments" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Atlassian-Token") != "no-check" {
+			t.Errorf("expected X-Atlassian-Token: no-check, got %q", r.Header.Get("X-Atlassian-Token"))
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("expected multipart file field: %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "TEST-1_plan.md" || string(data) != "# Plan" {
+			t.Errorf("unexpected upload: %s %q", header.Filename, string(data))
+		}
+		w.Write([]byte(`[{"id":"300","filename":"TEST-1_plan.md","mimeType":"text/markdown","size":6}]`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	attachment, err := client.UploadAttachment(context.Background(), "TEST-1", "TEST-1_plan.md", strings.NewReader("# Plan"))
+	if err != nil {
+		t.Fatalf("UploadAttachment failed: %v", err)
+	}
+	if attachment.ID != "300" || attachment.Size != 6 {
+		t.Errorf("unexpected attachment: %+v", attachment)
+	}
+}
+
+// TestJiraClient_Transitions는 전환 목록 조회와 전환 실행 요청을 검증한다.
+func TestJiraClient_Transitions(t *testing.T) {
+	var postedID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/transitions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"transitions":[{"id":"31","name":"Send to review","to":{"name":"In Review"}}]}`))
+		case http.MethodPost:
+			var payload struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			postedID = payload.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	transitions, err := client.GetTransitions(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatalf("GetTransitions failed: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].ToStatus != "In Review" {
+		t.Fatalf("unexpected transitions: %+v", transitions)
+	}
+
+	if err := client.DoTransition(context.Background(), "TEST-1", transitions[0].ID); err != nil {
+		t.Fatalf("DoTransition failed: %v", err)
+	}
+	if postedID != "31" {
+		t.Errorf("expected transition id 31 to be posted, got %q", postedID)
+	}
+}
+
+// TestJiraClient_GetIssue_Comments는 이슈 코멘트를 Markdown 본문과 작성자 정보로 변환하고,
+// 이슈 응답에 일부만 포함된 경우 코멘트 API로 전체를 다시 조회하는지 검증한다.
+func TestJiraClient_GetIssue_Comments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/issue/TEST-1":
+			w.Write([]byte(`{"key":"TEST-1","fields":{"summary":"s","comment":{"startAt":0,"maxResults":1,"total":2,"comments":[
+				{"id":"10","author":{"displayName":"Kim"},"body":"truncated","created":"2025-01-02T09:30:00.000+0900"}
+			]}}}`))
+		case "/rest/api/3/issue/TEST-1/comment":
+			if r.URL.Query().Get("orderBy") != "created" {
+				t.Errorf("expected orderBy=created, got %s", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("startAt") == "0" {
+				w.Write([]byte(`{"startAt":0,"maxResults":1,"total":2,"comments":[
+					{"id":"10","author":{"displayName":"Kim","accountType":"atlassian"},"created":"2025-01-02T09:30:00.000+0900",
+					 "body":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"재현됨","marks":[{"type":"strong"}]}]}]}}
+				]}`))
+				return
+			}
+			w.Write([]byte(`{"startAt":1,"maxResults":1,"total":2,"comments":[
+				{"id":"11","author":{"displayName":"Automation for Jira","accountType":"app"},"created":"2025-01-03T10:00:00.000+0000",
+				 "body":{"type":"doc","content":[{"type":"mediaSingle","content":[{"type":"media","attrs":{"id":"m1","alt":"log.png"}}]}]}}
+			]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	issue, err := client.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	if len(issue.Comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(issue.Comments))
+	}
+	first, second := issue.Comments[0], issue.Comments[1]
+	if first.ID != "10" || first.Author != "Kim" || first.AuthorIsBot || first.Body != "**재현됨**" {
+		t.Errorf("unexpected first comment: %+v", first)
+	}
+	if first.Created.UTC().Format("2006-01-02T15:04") != "2025-01-02T00:30" {
+		t.Errorf("unexpected created time: %v", first.Created)
+	}
+	if !second.AuthorIsBot || second.Body != "{{MEDIA:log.png}}" {
+		t.Errorf("unexpected second comment: %+v", second)
+	}
+}
+
+// TestJiraClient_GetIssue_CommentPagingWithSingleSlot은 호스트별 동시 요청 수가 1이어도
+// 이슈 조회 뒤 코멘트 페이지 조회가 슬롯을 기다리며 멈추지 않는지 검증한다.
+func TestJiraClient_GetIssue_CommentPagingWithSingleSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/rest/api/3/issue/TEST-1" {
+			w.Write([]byte(`{"key":"TEST-1","fields":{"summary":"s","comment":{"startAt":0,"maxResults":0,"total":2,"comments":[]}}}`))
+			return
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":1,"total":2,"comments":[{"id":"%d","body":"c%d"}]}`, startAt, 10+startAt, startAt)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	policy := DefaultRetryPolicy()
+	policy.MaxConcurrentPerHost = 1
+	client.SetRetryPolicy(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			issue, err := client.GetIssue(ctx, "TEST-1")
+			if err != nil {
+				t.Errorf("GetIssue failed: %v", err)
+				return
+			}
+			if len(issue.Comments) != 2 || issue.Comments[1].ID != "11" {
+				t.Errorf("expected both comment pages, got %+v", issue.Comments)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestJiraClient_GetIssue_RelatedIssues는 parent/subtasks/issuelinks를 관련 이슈로 변환하는지 검증한다.
+func TestJiraClient_GetIssue_RelatedIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"TEST-2","fields":{"summary":"s",
+			"parent":{"key":"TEST-1","fields":{"summary":"Epic story","status":{"name":"In Progress"}}},
+			"subtasks":[{"key":"TEST-3","fields":{"summary":"sub","status":{"name":"To Do"}}}],
+			"issuelinks":[
+				{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"inwardIssue":{"key":"TEST-4","fields":{"summary":"blocker","status":{"name":"Done"}}}},
+				{"type":{"name":"Duplicate","inward":"is duplicated by","outward":"duplicates"},"outwardIssue":{"key":"TEST-5","fields":{"summary":"original","status":{"name":"Open"}}}}
+			]}}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	issue, err := client.GetIssue(context.Background(), "TEST-2")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	expected := []domain.RelatedIssue{
+		{Key: "TEST-1", Summary: "Epic story", Status: "In Progress", Relation: domain.RelationParent},
+		{Key: "TEST-3", Summary: "sub", Status: "To Do", Relation: domain.RelationSubtask},
+		{Key: "TEST-4", Summary: "blocker", Status: "Done", Relation: "is blocked by"},
+		{Key: "TEST-5", Summary: "original", Status: "Open", Relation: "duplicates"},
+	}
+	if len(issue.Related) != len(expected) {
+		t.Fatalf("expected %d related issues, got %+v", len(expected), issue.Related)
+	}
+	for i, want := range expected {
+		want.Link = server.URL + "/browse/" + want.Key
+		if issue.Related[i] != want {
+			t.Errorf("related[%d]: expected %+v, got %+v", i, want, issue.Related[i])
+		}
+	}
+}
+
+// TestJiraClient_GetIssueBrief는 요약과 설명만 fields로 제한해 한 번에 조회하는지 검증한다.
+func TestJiraClient_GetIssueBrief(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"TEST-3","fields":{"summary":"sub","description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"details"}]}]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	issue, err := client.GetIssueBrief(context.Background(), "TEST-3")
+	if err != nil {
+		t.Fatalf("GetIssueBrief failed: %v", err)
+	}
+
+	if len(requests) != 1 || requests[0] != "/rest/api/3/issue/TEST-3?fields=summary%2Cdescription" {
+		t.Errorf("expected a single fields-limited request, got %v", requests)
+	}
+	if issue.Key != "TEST-3" || issue.Summary != "sub" || issue.Description != "details" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+}
+
+// TestJiraClient_GetIssue_Metadata는 표준 필드와 설정된 커스텀 필드를 메타데이터로 변환하는지 검증한다.
+func TestJiraClient_GetIssue_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"TEST-1","fields":{"summary":"s",
+			"status":{"name":"Open"},"priority":{"name":"High"},
+			"reporter":{"displayName":"Kim"},"assignee":null,
+			"labels":["crash","android"],
+			"components":[{"name":"Login"}],"fixVersions":[{"name":"2.3.0"},{"name":"2.3.1"}],
+			"customfield_10010":{"value":"Staging"},
+			"customfield_10011":"2.3.0-rc1",
+			"customfield_10012":[{"value":"Galaxy S23"},{"value":"Pixel 7"}],
+			"customfield_10013":{"value":"Critical","child":{"value":"P1"}},
+			"customfield_10014":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"memo","marks":[{"type":"strong"}]}]}]},
+			"customfield_10015":7,
+			"customfield_19999":{"value":"ignored"}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	client.SetCustomFields([]domain.CustomField{
+		{ID: "customfield_10010", Name: "환경"},
+		{ID: "customfield_10011", Name: "앱 버전"},
+		{ID: "customfield_10012", Name: "기기"},
+		{ID: "customfield_10013", Name: "심각도"},
+		{ID: "customfield_10014", Name: "메모"},
+		{ID: "customfield_10015", Name: "스토리 포인트"},
+		{ID: "customfield_10016", Name: "비어 있음"},
+	})
+	issue, err := client.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	meta := issue.Metadata
+	if meta.Status != "Open" || meta.Priority != "High" || meta.Reporter != "Kim" || meta.Assignee != "" {
+		t.Errorf("unexpected standard fields: %+v", meta)
+	}
+	if strings.Join(meta.Labels, ",") != "crash,android" || strings.Join(meta.Components, ",") != "Login" ||
+		strings.Join(meta.FixVersions, ",") != "2.3.0,2.3.1" {
+		t.Errorf("unexpected list fields: %+v", meta)
+	}
+
+	var got []string
+	for _, field := range meta.CustomFields {
+		got = append(got, field.Name+"="+field.Value)
+	}
+	expected := []string{"환경=Staging", "앱 버전=2.3.0-rc1", "기기=Galaxy S23, Pixel 7", "심각도=Critical > P1", "메모=**memo**", "스토리 포인트=7"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected custom fields:\n got: %v\nwant: %v", got, expected)
+	}
+}
+
+// TestJiraClient_DataCenter는 Bearer PAT 인증, serverInfo 기반 v2 감지,
+// wiki markup 설명 변환, v2 코멘트 작성자 이름과 코멘트 본문(wiki 문자열) 전송을 검증한다.
+func TestJiraClient_DataCenter(t *testing.T) {
+	var commentBody interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer pat-token" {
+			t.Errorf("expected bearer auth, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			w.Write([]byte(`{"deploymentType":"DataCenter","version":"9.12.0"}`))
+		case "/rest/api/2/issue/DC-1":
+			w.Write([]byte(`{"key":"DC-1","fields":{"summary":"s","description":"h2. 재현\n* *굵게* 단계\n!shot.png|thumbnail!",
+				"comment":{"total":1,"comments":[{"id":"1","author":{"name":"kim","displayName":"Kim"},"body":"{{code}} 확인","created":"2025-01-02T09:30:00.000+0900"}]}}}`))
+		case "/rest/api/2/issue/DC-1/comment":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			commentBody = req["body"]
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"100"}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "", "pat-token")
+	client.SetAuthMode(AuthModeBearer)
+	client.SetAPIVersion(APIVersionAuto)
+
+	issue, err := client.GetIssue(context.Background(), "DC-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if client.APIVersion(context.Background()) != APIVersionServer {
+		t.Errorf("expected detected API version 2, got %s", client.APIVersion(context.Background()))
+	}
+	if issue.Description != "## 재현\n- **굵게** 단계\n{{MEDIA:shot.png}}" {
+		t.Errorf("unexpected description: %q", issue.Description)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Body != "`code` 확인" || issue.Comments[0].AuthorID != "kim" {
+		t.Errorf("unexpected comments: %+v", issue.Comments)
+	}
+
+	if _, err := client.AddComment(context.Background(), "DC-1", "**done**"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if commentBody != "*done*" {
+		t.Errorf("expected wiki markup comment body, got %#v", commentBody)
+	}
+}
+
+// TestJiraClient_DetectAPIVersion_Cloud는 serverInfo가 Cloud를 보고하면 v3를 사용하는지 검증한다.
+func TestJiraClient_DetectAPIVersion_Cloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/2/serverInfo" {
+			w.Write([]byte(`{"deploymentType":"Cloud"}`))
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/rest/api/3/") {
+			t.Errorf("expected v3 path, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"transitions":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	client.SetAPIVersion(APIVersionAuto)
+	if _, err := client.GetTransitions(context.Background(), "TEST-1"); err != nil {
+		t.Fatalf("GetTransitions failed: %v", err)
+	}
+	if client.APIVersion(context.Background()) != APIVersionCloud {
+		t.Errorf("expected API version 3, got %s", client.APIVersion(context.Background()))
+	}
+}
+
+// TestJiraClient_GetIssue_Cancelled는 ctx 취소 시 응답을 기다리지 않고 취소 에러를 반환하는지 검증한다.
+func TestJiraClient_GetIssue_Cancelled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := client.GetIssue(ctx, "TEST-1")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"jira-ai-generator/internal/domain"
@@ -14,6 +16,35 @@ import (
 // MarkdownGenerator implements port.DocumentGenerator
 type MarkdownGenerator struct {
 	promptTemplate string
+
+	mu             sync.RWMutex // 설정 화면에서 문서 생성 도중에 바뀔 수 있음
+	commentOptions CommentOptions
+}
+
+// CommentOptions controls which issue comments are rendered in the generated document
+type CommentOptions struct {
+	Limit       int  // 최근 N개만 포함 (0 이하면 전체)
+	ExcludeBots bool // Jira 앱/자동화 계정이 작성한 코멘트 제외
+	// BotAuthors lists accounts treated as bots when ExcludeBots is set (사용자 이름, accountId 또는 표시 이름).
+	// Server/Data Center(v2)는 계정 종류를 알려주지 않으므로 이 목록으로만 봇을 구분한다.
+	BotAuthors []string
+}
+
+// isBot reports whether the comment was written by a Jira app or one of the configured bot accounts
+func (o CommentOptions) isBot(comment domain.Comment) bool {
+	if comment.AuthorIsBot {
+		return true
+	}
+	for _, author := range o.BotAuthors {
+		author = strings.TrimSpace(author)
+		if author == "" {
+			continue
+		}
+		if strings.EqualFold(author, comment.AuthorID) || strings.EqualFold(author, comment.Author) {
+			return true
+		}
+	}
+	return false
 }
 
 // NewMarkdownGenerator creates a new markdown generator
@@ -26,6 +57,13 @@ func NewMarkdownGenerator(promptTemplate string) *MarkdownGenerator {
 	}
 }
 
+// SetCommentOptions updates the comment filtering options
+func (g *MarkdownGenerator) SetCommentOptions(opts CommentOptions) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.commentOptions = opts
+}
+
 // normalizeMediaPathsToAbsolute는 마크다운에 출력할 미디어 경로를 절대경로로 정규화한다.
 func normalizeMediaPathsToAbsolute(paths []string) []string {
 	normalized := make([]string, 0, len(paths))
@@ -42,6 +80,10 @@ func normalizeMediaPathsToAbsolute(paths []string) []string {
 
 // Generate creates a document from a Jira issue
 func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+	g.mu.RLock()
+	commentOpts := g.commentOptions
+	g.mu.RUnlock()
+
 	var content strings.Builder
 
 	// 출력 마크다운 경로 일관성을 위해 이미지/프레임 경로를 모두 절대경로로 통일한다.
@@ -53,7 +95,9 @@ func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, frameP
 	content.WriteString("## 이슈 정보\n\n")
 	content.WriteString(fmt.Sprintf("- **Jira 링크**: [%s](%s)\n", issue.Key, issue.Link))
 	content.WriteString(fmt.Sprintf("- **생성일**: %s\n", time.Now().Format("2006-01-02 15:04:05")))
-	content.WriteString("\n---\n\n")
+	content.WriteString("\n")
+	content.WriteString(renderIssueMetadata(issue.Metadata))
+	content.WriteString("---\n\n")
 
 	// Create a map of filename to path for quick lookup
 	imageMap := make(map[string]string)
@@ -62,60 +106,134 @@ func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, frameP
 		imageMap[filename] = imgPath
 	}
 
-	// Create a map of video filename to its extracted frames
+	// Create a map of video name (without extension) to its extracted frames and contact sheet
 	videoFrameMap := make(map[string][]string)
+	videoSheetMap := make(map[string]string)
 	for _, framePath := range framePaths {
-		// Frame filename format: videoname_frame_0001.png
-		frameBase := filepath.Base(framePath)
-		parts := strings.Split(frameBase, "_frame_")
-		if len(parts) >= 1 {
-			videoName := parts[0]
-			// Try common video extensions
-			for _, ext := range []string{".mp4", ".mov", ".avi", ".webm", ".mkv"} {
-				videoKey := videoName + ext
-				videoFrameMap[videoKey] = append(videoFrameMap[videoKey], framePath)
-			}
+		// Frame filename format: videoname_frame_0001_12500ms.png
+		if videoName, _, _, ok := domain.ParseFrameFilename(filepath.Base(framePath)); ok {
+			videoFrameMap[videoName] = append(videoFrameMap[videoName], framePath)
+		} else if videoName, ok := domain.ParseContactSheetFilename(filepath.Base(framePath)); ok {
+			videoSheetMap[videoName] = framePath
 		}
 	}
 
-	// Format description and replace media markers with actual images/frames
-	formattedDesc := reformatDescription(issue.Description)
 	usedImages := make(map[string]bool)
 	usedFrames := make(map[string]bool)
+	usedVideos := make(map[string]bool)
+	usedTranscripts := make(map[string]bool)
+	inlinedNames := make(map[string]bool)
+	for _, inline := range issue.Inlined {
+		inlinedNames[inline.Filename] = true
+	}
 
 	// Replace {{MEDIA:filename}} markers with actual image markdown or video frames
 	mediaPattern := regexp.MustCompile(`\{\{MEDIA:([^}]+)\}\}`)
-	formattedDesc = mediaPattern.ReplaceAllStringFunc(formattedDesc, func(match string) string {
-		filename := mediaPattern.FindStringSubmatch(match)[1]
-
-		// Check if it's an image
-		if imgPath, ok := imageMap[filename]; ok {
-			usedImages[filename] = true
-			return fmt.Sprintf("![%s](%s)", filename, imgPath)
-		}
-
-		// Check if it's a video with extracted frames
-		if frames, ok := videoFrameMap[filename]; ok && len(frames) > 0 {
-			var frameMarkdown strings.Builder
-			frameMarkdown.WriteString(fmt.Sprintf("\n**[동영상: %s - 프레임 캡처]**\n", filename))
-			for i, framePath := range frames {
-				usedFrames[framePath] = true
-				frameMarkdown.WriteString(fmt.Sprintf("![프레임 %d](%s)\n", i+1, framePath))
+	mediaIDPattern := regexp.MustCompile(`\{\{MEDIA_ID:[^}]+\}\}`)
+	resolveMedia := func(text string) string {
+		text = mediaPattern.ReplaceAllStringFunc(text, func(match string) string {
+			filename := mediaPattern.FindStringSubmatch(match)[1]
+			// Jira 원래 파일명을 실제 저장된 로컬 파일명으로 바꾼다
+			localName := filename
+			if mapped, ok := issue.MediaNames[filename]; ok {
+				localName = mapped
 			}
-			return frameMarkdown.String()
-		}
 
-		return match
-	})
+			// Check if it's an image
+			if imgPath, ok := imageMap[localName]; ok {
+				usedImages[localName] = true
+				return fmt.Sprintf("![%s](%s)", filename, imgPath)
+			}
 
-	// Also handle MEDIA_ID markers (fallback)
-	mediaIDPattern := regexp.MustCompile(`\{\{MEDIA_ID:[^}]+\}\}`)
-	formattedDesc = mediaIDPattern.ReplaceAllString(formattedDesc, "")
+			// Check if it's a video with extracted frames (or at least probed metadata)
+			videoName := strings.TrimSuffix(localName, filepath.Ext(localName))
+			frames, sheet := videoFrameMap[videoName], videoSheetMap[videoName]
+			info, hasInfo := issue.Videos[localName]
+			transcript := issue.Transcripts[localName]
+			if len(frames) > 0 || sheet != "" || hasInfo || len(transcript) > 0 {
+				var frameMarkdown strings.Builder
+				switch {
+				case sheet != "":
+					frameMarkdown.WriteString(fmt.Sprintf("\n**[동영상: %s - 컨택트 시트]**\n", filename))
+				case len(frames) > 0:
+					frameMarkdown.WriteString(fmt.Sprintf("\n**[동영상: %s - 프레임 캡처]**\n", filename))
+				default:
+					frameMarkdown.WriteString(fmt.Sprintf("\n**[동영상: %s]**\n", filename))
+				}
+				if hasInfo {
+					usedVideos[localName] = true
+					frameMarkdown.WriteString(fmt.Sprintf("동영상 정보: %s\n", info))
+				}
+				if sheet != "" {
+					// 개별 프레임 대신 한 장으로 합친 이미지만 넣어 컨텍스트를 아낀다
+					usedFrames[sheet] = true
+					for _, framePath := range frames {
+						usedFrames[framePath] = true
+					}
+					frameMarkdown.WriteString(renderContactSheet(sheet, frames))
+				} else {
+					for i, framePath := range frames {
+						usedFrames[framePath] = true
+						frameMarkdown.WriteString(fmt.Sprintf("![%s](%s)\n", frameCaption(i+1, framePath), framePath))
+					}
+				}
+				if len(transcript) > 0 {
+					usedTranscripts[localName] = true
+					frameMarkdown.WriteString(renderTranscript(transcript, frames))
+				}
+				return frameMarkdown.String()
+			}
+
+			// 본문에 넣은 텍스트 첨부파일은 아래 섹션을 가리킨다
+			if inlinedNames[filename] {
+				return fmt.Sprintf("[첨부: %s]", filename)
+			}
+
+			return match
+		})
+
+		// Also handle MEDIA_ID markers (fallback)
+		return mediaIDPattern.ReplaceAllString(text, "")
+	}
+
+	// Format description and replace media markers with actual images/frames
+	formattedDesc := resolveMedia(reformatDescription(issue.Description))
 
 	content.WriteString("## 문제 설명\n\n")
 	content.WriteString(formattedDesc)
 	content.WriteString("\n\n---\n\n")
 
+	if comments := filterComments(issue.Comments, commentOpts); len(comments) > 0 {
+		content.WriteString("## 댓글\n\n")
+		if len(comments) < len(issue.Comments) {
+			content.WriteString(fmt.Sprintf("> 전체 %d개 중 %d개만 표시합니다.\n\n", len(issue.Comments), len(comments)))
+		}
+		for _, comment := range comments {
+			author := comment.Author
+			if author == "" {
+				author = "알 수 없음"
+			}
+			header := fmt.Sprintf("### %s", author)
+			if !comment.Created.IsZero() {
+				header += fmt.Sprintf(" · %s", comment.Created.Format("2006-01-02 15:04"))
+			}
+			content.WriteString(header + "\n\n")
+			content.WriteString(resolveMedia(reformatDescription(comment.Body)))
+			content.WriteString("\n\n")
+		}
+		content.WriteString("---\n\n")
+	}
+
+	if len(issue.Inlined) > 0 {
+		content.WriteString(renderInlineAttachments(issue.Inlined))
+		content.WriteString("---\n\n")
+	}
+
+	if len(issue.Related) > 0 {
+		content.WriteString(renderRelatedIssues(issue.Related))
+		content.WriteString("---\n\n")
+	}
+
 	// Collect unused images and unused frames for appendix
 	var unusedImages []string
 	for _, imgPath := range imagePaths {
@@ -125,16 +243,50 @@ func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, frameP
 		}
 	}
 
-	var unusedFramePaths []string
+	var unusedFramePaths, unusedSheets []string
 	for _, framePath := range framePaths {
-		if !usedFrames[framePath] {
-			unusedFramePaths = append(unusedFramePaths, framePath)
+		if usedFrames[framePath] {
+			continue
+		}
+		name := filepath.Base(framePath)
+		if _, ok := domain.ParseContactSheetFilename(name); ok {
+			unusedSheets = append(unusedSheets, framePath)
+			continue
+		}
+		// 컨택트 시트가 있는 동영상의 프레임은 시트로 대신한다
+		if videoName, _, _, ok := domain.ParseFrameFilename(name); ok && videoSheetMap[videoName] != "" {
+			continue
+		}
+		unusedFramePaths = append(unusedFramePaths, framePath)
+	}
+
+	var unusedVideos []string
+	for name := range issue.Videos {
+		if !usedVideos[name] {
+			unusedVideos = append(unusedVideos, name)
 		}
 	}
+	sort.Strings(unusedVideos)
 
-	if len(unusedImages) > 0 || len(unusedFramePaths) > 0 {
+	var unusedTranscripts []string
+	for name := range issue.Transcripts {
+		if !usedTranscripts[name] && len(issue.Transcripts[name]) > 0 {
+			unusedTranscripts = append(unusedTranscripts, name)
+		}
+	}
+	sort.Strings(unusedTranscripts)
+
+	if len(unusedImages) > 0 || len(unusedFramePaths) > 0 || len(unusedSheets) > 0 || len(unusedVideos) > 0 || len(unusedTranscripts) > 0 {
 		content.WriteString("## 추가 첨부 자료\n\n")
 
+		if len(unusedVideos) > 0 {
+			content.WriteString("### 동영상 정보\n\n")
+			for _, name := range unusedVideos {
+				content.WriteString(fmt.Sprintf("- **%s**: %s\n", name, issue.Videos[name]))
+			}
+			content.WriteString("\n")
+		}
+
 		if len(unusedImages) > 0 {
 			content.WriteString("### 기타 이미지\n\n")
 			for i, imgPath := range unusedImages {
@@ -146,11 +298,31 @@ func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, frameP
 		if len(unusedFramePaths) > 0 {
 			content.WriteString("### 동영상 프레임 캡처\n\n")
 			for i, framePath := range unusedFramePaths {
-				content.WriteString(fmt.Sprintf("%d. ![프레임 %d](%s)\n", i+1, i+1, framePath))
+				content.WriteString(fmt.Sprintf("%d. ![%s](%s)\n", i+1, frameCaption(i+1, framePath), framePath))
 			}
 			content.WriteString("\n")
 		}
 
+		if len(unusedSheets) > 0 {
+			content.WriteString("### 동영상 컨택트 시트\n\n")
+			for _, sheet := range unusedSheets {
+				videoName, _ := domain.ParseContactSheetFilename(filepath.Base(sheet))
+				content.WriteString(fmt.Sprintf("**%s**\n", videoName))
+				content.WriteString(renderContactSheet(sheet, videoFrameMap[videoName]))
+				content.WriteString("\n")
+			}
+		}
+
+		if len(unusedTranscripts) > 0 {
+			content.WriteString("### 동영상 음성 내용\n\n")
+			for _, name := range unusedTranscripts {
+				videoName := strings.TrimSuffix(name, filepath.Ext(name))
+				content.WriteString(fmt.Sprintf("**%s**\n", name))
+				content.WriteString(renderTranscript(issue.Transcripts[name], videoFrameMap[videoName]))
+				content.WriteString("\n")
+			}
+		}
+
 		content.WriteString("---\n\n")
 	}
 
@@ -176,6 +348,224 @@ func (g *MarkdownGenerator) Generate(issue *domain.JiraIssue, imagePaths, frameP
 	return doc, nil
 }
 
+// frameCaption labels a frame with its position in the video when the filename carries a timestamp
+func frameCaption(index int, framePath string) string {
+	if _, ts, hasTimestamp, _ := domain.ParseFrameFilename(filepath.Base(framePath)); hasTimestamp {
+		return fmt.Sprintf("프레임 %d (%s)", index, domain.FormatTimestamp(ts))
+	}
+	return fmt.Sprintf("프레임 %d", index)
+}
+
+// renderContactSheet renders a contact sheet image followed by the capture time of each tile
+func renderContactSheet(sheet string, frames []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("![컨택트 시트: 프레임 %d장](%s)\n", len(frames), sheet))
+	var times []string
+	for i, framePath := range frames {
+		if _, ts, hasTimestamp, _ := domain.ParseFrameFilename(filepath.Base(framePath)); hasTimestamp {
+			times = append(times, fmt.Sprintf("%d) %s", i+1, domain.FormatTimestamp(ts)))
+		}
+	}
+	if len(times) > 0 {
+		sb.WriteString("프레임 시점 (왼쪽→오른쪽, 위→아래): " + strings.Join(times, " · ") + "\n")
+	}
+	return sb.String()
+}
+
+// renderTranscript renders recognized speech with its position in the video and the frame shown at that moment
+func renderTranscript(segments []domain.TranscriptSegment, frames []string) string {
+	// 각 구간은 시작 시점 직전에 찍힌 프레임에 대응시킨다
+	var frameTimes []time.Duration
+	for _, framePath := range frames {
+		_, ts, hasTimestamp, _ := domain.ParseFrameFilename(filepath.Base(framePath))
+		if !hasTimestamp {
+			frameTimes = nil
+			break
+		}
+		frameTimes = append(frameTimes, ts)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n**음성 내용**\n\n")
+	for _, segment := range segments {
+		label := fmt.Sprintf("`%s`", domain.FormatTimestamp(segment.Start))
+		frame := 0
+		for i, ts := range frameTimes {
+			if ts <= segment.Start {
+				frame = i + 1
+			}
+		}
+		if frame > 0 {
+			label += fmt.Sprintf(" (프레임 %d)", frame)
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s\n", label, strings.Join(strings.Fields(segment.Text), " ")))
+	}
+	return sb.String()
+}
+
+// filterComments applies the comment options, keeping the most recent comments in original order
+func filterComments(comments []domain.Comment, opts CommentOptions) []domain.Comment {
+	var filtered []domain.Comment
+	for _, comment := range comments {
+		if opts.ExcludeBots && opts.isBot(comment) {
+			continue
+		}
+		if strings.TrimSpace(comment.Body) == "" {
+			continue
+		}
+		filtered = append(filtered, comment)
+	}
+	if limit := opts.Limit; limit > 0 && len(filtered) > limit {
+		filtered = filtered[len(filtered)-limit:]
+	}
+	return filtered
+}
+
+// renderIssueMetadata renders standard and custom fields as a table, skipping empty values
+func renderIssueMetadata(meta domain.IssueMetadata) string {
+	rows := [][2]string{
+		{"상태", meta.Status},
+		{"우선순위", meta.Priority},
+		{"보고자", meta.Reporter},
+		{"담당자", meta.Assignee},
+		{"레이블", strings.Join(meta.Labels, ", ")},
+		{"컴포넌트", strings.Join(meta.Components, ", ")},
+		{"수정 버전", strings.Join(meta.FixVersions, ", ")},
+	}
+	for _, field := range meta.CustomFields {
+		name := field.Name
+		if name == "" {
+			name = field.ID
+		}
+		rows = append(rows, [2]string{name, field.Value})
+	}
+
+	var sb strings.Builder
+	for _, row := range rows {
+		if strings.TrimSpace(row[1]) == "" {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("### 이슈 메타데이터\n\n")
+			sb.WriteString("| 항목 | 값 |\n")
+			sb.WriteString("| --- | --- |\n")
+		}
+		// 여러 줄 값(ADF 텍스트 영역 등)은 셀 안에서 <br>로 줄을 나눈다.
+		lines := strings.Split(strings.TrimSpace(row[1]), "\n")
+		for i := range lines {
+			lines[i] = escapeTableCell(lines[i])
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", escapeTableCell(row[0]), strings.Join(lines, "<br>")))
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderRelatedIssues renders the related issues table followed by any fetched descriptions
+func renderRelatedIssues(related []domain.RelatedIssue) string {
+	var sb strings.Builder
+	sb.WriteString("## 관련 이슈\n\n")
+	sb.WriteString("| 관계 | 이슈 | 요약 | 상태 |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, r := range related {
+		key := r.Key
+		if r.Link != "" {
+			key = fmt.Sprintf("[%s](%s)", r.Key, r.Link)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			escapeTableCell(r.Relation), key, escapeTableCell(r.Summary), escapeTableCell(r.Status)))
+	}
+	sb.WriteString("\n")
+
+	for _, r := range related {
+		desc := strings.TrimSpace(r.Description)
+		if desc == "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### [%s] %s (%s)\n\n", r.Key, r.Summary, r.Relation))
+		// 관련 이슈의 첨부파일은 다운로드하지 않으므로 플레이스홀더를 파일명 표기로 바꾼다.
+		desc = relatedMediaPattern.ReplaceAllString(reformatDescription(desc), "[첨부: $1]")
+		desc = relatedMediaIDPattern.ReplaceAllString(desc, "")
+		sb.WriteString(desc)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// renderInlineAttachments renders text attachments as fenced code blocks and zip archives as a listing
+// followed by the text files inside them
+func renderInlineAttachments(inlined []domain.InlineAttachment) string {
+	var sb strings.Builder
+	sb.WriteString("## 첨부 파일 내용\n\n")
+	for _, inline := range inlined {
+		if inline.Kind == domain.AttachmentArchive {
+			sb.WriteString(fmt.Sprintf("### %s (압축 파일, %d개 항목)\n\n", inline.Filename, len(inline.Entries)))
+			if len(inline.Entries) > 0 {
+				sb.WriteString("| 파일 | 크기 |\n")
+				sb.WriteString("| --- | --- |\n")
+				for _, entry := range inline.Entries {
+					sb.WriteString(fmt.Sprintf("| %s | %s |\n", escapeTableCell(entry.Name), formatBytes(entry.Size)))
+				}
+				if inline.Truncated {
+					sb.WriteString("| … | (목록 일부 생략) |\n")
+				}
+				sb.WriteString("\n")
+			}
+			for _, file := range inline.Files {
+				sb.WriteString(renderTextBlock("#### "+inline.Filename+" / "+file.Filename, file))
+			}
+			continue
+		}
+		sb.WriteString(renderTextBlock("### "+inline.Filename, inline))
+	}
+	return sb.String()
+}
+
+// renderTextBlock renders one text file under heading as a fenced code block
+func renderTextBlock(heading string, file domain.InlineAttachment) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s (%s)\n\n", heading, formatBytes(file.Size)))
+	if file.Truncated {
+		sb.WriteString("> 파일이 커서 앞부분과 뒷부분만 표시합니다.\n\n")
+	}
+	// 내용에 ``` 가 있어도 블록이 깨지지 않도록 가장 긴 백틱 연속보다 긴 펜스를 쓴다
+	fence := strings.Repeat("`", longestRun(file.Content, '`')+1)
+	if len(fence) < 3 {
+		fence = "```"
+	}
+	sb.WriteString(fence + file.Language + "\n")
+	sb.WriteString(strings.TrimRight(file.Content, "\n"))
+	sb.WriteString("\n" + fence + "\n\n")
+	return sb.String()
+}
+
+// longestRun returns the length of the longest run of c in s
+func longestRun(s string, c byte) int {
+	longest, current := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != c {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
+
+var relatedMediaPattern = regexp.MustCompile(`\{\{MEDIA:([^}]+)\}\}`)
+var relatedMediaIDPattern = regexp.MustCompile(`\{\{MEDIA_ID:[^}]+\}\}`)
+
+// escapeTableCell makes a value safe to place inside a single Markdown table cell
+func escapeTableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
 // SaveToFile saves the document to a file
 func (g *MarkdownGenerator) SaveToFile(doc *domain.GeneratedDocument) (string, error) {
 	// Convert to absolute path
@@ -224,31 +614,56 @@ func (g *MarkdownGenerator) GenerateClipboardContent(doc *domain.GeneratedDocume
 
 // Formatting utilities
 var sectionPattern = regexp.MustCompile(`\[(재현 ?스텝|현 ?결과|오류 ?내용|기대 ?결과|수정 ?요청|추가 ?정보)\]`)
-var multipleNewlines = regexp.MustCompile(`\n{2,}`)
+var multipleNewlines = regexp.MustCompile(`\n{3,}`)
 
 func reformatDescription(description string) string {
 	// Normalize line endings
 	description = strings.ReplaceAll(description, "\r\n", "\n")
 
-	// Trim leading/trailing whitespace from each line
-	lines := strings.Split(description, "\n")
-	var cleanLines []string
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// Skip completely empty lines, but keep non-empty ones
-		if trimmed != "" {
-			cleanLines = append(cleanLines, trimmed)
+	// 펜스 코드 블록은 그대로 두고, 나머지 줄은 들여쓰기(중첩 목록)를 유지한 채 끝 공백만 제거한다
+	var out []string
+	var text []string
+	fence := "" // 열린 펜스 문자열 (빈 문자열이면 코드 블록 밖)
+	flushText := func() {
+		if len(text) == 0 {
+			return
+		}
+		chunk := strings.Join(text, "\n")
+		// Add blank line before section headers for readability
+		chunk = sectionPattern.ReplaceAllString(chunk, "\n$0")
+		// Collapse runs of blank lines into one
+		chunk = multipleNewlines.ReplaceAllString(chunk, "\n\n")
+		// 코드 블록과 맞닿는 앞뒤 빈 줄도 한 줄로 줄인다
+		trimmed := strings.Trim(chunk, "\n")
+		if trimmed != "" && strings.HasPrefix(chunk, "\n") {
+			trimmed = "\n" + trimmed
 		}
+		if trimmed != "" && strings.HasSuffix(chunk, "\n") {
+			trimmed += "\n"
+		}
+		out = append(out, trimmed)
+		text = nil
 	}
 
-	// Join with single newline and add line break after section headers
-	result := strings.Join(cleanLines, "\n")
-
-	// Add blank line before section headers for readability
-	result = sectionPattern.ReplaceAllString(result, "\n$0")
-
-	// Remove any double+ newlines that might have been created
-	result = multipleNewlines.ReplaceAllString(result, "\n\n")
+	for _, line := range strings.Split(description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			out = append(out, line)
+			// 닫는 펜스는 여는 펜스 이상의 백틱만으로 이루어진 줄이다
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == "" {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			flushText()
+			out = append(out, line)
+			fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, "`"))]
+			continue
+		}
+		text = append(text, strings.TrimRight(line, " \t"))
+	}
+	flushText()
 
-	return strings.TrimSpace(result)
+	return strings.TrimSpace(strings.Join(out, "\n"))
 }
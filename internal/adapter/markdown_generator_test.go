@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"jira-ai-generator/internal/domain"
 )
@@ -51,3 +52,317 @@ func TestMarkdownGenerator_Generate_ConvertsRelativeMediaPathsToAbsolute(t *test
 	}
 }
 
+// TestMarkdownGenerator_Generate_Comments는 코멘트 섹션 렌더링, 봇 제외/최근 N개 옵션, 미디어 치환을 검증한다.
+func TestMarkdownGenerator_Generate_Comments(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	generator.SetCommentOptions(CommentOptions{Limit: 2, ExcludeBots: true})
+
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Summary:     "코멘트 테스트",
+		Description: "설명",
+		Comments: []domain.Comment{
+			{ID: "1", Author: "Kim", Body: "가장 오래된 코멘트"},
+			{ID: "2", Author: "Automation", AuthorIsBot: true, Body: "자동 알림"},
+			{ID: "3", Author: "Lee", Created: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC), Body: "스크린샷\n{{MEDIA:shot.png}}"},
+			{ID: "4", Author: "Park", Body: "최신 코멘트"},
+		},
+	}
+
+	imagePath, _ := filepath.Abs("output/TEST-1/shot.png")
+	doc, err := generator.Generate(issue, []string{imagePath}, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(doc.Content, "## 댓글\n\n> 전체 4개 중 2개만 표시합니다.") {
+		t.Errorf("expected comments section with truncation note:\n%s", doc.Content)
+	}
+	if strings.Contains(doc.Content, "가장 오래된 코멘트") || strings.Contains(doc.Content, "자동 알림") {
+		t.Errorf("expected old and bot comments to be excluded:\n%s", doc.Content)
+	}
+	if !strings.Contains(doc.Content, "### Lee · 2025-01-02 09:30\n\n스크린샷\n![shot.png]("+imagePath+")") {
+		t.Errorf("expected comment media placeholder resolved:\n%s", doc.Content)
+	}
+	if strings.Contains(doc.Content, "## 추가 첨부 자료") {
+		t.Errorf("image referenced by a comment should not be listed as unused:\n%s", doc.Content)
+	}
+	if strings.Index(doc.Content, "### Lee") > strings.Index(doc.Content, "### Park") {
+		t.Errorf("expected comments in chronological order")
+	}
+}
+
+// TestMarkdownGenerator_Generate_BotAuthors는 계정 종류가 없는 Server/Data Center 코멘트도
+// 설정한 봇 계정(사용자 이름 또는 표시 이름)과 일치하면 제외되는지 검증한다.
+func TestMarkdownGenerator_Generate_BotAuthors(t *testing.T) {
+	generator := NewMarkdownGenerator("")
+	generator.SetCommentOptions(CommentOptions{ExcludeBots: true, BotAuthors: []string{" jenkins ", "Automation"}})
+
+	issue := &domain.JiraIssue{
+		Key:         "DC-1",
+		Description: "설명",
+		Comments: []domain.Comment{
+			{ID: "1", Author: "Jenkins CI", AuthorID: "Jenkins", Body: "빌드 실패"},
+			{ID: "2", Author: "automation", AuthorID: "auto1", Body: "자동 알림"},
+			{ID: "3", Author: "Kim", AuthorID: "kim", Body: "재현됨"},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Contains(doc.Content, "빌드 실패") || strings.Contains(doc.Content, "자동 알림") {
+		t.Errorf("expected configured bot comments to be excluded:\n%s", doc.Content)
+	}
+	if !strings.Contains(doc.Content, "재현됨") {
+		t.Errorf("expected human comment to remain:\n%s", doc.Content)
+	}
+
+	generator.SetCommentOptions(CommentOptions{BotAuthors: []string{"jenkins"}})
+	doc, err = generator.Generate(issue, nil, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(doc.Content, "빌드 실패") {
+		t.Errorf("bot authors should only apply when ExcludeBots is set:\n%s", doc.Content)
+	}
+}
+
+func TestMarkdownGenerator_Generate_NoComments(t *testing.T) {
+	generator := NewMarkdownGenerator("")
+	doc, err := generator.Generate(&domain.JiraIssue{Key: "TEST-2", Description: "설명"}, nil, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Contains(doc.Content, "## 댓글") {
+		t.Errorf("expected no comments section:\n%s", doc.Content)
+	}
+}
+
+// TestMarkdownGenerator_Generate_RelatedIssues는 관련 이슈 표와 조회된 설명 렌더링을 검증한다.
+func TestMarkdownGenerator_Generate_RelatedIssues(t *testing.T) {
+	generator := NewMarkdownGenerator("")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-2",
+		Description: "설명",
+		Related: []domain.RelatedIssue{
+			{Key: "TEST-1", Summary: "A | B", Status: "Done", Relation: domain.RelationParent, Link: "https://jira/browse/TEST-1", Description: "상위 설명\n{{MEDIA:spec.png}}"},
+			{Key: "TEST-4", Summary: "blocker", Status: "Open", Relation: "is blocked by"},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(doc.Content, "| 상위 이슈 | [TEST-1](https://jira/browse/TEST-1) | A \\| B | Done |") {
+		t.Errorf("expected related issue row with escaped summary:\n%s", doc.Content)
+	}
+	if !strings.Contains(doc.Content, "| is blocked by | TEST-4 | blocker | Open |") {
+		t.Errorf("expected link row:\n%s", doc.Content)
+	}
+	if !strings.Contains(doc.Content, "### [TEST-1] A | B (상위 이슈)\n\n상위 설명\n[첨부: spec.png]") {
+		t.Errorf("expected related description with media placeholder replaced:\n%s", doc.Content)
+	}
+	if strings.Contains(doc.Content, "### [TEST-4]") {
+		t.Errorf("expected no description block for issue without description:\n%s", doc.Content)
+	}
+}
+
+// TestMarkdownGenerator_Generate_Metadata는 비어 있지 않은 메타데이터만 표로 렌더링하는지 검증한다.
+func TestMarkdownGenerator_Generate_Metadata(t *testing.T) {
+	generator := NewMarkdownGenerator("")
+	issue := &domain.JiraIssue{
+		Key: "TEST-1",
+		Metadata: domain.IssueMetadata{
+			Status:   "Open",
+			Priority: "High",
+			Labels:   []string{"crash", "android"},
+			CustomFields: []domain.CustomField{
+				{ID: "customfield_10010", Name: "환경", Value: "Staging | QA"},
+				{ID: "customfield_10014", Name: "메모", Value: "첫 줄\n둘째 줄"},
+			},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, nil, "./output")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	expected := "### 이슈 메타데이터\n\n| 항목 | 값 |\n| --- | --- |\n" +
+		"| 상태 | Open |\n| 우선순위 | High |\n| 레이블 | crash, android |\n" +
+		"| 환경 | Staging \\| QA |\n| 메모 | 첫 줄<br>둘째 줄 |\n"
+	if !strings.Contains(doc.Content, expected) {
+		t.Errorf("unexpected metadata table:\n%s", doc.Content)
+	}
+	if strings.Contains(doc.Content, "| 담당자 |") {
+		t.Errorf("expected empty fields to be skipped:\n%s", doc.Content)
+	}
+}
+
+// TestMarkdownGenerator_Generate_ResolvesMediaNames는 {{MEDIA:원래 이름}}이 저장된 로컬 파일명으로 연결되는지 검증한다.
+func TestMarkdownGenerator_Generate_ResolvesMediaNames(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Description: "{{MEDIA:../shot.png}}\n{{MEDIA:clip.final.mov}}",
+		MediaNames: map[string]string{
+			"../shot.png":    "shot.png",
+			"clip.final.mov": "20001_clip.final.mov",
+		},
+	}
+
+	doc, err := generator.Generate(issue,
+		[]string{"/out/TEST-1/shot.png"},
+		[]string{"/out/TEST-1/frames/20001_clip.final_frame_0001.png"},
+		"/out")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(doc.Content, "![../shot.png](/out/TEST-1/shot.png)") {
+		t.Errorf("expected image resolved through mapping, got:\n%s", doc.Content)
+	}
+	if !strings.Contains(doc.Content, "**[동영상: clip.final.mov - 프레임 캡처]**") {
+		t.Errorf("expected video frames resolved through mapping, got:\n%s", doc.Content)
+	}
+	if strings.Contains(doc.Content, "추가 첨부 자료") {
+		t.Errorf("expected all media to be used inline, got:\n%s", doc.Content)
+	}
+}
+
+// TestMarkdownGenerator_Generate_InlinesTextAttachments는 텍스트 첨부파일과 zip 목록이 코드 블록과 표로 들어가는지 검증한다.
+func TestMarkdownGenerator_Generate_InlinesTextAttachments(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Description: "로그 첨부\n{{MEDIA:crash.log}}",
+		Inlined: []domain.InlineAttachment{
+			{Filename: "crash.log", Kind: domain.AttachmentText, Content: "panic: ```boom```\n", Size: 18},
+			{
+				Filename: "report.zip",
+				Kind:     domain.AttachmentArchive,
+				Entries:  []domain.ArchiveEntry{{Name: "logs/app.log", Size: 11}, {Name: "a.png", Size: 2048}},
+				Files:    []domain.InlineAttachment{{Filename: "logs/app.log", Kind: domain.AttachmentText, Language: "", Content: "ERROR boom", Size: 11, Truncated: true}},
+			},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, nil, "/out")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"[첨부: crash.log]",
+		"## 첨부 파일 내용",
+		"### crash.log (18 B)\n\n````\npanic: ```boom```\n````",
+		"### report.zip (압축 파일, 2개 항목)",
+		"| logs/app.log | 11 B |",
+		"#### report.zip / logs/app.log (11 B)\n\n> 파일이 커서 앞부분과 뒷부분만 표시합니다.",
+	} {
+		if !strings.Contains(doc.Content, want) {
+			t.Errorf("expected %q in document, got:\n%s", want, doc.Content)
+		}
+	}
+}
+
+// TestMarkdownGenerator_Generate_FrameTimestamps는 프레임 캡션에 동영상 내 시점이 표시되는지 검증한다.
+func TestMarkdownGenerator_Generate_FrameTimestamps(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Description: "재현 영상\n{{MEDIA:clip.mp4}}",
+	}
+
+	doc, err := generator.Generate(issue, nil, []string{
+		"/out/TEST-1/frames/clip_frame_0001_0ms.png",
+		"/out/TEST-1/frames/clip_frame_0002_83500ms.png",
+	}, "/out")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"![프레임 1 (00:00.0)](/out/TEST-1/frames/clip_frame_0001_0ms.png)",
+		"![프레임 2 (01:23.5)](/out/TEST-1/frames/clip_frame_0002_83500ms.png)",
+	} {
+		if !strings.Contains(doc.Content, want) {
+			t.Errorf("expected %q in document, got:\n%s", want, doc.Content)
+		}
+	}
+}
+
+// TestMarkdownGenerator_Generate_ContactSheet는 컨택트 시트가 있으면 개별 프레임 대신 시트와 동영상 정보를 넣는지 검증한다.
+func TestMarkdownGenerator_Generate_ContactSheet(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Description: "재현 영상\n{{MEDIA:clip.mp4}}",
+		Videos: map[string]domain.VideoInfo{
+			"clip.mp4":  {Duration: 83500 * time.Millisecond, Width: 1920, Height: 1080, Codec: "h264", HasAudio: true},
+			"other.mov": {Duration: 5 * time.Second, Codec: "hevc"},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, []string{
+		"/out/TEST-1/frames/clip_frame_0001_0ms.png",
+		"/out/TEST-1/frames/clip_frame_0002_12500ms.png",
+		"/out/TEST-1/frames/clip_contact_sheet.png",
+	}, "/out")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"**[동영상: clip.mp4 - 컨택트 시트]**\n동영상 정보: 길이 01:23.5 · 1920x1080 · h264 · 오디오 있음\n",
+		"![컨택트 시트: 프레임 2장](/out/TEST-1/frames/clip_contact_sheet.png)",
+		"프레임 시점 (왼쪽→오른쪽, 위→아래): 1) 00:00.0 · 2) 00:12.5",
+		"- **other.mov**: 길이 00:05.0 · hevc · 오디오 없음",
+	} {
+		if !strings.Contains(doc.Content, want) {
+			t.Errorf("expected %q in document, got:\n%s", want, doc.Content)
+		}
+	}
+	if strings.Contains(doc.Content, "clip_frame_0001_0ms.png)") {
+		t.Errorf("expected individual frames to be replaced by the contact sheet, got:\n%s", doc.Content)
+	}
+}
+
+// TestMarkdownGenerator_Generate_Transcript는 음성 인식 결과가 재생 시점과 해당 프레임 번호와 함께 들어가는지 검증한다.
+func TestMarkdownGenerator_Generate_Transcript(t *testing.T) {
+	generator := NewMarkdownGenerator("테스트 프롬프트")
+	issue := &domain.JiraIssue{
+		Key:         "TEST-1",
+		Description: "재현 영상\n{{MEDIA:clip.mp4}}",
+		Transcripts: map[string][]domain.TranscriptSegment{
+			"clip.mp4": {
+				{Start: 1 * time.Second, Text: "로그인 화면입니다"},
+				{Start: 15 * time.Second, Text: "저장을 누르면\n멈춥니다"},
+			},
+			"other.mov": {{Start: 0, Text: "다른 영상"}},
+		},
+	}
+
+	doc, err := generator.Generate(issue, nil, []string{
+		"/out/TEST-1/frames/clip_frame_0001_0ms.png",
+		"/out/TEST-1/frames/clip_frame_0002_12500ms.png",
+	}, "/out")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"![프레임 2 (00:12.5)](/out/TEST-1/frames/clip_frame_0002_12500ms.png)\n\n**음성 내용**\n\n",
+		"- `00:01.0` (프레임 1) 로그인 화면입니다\n",
+		"- `00:15.0` (프레임 2) 저장을 누르면 멈춥니다\n",
+		"### 동영상 음성 내용\n\n**other.mov**\n\n**음성 내용**\n\n- `00:00.0` 다른 영상\n",
+	} {
+		if !strings.Contains(doc.Content, want) {
+			t.Errorf("expected %q in document, got:\n%s", want, doc.Content)
+		}
+	}
+}
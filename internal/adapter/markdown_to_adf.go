@@ -0,0 +1,446 @@
+package adapter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// adfNode는 Atlassian Document Format(ADF)의 단일 노드다.
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+}
+
+// adfMark는 텍스트 노드에 적용되는 서식(굵게, 코드, 링크 등)이다.
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+var (
+	mdHeadingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBulletPattern      = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrderedPattern     = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdRulePattern        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdTableDelimiterLine = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// markdownToADF는 Markdown 텍스트를 Jira REST API v3용 ADF 문서로 변환한다.
+// 제목, 문단, 목록(중첩 포함), 코드 블록, 인용, 구분선, 표와 굵게/기울임/취소선/코드/링크 서식을 지원한다.
+func markdownToADF(markdown string) adfNode {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	lines := strings.Split(markdown, "\n")
+
+	content := parseMarkdownBlocks(lines)
+	if len(content) == 0 {
+		content = []adfNode{{Type: "paragraph"}}
+	}
+	return adfNode{Type: "doc", Version: 1, Content: content}
+}
+
+// parseMarkdownBlocks는 줄 목록을 ADF 블록 노드로 변환한다.
+func parseMarkdownBlocks(lines []string) []adfNode {
+	var blocks []adfNode
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		blocks = append(blocks, adfNode{Type: "paragraph", Content: parseMarkdownInlineLines(paragraph)})
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flushParagraph()
+			fence := trimmed[:3]
+			language := strings.TrimSpace(trimmed[3:])
+			var code []string
+			for i+1 < len(lines) {
+				i++
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			block := adfNode{Type: "codeBlock"}
+			if language != "" {
+				block.Attrs = map[string]interface{}{"language": language}
+			}
+			if text := strings.Join(code, "\n"); text != "" {
+				block.Content = []adfNode{{Type: "text", Text: text}}
+			}
+			blocks = append(blocks, block)
+
+		case mdHeadingPattern.MatchString(trimmed):
+			flushParagraph()
+			m := mdHeadingPattern.FindStringSubmatch(trimmed)
+			blocks = append(blocks, adfNode{
+				Type:    "heading",
+				Attrs:   map[string]interface{}{"level": len(m[1])},
+				Content: parseMarkdownInline(m[2], nil),
+			})
+
+		case mdRulePattern.MatchString(line):
+			flushParagraph()
+			blocks = append(blocks, adfNode{Type: "rule"})
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					break
+				}
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(t, ">"), " "))
+			}
+			i--
+			if inner := parseMarkdownBlocks(quoted); len(inner) > 0 {
+				blocks = append(blocks, adfNode{Type: "blockquote", Content: inner})
+			}
+
+		case isMarkdownTableStart(lines, i):
+			flushParagraph()
+			var rows []string
+			for ; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				rows = append(rows, lines[i])
+			}
+			i--
+			blocks = append(blocks, buildMarkdownTable(rows))
+
+		case mdBulletPattern.MatchString(line) || mdOrderedPattern.MatchString(line):
+			flushParagraph()
+			end := markdownListEnd(lines, i)
+			blocks = append(blocks, buildMarkdownList(lines[i:end]))
+			i = end - 1
+
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+
+	return blocks
+}
+
+// isMarkdownTableStart는 i번째 줄이 헤더 행이고 다음 줄이 구분 행인 표의 시작인지 확인한다.
+func isMarkdownTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	next := lines[i+1]
+	return strings.Contains(next, "-") && mdTableDelimiterLine.MatchString(next)
+}
+
+// splitMarkdownTableRow는 "| a | b |" 형식의 행을 셀 문자열로 분리한다.
+func splitMarkdownTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// buildMarkdownTable은 표 행 목록(헤더, 구분선, 본문)을 ADF table 노드로 변환한다.
+func buildMarkdownTable(rows []string) adfNode {
+	table := adfNode{Type: "table"}
+	for idx, row := range rows {
+		if idx == 1 {
+			continue // 구분 행
+		}
+		cellType := "tableCell"
+		if idx == 0 {
+			cellType = "tableHeader"
+		}
+		tableRow := adfNode{Type: "tableRow"}
+		for _, cell := range splitMarkdownTableRow(row) {
+			paragraph := adfNode{Type: "paragraph", Content: parseMarkdownInline(cell, nil)}
+			tableRow.Content = append(tableRow.Content, adfNode{Type: cellType, Content: []adfNode{paragraph}})
+		}
+		table.Content = append(table.Content, tableRow)
+	}
+	return table
+}
+
+// markdownIndent는 줄 앞의 들여쓰기 폭을 반환한다 (탭은 4칸으로 계산).
+func markdownIndent(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// markdownListEnd는 start에서 시작하는 목록 블록이 끝나는 줄 인덱스(미포함)를 반환한다.
+// 더 깊게 들여쓴 줄과 같은 들여쓰기의 목록 항목은 같은 목록으로 본다.
+func markdownListEnd(lines []string, start int) int {
+	baseIndent := markdownIndent(lines[start])
+	i := start + 1
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			// 빈 줄 다음에 목록이 이어지지 않으면 목록을 종료한다.
+			if i+1 < len(lines) && markdownIndent(lines[i+1]) > baseIndent {
+				continue
+			}
+			if i+1 < len(lines) && isMarkdownListItem(lines[i+1]) && markdownIndent(lines[i+1]) == baseIndent {
+				continue
+			}
+			break
+		}
+		indent := markdownIndent(line)
+		if indent > baseIndent {
+			continue
+		}
+		if indent == baseIndent && isMarkdownListItem(line) {
+			continue
+		}
+		break
+	}
+	return i
+}
+
+// isMarkdownListItem은 줄이 글머리표 또는 번호 목록 항목인지 확인한다.
+func isMarkdownListItem(line string) bool {
+	return mdBulletPattern.MatchString(line) || mdOrderedPattern.MatchString(line)
+}
+
+// buildMarkdownList는 목록 블록을 bulletList/orderedList 노드로 변환한다.
+// 항목보다 깊게 들여쓴 줄은 해당 항목의 하위 블록으로 재귀 변환한다.
+func buildMarkdownList(lines []string) adfNode {
+	baseIndent := markdownIndent(lines[0])
+	list := adfNode{Type: "bulletList"}
+	if m := mdOrderedPattern.FindStringSubmatch(lines[0]); m != nil {
+		list.Type = "orderedList"
+		if order, err := strconv.Atoi(m[2]); err == nil && order != 1 {
+			list.Attrs = map[string]interface{}{"order": order}
+		}
+	}
+
+	var itemText string
+	var children []string
+	hasItem := false
+
+	flushItem := func() {
+		if !hasItem {
+			return
+		}
+		item := adfNode{Type: "listItem"}
+		item.Content = append(item.Content, adfNode{Type: "paragraph", Content: parseMarkdownInline(itemText, nil)})
+		if len(children) > 0 {
+			item.Content = append(item.Content, parseMarkdownBlocks(dedentMarkdownLines(children))...)
+		}
+		list.Content = append(list.Content, item)
+		itemText = ""
+		children = nil
+		hasItem = false
+	}
+
+	for _, line := range lines {
+		if markdownIndent(line) == baseIndent && isMarkdownListItem(line) {
+			flushItem()
+			hasItem = true
+			if m := mdBulletPattern.FindStringSubmatch(line); m != nil {
+				itemText = m[2]
+			} else if m := mdOrderedPattern.FindStringSubmatch(line); m != nil {
+				itemText = m[3]
+			}
+			itemText = replaceMarkdownTaskBox(itemText)
+			continue
+		}
+		children = append(children, line)
+	}
+	flushItem()
+
+	return list
+}
+
+// replaceMarkdownTaskBox는 "[ ]", "[x]" 체크박스 표기를 기호로 바꾼다.
+func replaceMarkdownTaskBox(text string) string {
+	switch {
+	case strings.HasPrefix(text, "[ ] "):
+		return "☐ " + text[4:]
+	case strings.HasPrefix(text, "[x] "), strings.HasPrefix(text, "[X] "):
+		return "☑ " + text[4:]
+	}
+	return text
+}
+
+// dedentMarkdownLines는 줄 목록에서 공통 들여쓰기를 제거한다.
+func dedentMarkdownLines(lines []string) []string {
+	minIndent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indent := markdownIndent(line); minIndent < 0 || indent < minIndent {
+			minIndent = indent
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if len(line) >= minIndent && minIndent > 0 {
+			line = line[minIndent:]
+		}
+		result[i] = line
+	}
+	return result
+}
+
+// parseMarkdownInlineLines는 여러 줄로 된 문단을 hardBreak로 이어 인라인 노드로 변환한다.
+func parseMarkdownInlineLines(lines []string) []adfNode {
+	var nodes []adfNode
+	for i, line := range lines {
+		if i > 0 {
+			nodes = append(nodes, adfNode{Type: "hardBreak"})
+		}
+		nodes = append(nodes, parseMarkdownInline(line, nil)...)
+	}
+	return nodes
+}
+
+// parseMarkdownInline은 한 줄의 인라인 서식을 text 노드와 mark로 변환한다.
+func parseMarkdownInline(text string, marks []adfMark) []adfNode {
+	var nodes []adfNode
+	var plain strings.Builder
+
+	flushPlain := func() {
+		if plain.Len() == 0 {
+			return
+		}
+		nodes = append(nodes, newADFText(plain.String(), marks))
+		plain.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		// 인라인 코드: 내부 서식은 해석하지 않는다.
+		if rest[0] == '`' {
+			if end := strings.Index(rest[1:], "`"); end > 0 {
+				flushPlain()
+				nodes = append(nodes, newADFText(rest[1:1+end], appendADFMark(linkMarksOnly(marks), adfMark{Type: "code"})))
+				i += end + 2
+				continue
+			}
+		}
+
+		// 링크: [텍스트](URL)
+		if rest[0] == '[' {
+			if closeText := strings.Index(rest, "]("); closeText > 0 {
+				if closeURL := strings.Index(rest[closeText+2:], ")"); closeURL > 0 {
+					flushPlain()
+					href := rest[closeText+2 : closeText+2+closeURL]
+					link := adfMark{Type: "link", Attrs: map[string]interface{}{"href": href}}
+					nodes = append(nodes, parseMarkdownInline(rest[1:closeText], appendADFMark(marks, link))...)
+					i += closeText + 2 + closeURL + 1
+					continue
+				}
+			}
+		}
+
+		// 굵게 / 취소선: **텍스트**, __텍스트__, ~~텍스트~~
+		if delim, markType, ok := markdownDoubleDelimiter(rest); ok {
+			if end := strings.Index(rest[2:], delim); end > 0 {
+				flushPlain()
+				nodes = append(nodes, parseMarkdownInline(rest[2:2+end], appendADFMark(marks, adfMark{Type: markType}))...)
+				i += end + 4
+				continue
+			}
+		}
+
+		// 기울임: *텍스트*, _텍스트_ (단어 중간의 '_'는 무시)
+		if (rest[0] == '*' || rest[0] == '_') && len(rest) > 2 && rest[1] != ' ' {
+			prevIsWord := i > 0 && isMarkdownWordChar(text[i-1])
+			if rest[0] == '*' || !prevIsWord {
+				if end := strings.IndexByte(rest[1:], rest[0]); end > 0 && rest[end] != ' ' {
+					after := 1 + end + 1
+					if rest[0] == '*' || after >= len(rest) || !isMarkdownWordChar(rest[after]) {
+						flushPlain()
+						nodes = append(nodes, parseMarkdownInline(rest[1:1+end], appendADFMark(marks, adfMark{Type: "em"}))...)
+						i += after
+						continue
+					}
+				}
+			}
+		}
+
+		plain.WriteByte(text[i])
+		i++
+	}
+	flushPlain()
+
+	return nodes
+}
+
+// markdownDoubleDelimiter는 두 글자 서식 구분자와 대응하는 ADF mark 타입을 반환한다.
+func markdownDoubleDelimiter(s string) (string, string, bool) {
+	if len(s) < 4 {
+		return "", "", false
+	}
+	switch s[:2] {
+	case "**", "__":
+		return s[:2], "strong", true
+	case "~~":
+		return s[:2], "strike", true
+	}
+	return "", "", false
+}
+
+// isMarkdownWordChar는 '_' 기울임 판정에 쓰이는 단어 문자인지 확인한다.
+func isMarkdownWordChar(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// linkMarksOnly는 code mark와 함께 쓸 수 있는 link mark만 남긴다 (ADF 제약).
+func linkMarksOnly(marks []adfMark) []adfMark {
+	var result []adfMark
+	for _, mark := range marks {
+		if mark.Type == "link" {
+			result = append(result, mark)
+		}
+	}
+	return result
+}
+
+// appendADFMark는 기존 mark 목록을 변경하지 않고 새 mark를 덧붙인다.
+func appendADFMark(marks []adfMark, mark adfMark) []adfMark {
+	result := make([]adfMark, 0, len(marks)+1)
+	result = append(result, marks...)
+	return append(result, mark)
+}
+
+// newADFText는 mark가 적용된 text 노드를 생성한다.
+func newADFText(text string, marks []adfMark) adfNode {
+	node := adfNode{Type: "text", Text: text}
+	if len(marks) > 0 {
+		node.Marks = marks
+	}
+	return node
+}
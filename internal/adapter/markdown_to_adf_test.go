@@ -0,0 +1,105 @@
+package adapter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// adfJSON은 비교를 위해 ADF 노드를 JSON 문자열로 직렬화한다.
+func adfJSON(t *testing.T, node interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(node)
+	if err != nil {
+		t.Fatalf("failed to marshal ADF: %v", err)
+	}
+	return string(data)
+}
+
+func TestMarkdownToADF_Blocks(t *testing.T) {
+	markdown := "# Title\n\nFirst line\nsecond line\n\n```go\nfmt.Println(1)\n```\n\n> quoted\n\n---"
+
+	doc := markdownToADF(markdown)
+
+	if doc.Type != "doc" || doc.Version != 1 {
+		t.Fatalf("unexpected root: %+v", doc)
+	}
+	var types []string
+	for _, block := range doc.Content {
+		types = append(types, block.Type)
+	}
+	expected := []string{"heading", "paragraph", "codeBlock", "blockquote", "rule"}
+	if adfJSON(t, types) != adfJSON(t, expected) {
+		t.Fatalf("expected blocks %v, got %v", expected, types)
+	}
+
+	if doc.Content[0].Attrs["level"] != 1 {
+		t.Errorf("expected heading level 1, got %v", doc.Content[0].Attrs["level"])
+	}
+	paragraph := doc.Content[1].Content
+	if len(paragraph) != 3 || paragraph[1].Type != "hardBreak" {
+		t.Errorf("expected paragraph lines joined by hardBreak, got %s", adfJSON(t, paragraph))
+	}
+	code := doc.Content[2]
+	if code.Attrs["language"] != "go" || code.Content[0].Text != "fmt.Println(1)" {
+		t.Errorf("unexpected code block: %s", adfJSON(t, code))
+	}
+}
+
+func TestMarkdownToADF_NestedLists(t *testing.T) {
+	markdown := "1. first\n   - child a\n   - child b\n2. second\n- [x] done"
+
+	doc := markdownToADF(markdown)
+
+	if len(doc.Content) != 1 {
+		t.Fatalf("expected a single list, got %s", adfJSON(t, doc.Content))
+	}
+	list := doc.Content[0]
+	if list.Type != "orderedList" || len(list.Content) != 3 {
+		t.Fatalf("expected orderedList with 3 items, got %s", adfJSON(t, list))
+	}
+	first := list.Content[0]
+	if len(first.Content) != 2 || first.Content[1].Type != "bulletList" || len(first.Content[1].Content) != 2 {
+		t.Errorf("expected nested bulletList with 2 items, got %s", adfJSON(t, first))
+	}
+	if text := list.Content[2].Content[0].Content[0].Text; text != "☑ done" {
+		t.Errorf("expected task box converted, got %q", text)
+	}
+}
+
+func TestMarkdownToADF_InlineMarks(t *testing.T) {
+	doc := markdownToADF("Use **bold `code`** and [docs](https://example.com) in snake_case_name")
+
+	got := adfJSON(t, doc.Content[0].Content)
+	expected := `[{"type":"text","text":"Use "},` +
+		`{"type":"text","text":"bold ","marks":[{"type":"strong"}]},` +
+		`{"type":"text","text":"code","marks":[{"type":"code"}]},` +
+		`{"type":"text","text":" and "},` +
+		`{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]},` +
+		`{"type":"text","text":" in snake_case_name"}]`
+	if got != expected {
+		t.Errorf("unexpected inline nodes:\n got: %s\nwant: %s", got, expected)
+	}
+}
+
+func TestMarkdownToADF_Table(t *testing.T) {
+	doc := markdownToADF("| File | Change |\n|------|--------|\n| a.go | fix |")
+
+	table := doc.Content[0]
+	if table.Type != "table" || len(table.Content) != 2 {
+		t.Fatalf("expected table with 2 rows, got %s", adfJSON(t, table))
+	}
+	if table.Content[0].Content[0].Type != "tableHeader" || table.Content[1].Content[1].Type != "tableCell" {
+		t.Errorf("unexpected cell types: %s", adfJSON(t, table))
+	}
+	if text := table.Content[1].Content[0].Content[0].Content[0].Text; text != "a.go" {
+		t.Errorf("expected cell text a.go, got %q", text)
+	}
+}
+
+func TestMarkdownToADF_Empty(t *testing.T) {
+	doc := markdownToADF("")
+
+	if len(doc.Content) != 1 || doc.Content[0].Type != "paragraph" {
+		t.Errorf("expected single empty paragraph, got %s", adfJSON(t, doc))
+	}
+}
@@ -0,0 +1,143 @@
+package adapter
+
+import (
+	"strings"
+)
+
+// markdownToWiki는 Markdown을 Jira Server/Data Center(REST v2)의 wiki markup으로 변환한다.
+// Markdown 해석은 markdownToADF와 공유하고, 만들어진 ADF 트리를 wiki 표기로 출력한다.
+func markdownToWiki(markdown string) string {
+	doc := markdownToADF(markdown)
+	return strings.TrimSpace(renderWikiBlocks(doc.Content, ""))
+}
+
+// renderWikiBlocks는 블록 노드 목록을 빈 줄로 구분하여 출력한다. listPrefix는 중첩 목록 마커다.
+func renderWikiBlocks(nodes []adfNode, listPrefix string) string {
+	var parts []string
+	for _, node := range nodes {
+		if rendered := renderWikiBlock(node, listPrefix); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderWikiBlock은 단일 블록 노드를 wiki markup으로 변환한다.
+func renderWikiBlock(node adfNode, listPrefix string) string {
+	switch node.Type {
+	case "paragraph":
+		return renderWikiInline(node.Content)
+
+	case "heading":
+		level, _ := node.Attrs["level"].(int)
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return "h" + string(rune('0'+level)) + ". " + renderWikiInline(node.Content)
+
+	case "bulletList", "orderedList":
+		marker := "*"
+		if node.Type == "orderedList" {
+			marker = "#"
+		}
+		return renderWikiList(node, listPrefix+marker)
+
+	case "codeBlock":
+		var code strings.Builder
+		for _, child := range node.Content {
+			code.WriteString(child.Text)
+		}
+		open := "{code}"
+		if language, _ := node.Attrs["language"].(string); language != "" {
+			open = "{code:" + language + "}"
+		}
+		return open + "\n" + code.String() + "\n{code}"
+
+	case "blockquote":
+		return "{quote}\n" + renderWikiBlocks(node.Content, "") + "\n{quote}"
+
+	case "rule":
+		return "----"
+
+	case "table":
+		return renderWikiTableNode(node)
+	}
+	return renderWikiInline(node.Content)
+}
+
+// renderWikiList는 목록 항목을 "* 항목", "*# 하위 항목" 형식으로 출력한다.
+func renderWikiList(list adfNode, prefix string) string {
+	var lines []string
+	for _, item := range list.Content {
+		for i, child := range item.Content {
+			switch {
+			case child.Type == "bulletList" || child.Type == "orderedList":
+				lines = append(lines, renderWikiBlock(child, prefix))
+			case i == 0:
+				lines = append(lines, prefix+" "+renderWikiInline(child.Content))
+			default:
+				// 항목의 추가 문단은 줄바꿈(\\)으로 같은 항목에 붙인다.
+				lines[len(lines)-1] += " \\\\ " + renderWikiInline(child.Content)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderWikiTableNode는 표 노드를 ||머리글|| / |셀| 행으로 출력한다.
+func renderWikiTableNode(table adfNode) string {
+	var rows []string
+	for _, row := range table.Content {
+		sep := "|"
+		var cells []string
+		for _, cell := range row.Content {
+			if cell.Type == "tableHeader" {
+				sep = "||"
+			}
+			cells = append(cells, strings.ReplaceAll(renderWikiBlocks(cell.Content, ""), "\n", " \\\\ "))
+		}
+		rows = append(rows, sep+strings.Join(cells, sep)+sep)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderWikiInline은 인라인 노드를 wiki 서식으로 출력한다.
+func renderWikiInline(nodes []adfNode) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "hardBreak":
+			sb.WriteString("\n")
+		case "text":
+			sb.WriteString(applyWikiMarks(node.Text, node.Marks))
+		}
+	}
+	return sb.String()
+}
+
+// applyWikiMarks는 텍스트에 mark를 wiki 서식으로 적용한다.
+func applyWikiMarks(text string, marks []adfMark) string {
+	if len(marks) == 0 {
+		return text
+	}
+	formatted := text
+	var href string
+	for _, mark := range marks {
+		switch mark.Type {
+		case "code":
+			formatted = "{{" + formatted + "}}"
+		case "strong":
+			formatted = "*" + formatted + "*"
+		case "em":
+			formatted = "_" + formatted + "_"
+		case "strike":
+			formatted = "-" + formatted + "-"
+		case "link":
+			href, _ = mark.Attrs["href"].(string)
+		}
+	}
+	if href != "" {
+		formatted = "[" + formatted + "|" + href + "]"
+	}
+	return formatted
+}
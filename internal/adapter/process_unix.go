@@ -0,0 +1,31 @@
+//go:build !windows
+
+package adapter
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// ProcessRunning reports whether a process with the given PID exists
+func ProcessRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// 시그널 0은 보내지 않고 존재와 권한만 확인한다 (다른 사용자의 프로세스면 EPERM)
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// TerminateProcess asks the process to stop with SIGTERM so the agent CLI can clean up
+func TerminateProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := proc.Signal(syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build windows
+
+package adapter
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// stillActive is the exit code GetExitCodeProcess reports for a running process (STILL_ACTIVE)
+const stillActive = 259
+
+// ProcessRunning reports whether a process with the given PID exists
+func ProcessRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// 권한이 없어 열 수 없는 프로세스는 실행 중으로 본다
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	defer syscall.CloseHandle(handle)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// TerminateProcess stops the process. Windows에는 SIGTERM이 없으므로 바로 종료한다.
+func TerminateProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := proc.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"jira-ai-generator/internal/logger"
+)
+
+// RetryPolicy controls how RetryTransport retries failed requests and limits concurrency
+type RetryPolicy struct {
+	MaxRetries           int           // 첫 시도 이후 재시도 횟수 (0이면 재시도하지 않음)
+	BaseDelay            time.Duration // 첫 재시도 대기 시간, 이후 2배씩 증가
+	MaxDelay             time.Duration // 대기 시간 상한 (Retry-After가 이보다 길면 재시도하지 않음)
+	AttemptTimeout       time.Duration // 시도 1회에서 응답 헤더를 받을 때까지의 제한 시간 (0이면 제한 없음)
+	MaxConcurrentPerHost int           // 호스트별 동시 요청 수 (0 이하이면 제한 없음)
+}
+
+// DefaultRetryPolicy returns the policy used by NewJiraClient
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:           3,
+		BaseDelay:            500 * time.Millisecond,
+		MaxDelay:             30 * time.Second,
+		AttemptTimeout:       30 * time.Second,
+		MaxConcurrentPerHost: 4,
+	}
+}
+
+// RetryEvent describes a retry decision made by RetryTransport
+type RetryEvent struct {
+	Method     string
+	URL        string
+	Attempt    int           // 다음 시도 번호 (첫 재시도는 2)
+	MaxRetries int           // 정책상 최대 재시도 횟수
+	StatusCode int           // 재시도 사유가 된 응답 상태 코드 (네트워크 오류면 0)
+	Err        error         // 재시도 사유가 된 네트워크 오류
+	Delay      time.Duration // 다음 시도까지 대기 시간
+}
+
+// String formats the event for logs
+func (e RetryEvent) String() string {
+	reason := fmt.Sprintf("HTTP %d", e.StatusCode)
+	if e.Err != nil {
+		reason = e.Err.Error()
+	}
+	return fmt.Sprintf("Jira 요청 재시도 %d/%d (%s, %s %s): %s 후 재시도",
+		e.Attempt-1, e.MaxRetries, reason, e.Method, e.URL, e.Delay.Round(time.Millisecond))
+}
+
+// RetryTransport is an http.RoundTripper that retries 429/502/503/504 and network errors
+// with exponential backoff and jitter, honours Retry-After, and limits concurrent requests per host.
+type RetryTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	onRetry func(RetryEvent)
+
+	mu        sync.Mutex
+	hostSlots map[string]chan struct{}
+
+	sleep  func(ctx context.Context, d time.Duration) error // 테스트에서 대기를 대체한다
+	jitter func() float64                                   // [0, 1) 난수
+}
+
+// NewRetryTransport wraps base (http.DefaultTransport if nil) with the given policy
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		base:      base,
+		policy:    policy,
+		hostSlots: make(map[string]chan struct{}),
+		sleep:     sleepContext,
+		jitter:    rand.Float64,
+	}
+}
+
+// SetPolicy replaces the retry policy. 이미 만들어진 호스트별 슬롯은 새 동시성 제한으로 다시 만든다.
+func (t *RetryTransport) SetPolicy(policy RetryPolicy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.policy = policy
+	t.hostSlots = make(map[string]chan struct{})
+}
+
+// SetObserver registers a callback invoked before each retry (e.g. to surface it in the UI log)
+func (t *RetryTransport) SetObserver(onRetry func(RetryEvent)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onRetry = onRetry
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	policy := t.policy
+	onRetry := t.onRetry
+	t.mu.Unlock()
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			// 재시도 가능 여부는 canReplay에서 확인했으므로 GetBody가 있다
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.roundTripOnce(req, policy)
+
+		canRetry := attempt <= policy.MaxRetries && canReplay(req) && ctx.Err() == nil
+		if !canRetry || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(policy, attempt)
+		event := RetryEvent{Method: req.Method, URL: req.URL.Redacted(), Attempt: attempt + 1, MaxRetries: policy.MaxRetries, Err: err, Delay: delay}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > policy.MaxDelay {
+					// 서버가 요구한 대기 시간이 너무 길면 호출자에게 그대로 돌려준다
+					logger.Debug("RetryTransport: Retry-After %s exceeds max delay, giving up: %s %s", retryAfter, req.Method, event.URL)
+					return resp, nil
+				}
+				event.Delay = retryAfter
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		logger.Debug("RetryTransport: %s", event)
+		if onRetry != nil {
+			onRetry(event)
+		}
+		if err := t.sleep(ctx, event.Delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTripOnce performs one attempt while holding a per-host slot.
+// 제한 시간은 응답 헤더까지만 적용되어 큰 첨부파일 본문은 호출자의 ctx가 끝날 때까지 받을 수 있다.
+// 슬롯은 응답 본문을 끝까지 읽거나 닫는 시점 중 먼저 오는 때에 해제되어,
+// 본문을 다 읽은 호출자가 닫기 전에 같은 호스트로 다음 요청을 보내도 교착되지 않는다.
+func (t *RetryTransport) roundTripOnce(req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	slot := t.hostSlot(req.URL.Host, policy.MaxConcurrentPerHost)
+	if slot != nil {
+		select {
+		case slot <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	req = req.WithContext(ctx)
+	var timer *time.Timer
+	if policy.AttemptTimeout > 0 {
+		timer = time.AfterFunc(policy.AttemptTimeout, cancel)
+	}
+	var releaseOnce sync.Once
+	releaseSlot := func() {
+		releaseOnce.Do(func() {
+			if slot != nil {
+				<-slot
+			}
+		})
+	}
+	release := func() {
+		cancel()
+		releaseSlot()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if timer != nil && !timer.Stop() && err == nil {
+		// 헤더를 받은 직후 제한 시간이 지나 본문이 이미 취소되었다
+		resp.Body.Close()
+		release()
+		return nil, fmt.Errorf("timeout awaiting response headers after %s", policy.AttemptTimeout)
+	}
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, releaseSlot: releaseSlot, release: release}
+	return resp, nil
+}
+
+// hostSlot returns the semaphore for host, or nil when concurrency is unlimited
+func (t *RetryTransport) hostSlot(host string, limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	slot, ok := t.hostSlots[host]
+	if !ok {
+		slot = make(chan struct{}, limit)
+		t.hostSlots[host] = slot
+	}
+	return slot
+}
+
+// backoff returns BaseDelay*2^(attempt-1) capped at MaxDelay, with jitter in [50%, 100%]
+func (t *RetryTransport) backoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(t.jitter()*float64(delay-half))
+}
+
+// releasingBody releases the attempt's host slot once the body is fully read (EOF or error),
+// and the slot and attempt context once the body is closed
+type releasingBody struct {
+	io.ReadCloser
+	releaseSlot func()
+	release     func()
+}
+
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.releaseSlot()
+	}
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// shouldRetry reports whether the outcome is transient.
+// 429는 서버가 요청을 처리하지 않았으므로 모든 메서드를 재시도하고,
+// 5xx와 네트워크 오류는 중복 실행을 피하기 위해 멱등 메서드만 재시도한다.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// canReplay reports whether the request body can be sent again (스트리밍 업로드는 재전송할 수 없다)
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,291 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scriptedServer는 요청 순서대로 statuses의 상태 코드를 응답하고, 목록을 다 쓰면 200을 응답한다.
+func scriptedServer(t *testing.T, statuses []int, headers map[int]http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n < len(statuses) {
+			for key, values := range headers[n] {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[n])
+			w.Write([]byte(`{"errorMessages":["scripted failure"]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"startAt":0,"maxResults":50,"total":0,"issues":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// newTestRetryTransport는 실제로 대기하지 않고 요청된 대기 시간만 기록하는 transport를 만든다.
+func newTestRetryTransport(policy RetryPolicy) (*RetryTransport, *[]time.Duration) {
+	var slept []time.Duration
+	transport := NewRetryTransport(nil, policy)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return ctx.Err()
+	}
+	transport.jitter = func() float64 { return 1 }
+	return transport, &slept
+}
+
+// TestRetryTransport_HonoursRetryAfter는 429 응답의 Retry-After만큼 대기한 뒤 재시도하고 관찰자에게 알리는지 검증한다.
+func TestRetryTransport_HonoursRetryAfter(t *testing.T) {
+	server, calls := scriptedServer(t, []int{429}, map[int]http.Header{
+		0: {"Retry-After": []string{"7"}},
+	})
+	transport, slept := newTestRetryTransport(DefaultRetryPolicy())
+	var events []RetryEvent
+	transport.SetObserver(func(e RetryEvent) { events = append(events, e) })
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	client.httpClient.Transport = transport
+	if _, err := client.SearchIssues(context.Background(), "project = TEST", 0, 50); err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+	if len(*slept) != 1 || (*slept)[0] != 7*time.Second {
+		t.Errorf("expected a single 7s wait from Retry-After, got %v", *slept)
+	}
+	if len(events) != 1 || events[0].StatusCode != 429 || events[0].Attempt != 2 {
+		t.Fatalf("unexpected retry events: %+v", events)
+	}
+	if !strings.Contains(events[0].String(), "HTTP 429") {
+		t.Errorf("expected status in log message, got %q", events[0].String())
+	}
+}
+
+// TestRetryTransport_ExponentialBackoffThenSuccess는 연속된 502/503/504에 대해 대기 시간이 2배씩 늘어나는지 검증한다.
+func TestRetryTransport_ExponentialBackoffThenSuccess(t *testing.T) {
+	server, calls := scriptedServer(t, []int{503, 502, 504}, nil)
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 100 * time.Millisecond
+	transport, slept := newTestRetryTransport(policy)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 after retries, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(calls); got != 4 {
+		t.Errorf("expected 4 requests, got %d", got)
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+	if len(*slept) != len(want) {
+		t.Fatalf("expected waits %v, got %v", want, *slept)
+	}
+	for i := range want {
+		if (*slept)[i] != want[i] {
+			t.Errorf("wait %d: expected %v, got %v", i, want[i], (*slept)[i])
+		}
+	}
+}
+
+// TestRetryTransport_GivesUpAfterMaxRetries는 재시도 횟수를 모두 쓰면 마지막 응답을 그대로 에러로 돌려주는지 검증한다.
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := scriptedServer(t, []int{503, 503, 503, 503, 503}, nil)
+	policy := DefaultRetryPolicy()
+	policy.MaxRetries = 2
+	transport, _ := newTestRetryTransport(policy)
+
+	client := NewJiraClient(server.URL, "user@example.com", "token")
+	client.httpClient.Transport = transport
+	_, err := client.SearchIssues(context.Background(), "project = TEST", 0, 50)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected 503 error after retries, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("expected 1 attempt + 2 retries, got %d requests", got)
+	}
+}
+
+// TestRetryTransport_DoesNotRetryNonIdempotentOn5xx는 POST 요청은 5xx에서 중복 실행을 피하기 위해 재시도하지 않는지 검증한다.
+func TestRetryTransport_DoesNotRetryNonIdempotentOn5xx(t *testing.T) {
+	server, calls := scriptedServer(t, []int{503}, nil)
+	transport, _ := newTestRetryTransport(DefaultRetryPolicy())
+
+	resp, err := (&http.Client{Transport: transport}).Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || atomic.LoadInt32(calls) != 1 {
+		t.Errorf("expected POST 503 to be returned without retry, status=%d calls=%d", resp.StatusCode, atomic.LoadInt32(calls))
+	}
+}
+
+// TestRetryTransport_ReplaysBodyOn429는 429로 거절된 POST 요청을 같은 본문으로 다시 보내는지 검증한다.
+func TestRetryTransport_ReplaysBodyOn429(t *testing.T) {
+	var bodies []string
+	var mu sync.Mutex
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	transport, _ := newTestRetryTransport(DefaultRetryPolicy())
+
+	resp, err := (&http.Client{Transport: transport}).Post(server.URL, "application/json", strings.NewReader(`{"body":"hi"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected 201 after retry, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"body":"hi"}` {
+		t.Errorf("expected body to be replayed, got %q", bodies)
+	}
+}
+
+// TestRetryTransport_RetryAfterBeyondMaxDelayIsReturned는 MaxDelay보다 긴 Retry-After는 기다리지 않고 응답을 돌려주는지 검증한다.
+func TestRetryTransport_RetryAfterBeyondMaxDelayIsReturned(t *testing.T) {
+	server, calls := scriptedServer(t, []int{429}, map[int]http.Header{
+		0: {"Retry-After": []string{"3600"}},
+	})
+	transport, slept := newTestRetryTransport(DefaultRetryPolicy())
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || atomic.LoadInt32(calls) != 1 || len(*slept) != 0 {
+		t.Errorf("expected 429 returned immediately, status=%d calls=%d waits=%v", resp.StatusCode, atomic.LoadInt32(calls), *slept)
+	}
+}
+
+// TestRetryTransport_LimitsConcurrencyPerHost는 호스트별 동시 요청 수가 MaxConcurrentPerHost를 넘지 않는지 검증한다.
+func TestRetryTransport_LimitsConcurrencyPerHost(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.MaxConcurrentPerHost = 2
+	client := &http.Client{Transport: NewRetryTransport(nil, policy)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+// TestRetryTransport_ReleasesSlotAtEOF는 본문을 끝까지 읽으면 닫기 전에도 슬롯이 해제되어
+// 동시 요청 수가 1이어도 같은 호스트로 이어지는 요청이 막히지 않는지 검증한다.
+func TestRetryTransport_ReleasesSlotAtEOF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.MaxConcurrentPerHost = 1
+	client := &http.Client{Transport: NewRetryTransport(nil, policy)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	first, err := client.Do(mustRequest(t, ctx, server.URL))
+	if err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	defer first.Body.Close()
+	if _, err := io.ReadAll(first.Body); err != nil {
+		t.Fatalf("failed to read first body: %v", err)
+	}
+
+	second, err := client.Do(mustRequest(t, ctx, server.URL))
+	if err != nil {
+		t.Fatalf("expected the nested request to get a slot once the first body hit EOF, got %v", err)
+	}
+	second.Body.Close()
+}
+
+func mustRequest(t *testing.T, ctx context.Context, url string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	return req
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"12", 12 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
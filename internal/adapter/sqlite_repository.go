@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/logger"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -104,6 +106,77 @@ func (r *SQLiteRepository) migrate() error {
 		return err
 	}
 
+	if err := r.addColumnIfMissing("analysis_results", "jira_comment_id", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// 3차에서 이어서 실행할 CLI 세션 ID
+	if err := r.addColumnIfMissing("analysis_results", "session_id", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// CLI가 보고한 토큰 사용량과 비용
+	for _, column := range []struct{ name, definition string }{
+		{"input_tokens", "INTEGER DEFAULT 0"},
+		{"output_tokens", "INTEGER DEFAULT 0"},
+		{"cache_creation_tokens", "INTEGER DEFAULT 0"},
+		{"cache_read_tokens", "INTEGER DEFAULT 0"},
+		{"cost_usd", "REAL DEFAULT 0"},
+		{"duration_ms", "INTEGER DEFAULT 0"},
+		{"num_turns", "INTEGER DEFAULT 0"},
+	} {
+		if err := r.addColumnIfMissing("analysis_results", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	// 첨부파일 캐시 정보 (Jira 첨부파일 ID, 크기, 내용 해시)
+	for _, column := range []struct{ name, definition string }{
+		{"jira_attachment_id", "TEXT DEFAULT ''"},
+		{"size", "INTEGER DEFAULT 0"},
+		{"content_hash", "TEXT DEFAULT ''"},
+	} {
+		if err := r.addColumnIfMissing("attachments", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addColumnIfMissing은 기존 DB에 없는 컬럼을 ALTER TABLE로 추가한다.
+func (r *SQLiteRepository) addColumnIfMissing(table, column, definition string) error {
+	rows, err := r.db.Query(fmt.Sprintf(`PRAGMA table_info(%s)`, table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s table columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return fmt.Errorf("failed to scan %s table column: %w", table, err)
+		}
+		if strings.EqualFold(name, column) {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s table columns: %w", table, err)
+	}
+	rows.Close()
+
+	logger.Debug("addColumnIfMissing: adding %s.%s", table, column)
+	if _, err := r.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
@@ -446,8 +519,9 @@ func (r *SQLiteRepository) ListIssuesByChannelAndPhase(channelIndex, phase int)
 
 // CreateAnalysisResult creates a new analysis result
 func (r *SQLiteRepository) CreateAnalysisResult(result *domain.AnalysisResult) error {
-	query := `INSERT INTO analysis_results (issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO analysis_results (issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message, jira_comment_id,
+			input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, duration_ms, num_turns, session_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	res, err := r.db.Exec(query,
 		result.IssueID,
@@ -459,6 +533,15 @@ func (r *SQLiteRepository) CreateAnalysisResult(result *domain.AnalysisResult) e
 		result.StartedAt,
 		result.CompletedAt,
 		result.ErrorMessage,
+		result.JiraCommentID,
+		result.Usage.InputTokens,
+		result.Usage.OutputTokens,
+		result.Usage.CacheCreationTokens,
+		result.Usage.CacheReadTokens,
+		result.Usage.CostUSD,
+		result.Usage.DurationMS,
+		result.Usage.NumTurns,
+		result.SessionID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create analysis result: %w", err)
@@ -475,7 +558,8 @@ func (r *SQLiteRepository) CreateAnalysisResult(result *domain.AnalysisResult) e
 
 // GetAnalysisResult retrieves an analysis result by issue ID and phase
 func (r *SQLiteRepository) GetAnalysisResult(issueID int64, phase int) (*domain.AnalysisResult, error) {
-	query := `SELECT id, issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message
+	query := `SELECT id, issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message, COALESCE(jira_comment_id, ''),
+			input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, duration_ms, num_turns, COALESCE(session_id, '')
 		FROM analysis_results WHERE issue_id = ? AND analysis_phase = ?`
 
 	var result domain.AnalysisResult
@@ -492,6 +576,15 @@ func (r *SQLiteRepository) GetAnalysisResult(issueID int64, phase int) (*domain.
 		&startedAt,
 		&completedAt,
 		&result.ErrorMessage,
+		&result.JiraCommentID,
+		&result.Usage.InputTokens,
+		&result.Usage.OutputTokens,
+		&result.Usage.CacheCreationTokens,
+		&result.Usage.CacheReadTokens,
+		&result.Usage.CostUSD,
+		&result.Usage.DurationMS,
+		&result.Usage.NumTurns,
+		&result.SessionID,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("analysis result not found for issue %d phase %d", issueID, phase)
@@ -513,7 +606,8 @@ func (r *SQLiteRepository) GetAnalysisResult(issueID int64, phase int) (*domain.
 // UpdateAnalysisResult updates an existing analysis result
 func (r *SQLiteRepository) UpdateAnalysisResult(result *domain.AnalysisResult) error {
 	logger.Debug("UpdateAnalysisResult: ID=%d, status=%s", result.ID, result.Status)
-	query := `UPDATE analysis_results SET result_path = ?, plan_path = ?, execution_path = ?, status = ?, started_at = ?, completed_at = ?, error_message = ?
+	query := `UPDATE analysis_results SET result_path = ?, plan_path = ?, execution_path = ?, status = ?, started_at = ?, completed_at = ?, error_message = ?, jira_comment_id = ?,
+			input_tokens = ?, output_tokens = ?, cache_creation_tokens = ?, cache_read_tokens = ?, cost_usd = ?, duration_ms = ?, num_turns = ?, session_id = ?
 		WHERE id = ?`
 
 	_, err := r.db.Exec(query,
@@ -524,6 +618,15 @@ func (r *SQLiteRepository) UpdateAnalysisResult(result *domain.AnalysisResult) e
 		result.StartedAt,
 		result.CompletedAt,
 		result.ErrorMessage,
+		result.JiraCommentID,
+		result.Usage.InputTokens,
+		result.Usage.OutputTokens,
+		result.Usage.CacheCreationTokens,
+		result.Usage.CacheReadTokens,
+		result.Usage.CostUSD,
+		result.Usage.DurationMS,
+		result.Usage.NumTurns,
+		result.SessionID,
 		result.ID,
 	)
 	if err != nil {
@@ -621,8 +724,9 @@ func (r *SQLiteRepository) ListAllIssues() ([]*domain.IssueRecord, error) {
 
 // ListAnalysisResultsByIssue lists all analysis results for an issue
 func (r *SQLiteRepository) ListAnalysisResultsByIssue(issueID int64) ([]*domain.AnalysisResult, error) {
-	query := `SELECT id, issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message
-		FROM analysis_results WHERE issue_id = ? ORDER BY analysis_phase`
+	query := `SELECT id, issue_id, analysis_phase, result_path, plan_path, execution_path, status, started_at, completed_at, error_message, COALESCE(jira_comment_id, ''),
+			input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, duration_ms, num_turns, COALESCE(session_id, '')
+		FROM analysis_results WHERE issue_id = ? ORDER BY analysis_phase, id`
 
 	rows, err := r.db.Query(query, issueID)
 	if err != nil {
@@ -646,6 +750,15 @@ func (r *SQLiteRepository) ListAnalysisResultsByIssue(issueID int64) ([]*domain.
 			&startedAt,
 			&completedAt,
 			&result.ErrorMessage,
+			&result.JiraCommentID,
+			&result.Usage.InputTokens,
+			&result.Usage.OutputTokens,
+			&result.Usage.CacheCreationTokens,
+			&result.Usage.CacheReadTokens,
+			&result.Usage.CostUSD,
+			&result.Usage.DurationMS,
+			&result.Usage.NumTurns,
+			&result.SessionID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan analysis result: %w", err)
@@ -664,10 +777,104 @@ func (r *SQLiteRepository) ListAnalysisResultsByIssue(issueID int64) ([]*domain.
 	return results, nil
 }
 
+// SumAnalysisUsage sums the usage of analysis results finished at or after since, grouped by issue, channel or day.
+// 날짜는 로컬 시간 기준이며, since가 zero이면 전체 기간을 합산한다.
+// 날짜별은 최근 날짜부터, 이슈/채널별은 비용이 큰 순서로 정렬한다.
+func (r *SQLiteRepository) SumAnalysisUsage(groupBy domain.UsageGroupBy, since time.Time) ([]*domain.UsageTotal, error) {
+	query := `SELECT i.issue_key, i.channel_index, a.started_at, a.completed_at,
+			a.input_tokens, a.output_tokens, a.cache_creation_tokens, a.cache_read_tokens, a.cost_usd, a.duration_ms, a.num_turns
+		FROM analysis_results a JOIN issues i ON i.id = a.issue_id`
+	var args []interface{}
+	if !since.IsZero() {
+		// 시각은 로컬 시간 문자열로 저장되므로 초 단위 접두어로 먼저 거르고, 정확한 비교는 아래에서 한다
+		query += ` WHERE substr(COALESCE(a.completed_at, a.started_at), 1, 19) >= ?`
+		args = append(args, since.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analysis usage: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[string]*domain.UsageTotal)
+	for rows.Next() {
+		var issueKey string
+		var channelIndex int
+		var startedAt, completedAt sql.NullTime
+		var usage domain.AnalysisUsage
+		if err := rows.Scan(
+			&issueKey,
+			&channelIndex,
+			&startedAt,
+			&completedAt,
+			&usage.InputTokens,
+			&usage.OutputTokens,
+			&usage.CacheCreationTokens,
+			&usage.CacheReadTokens,
+			&usage.CostUSD,
+			&usage.DurationMS,
+			&usage.NumTurns,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan analysis usage: %w", err)
+		}
+
+		// 완료 시각이 없으면(취소 등) 시작 시각 기준
+		finishedAt := completedAt
+		if !finishedAt.Valid {
+			finishedAt = startedAt
+		}
+		if !since.IsZero() && (!finishedAt.Valid || finishedAt.Time.Before(since)) {
+			continue
+		}
+
+		var key string
+		switch groupBy {
+		case domain.UsageByIssue:
+			key = issueKey
+		case domain.UsageByChannel:
+			key = strconv.Itoa(channelIndex)
+		case domain.UsageByDay:
+			if !finishedAt.Valid {
+				continue
+			}
+			key = finishedAt.Time.Local().Format("2006-01-02")
+		default:
+			return nil, fmt.Errorf("unknown usage grouping: %q", groupBy)
+		}
+
+		total, ok := totals[key]
+		if !ok {
+			total = &domain.UsageTotal{Key: key}
+			totals[key] = total
+		}
+		total.Runs++
+		total.Usage.Add(usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read analysis usage: %w", err)
+	}
+
+	result := make([]*domain.UsageTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if groupBy == domain.UsageByDay {
+			return result[i].Key > result[j].Key
+		}
+		if result[i].Usage.CostUSD != result[j].Usage.CostUSD {
+			return result[i].Usage.CostUSD > result[j].Usage.CostUSD
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
 // CreateAttachment creates a new attachment record
 func (r *SQLiteRepository) CreateAttachment(attachment *domain.AttachmentRecord) error {
-	query := `INSERT INTO attachments (issue_id, filename, local_path, mime_type, is_video)
-		VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT INTO attachments (issue_id, filename, local_path, mime_type, is_video, jira_attachment_id, size, content_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	res, err := r.db.Exec(query,
 		attachment.IssueID,
@@ -675,6 +882,9 @@ func (r *SQLiteRepository) CreateAttachment(attachment *domain.AttachmentRecord)
 		attachment.LocalPath,
 		attachment.MimeType,
 		attachment.IsVideo,
+		attachment.JiraID,
+		attachment.Size,
+		attachment.ContentHash,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create attachment: %w", err)
@@ -691,7 +901,8 @@ func (r *SQLiteRepository) CreateAttachment(attachment *domain.AttachmentRecord)
 
 // ListAttachmentsByIssue lists all attachments for an issue
 func (r *SQLiteRepository) ListAttachmentsByIssue(issueID int64) ([]*domain.AttachmentRecord, error) {
-	query := `SELECT id, issue_id, filename, local_path, mime_type, is_video
+	query := `SELECT id, issue_id, filename, local_path, mime_type, is_video,
+		COALESCE(jira_attachment_id, ''), COALESCE(size, 0), COALESCE(content_hash, '')
 		FROM attachments WHERE issue_id = ?`
 
 	rows, err := r.db.Query(query, issueID)
@@ -710,6 +921,9 @@ func (r *SQLiteRepository) ListAttachmentsByIssue(issueID int64) ([]*domain.Atta
 			&attachment.LocalPath,
 			&attachment.MimeType,
 			&attachment.IsVideo,
+			&attachment.JiraID,
+			&attachment.Size,
+			&attachment.ContentHash,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan attachment: %w", err)
@@ -220,6 +220,8 @@ func TestCreateAndGetAnalysisResult(t *testing.T) {
 		Status:        "completed",
 		StartedAt:     &now,
 		CompletedAt:   &now,
+		Usage:         domain.AnalysisUsage{InputTokens: 120, OutputTokens: 45, CacheCreationTokens: 8, CacheReadTokens: 900, CostUSD: 0.0312, DurationMS: 4200, NumTurns: 4},
+		SessionID:     "9f1c2d3e-session",
 	}
 
 	// Act
@@ -240,6 +242,12 @@ func TestCreateAndGetAnalysisResult(t *testing.T) {
 	if retrieved.Status != result.Status {
 		t.Errorf("Expected Status %s, got %s", result.Status, retrieved.Status)
 	}
+	if retrieved.Usage != result.Usage {
+		t.Errorf("Expected Usage %+v, got %+v", result.Usage, retrieved.Usage)
+	}
+	if retrieved.SessionID != result.SessionID {
+		t.Errorf("Expected SessionID %s, got %s", result.SessionID, retrieved.SessionID)
+	}
 }
 
 func TestCreateAndListAttachments(t *testing.T) {
@@ -265,11 +273,14 @@ func TestCreateAndListAttachments(t *testing.T) {
 
 	attachments := []*domain.AttachmentRecord{
 		{
-			IssueID:   issue.ID,
-			Filename:  "video.mp4",
-			LocalPath: "/path/to/video.mp4",
-			MimeType:  "video/mp4",
-			IsVideo:   true,
+			IssueID:     issue.ID,
+			Filename:    "video.mp4",
+			LocalPath:   "/path/to/video.mp4",
+			MimeType:    "video/mp4",
+			IsVideo:     true,
+			JiraID:      "10001",
+			Size:        2048,
+			ContentHash: "abc123",
 		},
 		{
 			IssueID:   issue.ID,
@@ -296,6 +307,9 @@ func TestCreateAndListAttachments(t *testing.T) {
 	if len(retrieved) != 2 {
 		t.Errorf("Expected 2 attachments, got %d", len(retrieved))
 	}
+	if got := retrieved[0]; got.JiraID != "10001" || got.Size != 2048 || got.ContentHash != "abc123" {
+		t.Errorf("Expected cache fields to round-trip, got %+v", got)
+	}
 }
 
 func TestDeleteIssue(t *testing.T) {
@@ -510,3 +524,149 @@ func TestDeleteIssueByIDAndChannel_DeletesOnlyTargetIssueAndRelatedData(t *testi
 		t.Fatalf("expected 0 attachments after delete, got %d", len(attachments))
 	}
 }
+
+func TestUpdateAnalysisResult_PersistsJiraCommentID(t *testing.T) {
+	// Arrange
+	dbPath := "test.db"
+	defer os.Remove(dbPath)
+
+	repo, err := NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	issue := &domain.IssueRecord{IssueKey: "TEST-300", Phase: 2, Status: "active", ChannelIndex: 0}
+	if err := repo.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	result := &domain.AnalysisResult{IssueID: issue.ID, AnalysisPhase: 1, PlanPath: "/path/to/plan.md", Status: "completed"}
+	if err := repo.CreateAnalysisResult(result); err != nil {
+		t.Fatalf("CreateAnalysisResult failed: %v", err)
+	}
+
+	// Act
+	result.JiraCommentID = "10042"
+	if err := repo.UpdateAnalysisResult(result); err != nil {
+		t.Fatalf("UpdateAnalysisResult failed: %v", err)
+	}
+
+	// Assert
+	results, err := repo.ListAnalysisResultsByIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("ListAnalysisResultsByIssue failed: %v", err)
+	}
+	if len(results) != 1 || results[0].JiraCommentID != "10042" {
+		t.Errorf("Expected JiraCommentID 10042, got %+v", results)
+	}
+}
+
+func TestNewSQLiteRepository_AddsJiraCommentIDColumnToLegacySchema(t *testing.T) {
+	// Arrange: jira_comment_id 컬럼이 없는 기존 DB
+	dbPath := "test_legacy.db"
+	defer os.Remove(dbPath)
+
+	legacy, err := NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if _, err := legacy.db.Exec(`ALTER TABLE analysis_results DROP COLUMN jira_comment_id`); err != nil {
+		t.Fatalf("Failed to prepare legacy schema: %v", err)
+	}
+	legacy.Close()
+
+	// Act
+	repo, err := NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+	defer repo.Close()
+
+	// Assert
+	issue := &domain.IssueRecord{IssueKey: "TEST-301", Phase: 2, Status: "active", ChannelIndex: 0}
+	if err := repo.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	result := &domain.AnalysisResult{IssueID: issue.ID, AnalysisPhase: 1, Status: "completed", JiraCommentID: "1"}
+	if err := repo.CreateAnalysisResult(result); err != nil {
+		t.Fatalf("CreateAnalysisResult after migration failed: %v", err)
+	}
+}
+
+func TestSumAnalysisUsage(t *testing.T) {
+	// Arrange
+	dbPath := "test_usage.db"
+	defer os.Remove(dbPath)
+
+	repo, err := NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	issueA := &domain.IssueRecord{IssueKey: "TEST-401", Phase: 3, Status: "active", ChannelIndex: 0}
+	issueB := &domain.IssueRecord{IssueKey: "TEST-402", Phase: 2, Status: "active", ChannelIndex: 1}
+	for _, issue := range []*domain.IssueRecord{issueA, issueB} {
+		if err := repo.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	for _, r := range []struct {
+		issueID  int64
+		finished time.Time
+		usage    domain.AnalysisUsage
+	}{
+		{issueA.ID, yesterday, domain.AnalysisUsage{InputTokens: 100, OutputTokens: 10, CostUSD: 0.5, NumTurns: 2}},
+		{issueA.ID, today, domain.AnalysisUsage{InputTokens: 200, OutputTokens: 20, CostUSD: 1.0, NumTurns: 3}},
+		{issueB.ID, today, domain.AnalysisUsage{InputTokens: 50, OutputTokens: 5, CacheReadTokens: 1000, CostUSD: 0.25, NumTurns: 1}},
+	} {
+		finished := r.finished
+		if err := repo.CreateAnalysisResult(&domain.AnalysisResult{IssueID: r.issueID, AnalysisPhase: 1, Status: "completed", CompletedAt: &finished, Usage: r.usage}); err != nil {
+			t.Fatalf("CreateAnalysisResult failed: %v", err)
+		}
+	}
+
+	// Act & Assert: 이슈별 (비용 순)
+	byIssue, err := repo.SumAnalysisUsage(domain.UsageByIssue, time.Time{})
+	if err != nil {
+		t.Fatalf("SumAnalysisUsage failed: %v", err)
+	}
+	if len(byIssue) != 2 || byIssue[0].Key != "TEST-401" || byIssue[0].Runs != 2 || byIssue[0].Usage.CostUSD != 1.5 || byIssue[0].Usage.InputTokens != 300 {
+		t.Errorf("unexpected per-issue totals: %+v", byIssue)
+	}
+
+	// 채널별
+	byChannel, err := repo.SumAnalysisUsage(domain.UsageByChannel, time.Time{})
+	if err != nil {
+		t.Fatalf("SumAnalysisUsage failed: %v", err)
+	}
+	if len(byChannel) != 2 || byChannel[1].Key != "1" || byChannel[1].Usage.CacheReadTokens != 1000 {
+		t.Errorf("unexpected per-channel totals: %+v", byChannel)
+	}
+
+	// 날짜별 (최근 날짜 먼저)
+	byDay, err := repo.SumAnalysisUsage(domain.UsageByDay, time.Time{})
+	if err != nil {
+		t.Fatalf("SumAnalysisUsage failed: %v", err)
+	}
+	if len(byDay) != 2 || byDay[0].Key != today.Format("2006-01-02") || byDay[0].Usage.CostUSD != 1.25 || byDay[0].Runs != 2 {
+		t.Errorf("unexpected per-day totals: %+v", byDay)
+	}
+
+	// since 이후만 합산
+	startOfToday := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	sinceToday, err := repo.SumAnalysisUsage(domain.UsageByIssue, startOfToday)
+	if err != nil {
+		t.Fatalf("SumAnalysisUsage failed: %v", err)
+	}
+	if len(sinceToday) != 2 || sinceToday[0].Usage.CostUSD != 1.0 || sinceToday[0].Runs != 1 {
+		t.Errorf("unexpected totals since today: %+v", sinceToday)
+	}
+
+	if _, err := repo.SumAnalysisUsage("unknown", time.Time{}); err == nil {
+		t.Error("expected error for unknown grouping")
+	}
+}
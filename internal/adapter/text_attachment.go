@@ -0,0 +1,253 @@
+package adapter
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// DefaultInlineBytes is the default amount of each text attachment embedded in the document
+const DefaultInlineBytes = 64 << 10
+
+const (
+	maxArchiveInlineFiles = 10        // 압축 파일당 본문에 넣으려고 열어 볼 텍스트 파일 수 (실패한 시도 포함)
+	maxArchiveEntries     = 500       // 압축 파일 목록에 표시할 항목 수
+	maxScanBytes          = 256 << 20 // 잘라내기 위해 끝까지 읽을 최대 크기 (압축 폭탄 방지)
+	maxArchiveScanBytes   = 256 << 20 // 압축 파일 하나에서 풀어 읽을 전체 크기
+	binarySniffBytes      = 8 << 10   // 바이너리 여부를 판단할 앞부분 크기
+)
+
+// TextAttachmentReader implements port.AttachmentReader.
+// 텍스트 파일은 앞부분과 뒷부분을 남기고 가운데를 잘라내어 스택 트레이스의 시작과 마지막 오류가 모두 보이게 한다.
+type TextAttachmentReader struct {
+	mu               sync.RWMutex // 설정 화면에서 읽는 도중에 바뀔 수 있음
+	maxBytes         int64
+	archiveScanBytes int64 // 테스트에서 줄일 수 있도록 필드로 둔다
+}
+
+// NewTextAttachmentReader creates a reader that keeps at most maxBytes of each file (DefaultInlineBytes if <= 0)
+func NewTextAttachmentReader(maxBytes int64) *TextAttachmentReader {
+	r := &TextAttachmentReader{archiveScanBytes: maxArchiveScanBytes}
+	r.SetMaxBytes(maxBytes)
+	return r
+}
+
+// SetMaxBytes sets how many bytes of each file are kept
+func (r *TextAttachmentReader) SetMaxBytes(maxBytes int64) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultInlineBytes
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxBytes = maxBytes
+}
+
+// Read loads a downloaded text attachment or zip archive for inlining
+func (r *TextAttachmentReader) Read(localPath, filename string, kind domain.AttachmentKind) (*domain.InlineAttachment, error) {
+	// 압축 파일 하나의 모든 항목에 같은 크기 제한을 적용한다
+	r.mu.RLock()
+	maxBytes := r.maxBytes
+	r.mu.RUnlock()
+
+	switch kind {
+	case domain.AttachmentText:
+		f, err := os.Open(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open attachment: %w", err)
+		}
+		defer f.Close()
+		return readText(f, filename, maxBytes)
+	case domain.AttachmentArchive:
+		return r.readArchive(localPath, filename, maxBytes)
+	}
+	return nil, fmt.Errorf("unsupported attachment kind %q: %s", kind, filename)
+}
+
+// readText keeps at most maxBytes of src
+func readText(src io.Reader, filename string, maxBytes int64) (*domain.InlineAttachment, error) {
+	content, size, truncated, err := readHeadTail(io.LimitReader(src, maxScanBytes), maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	return &domain.InlineAttachment{
+		Filename:  filename,
+		Kind:      domain.AttachmentText,
+		Language:  codeLanguage(filename),
+		Content:   content,
+		Size:      size,
+		Truncated: truncated,
+	}, nil
+}
+
+// readArchive lists a zip archive and inlines its text files without extracting anything to disk.
+// 바이너리로 판명되거나 손상된 파일도 시도 횟수에 포함하고, 풀어 읽는 전체 크기를 제한하여
+// 작은 파일 여러 개로 만든 압축 폭탄도 막는다.
+func (r *TextAttachmentReader) readArchive(localPath, filename string, maxBytes int64) (*domain.InlineAttachment, error) {
+	zr, err := zip.OpenReader(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %w", filename, err)
+	}
+	defer zr.Close()
+
+	info, _ := os.Stat(localPath)
+	archive := &domain.InlineAttachment{Filename: filename, Kind: domain.AttachmentArchive}
+	if info != nil {
+		archive.Size = info.Size()
+	}
+
+	attempts, budget := 0, r.archiveScanBytes
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		// 압축 파일 안의 경로는 표시용으로만 쓰며 디스크에 풀지 않는다
+		name := path.Clean(strings.ReplaceAll(file.Name, `\`, "/"))
+		if len(archive.Entries) < maxArchiveEntries {
+			archive.Entries = append(archive.Entries, domain.ArchiveEntry{Name: name, Size: int64(file.UncompressedSize64)})
+		} else {
+			archive.Truncated = true
+		}
+
+		if attempts >= maxArchiveInlineFiles || budget <= 0 || domain.ClassifyAttachment("", name) != domain.AttachmentText {
+			continue
+		}
+		attempts++
+		rc, err := file.Open()
+		if err != nil {
+			continue
+		}
+		counted := &countingReader{Reader: io.LimitReader(rc, budget)}
+		inline, err := readText(counted, name, maxBytes)
+		rc.Close()
+		budget -= counted.n
+		if err == nil {
+			if budget <= 0 {
+				// 남은 크기를 다 써서 파일 끝까지 읽지 못했을 수 있다
+				inline.Truncated = true
+			}
+			archive.Files = append(archive.Files, *inline)
+		}
+	}
+	return archive, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// errNotText is returned for attachments that look like binary data
+var errNotText = errors.New("attachment does not look like text")
+
+// readHeadTail reads src keeping the first and last limit/2 bytes.
+// 잘라낸 경우 양쪽 경계를 줄 단위로 맞추고 가운데에 생략 표시를 넣는다.
+func readHeadTail(src io.Reader, limit int64) (content string, size int64, truncated bool, err error) {
+	half := limit / 2
+	head := make([]byte, 0, half)
+	var tail []byte
+	buf := make([]byte, 32<<10)
+	for {
+		n, readErr := src.Read(buf)
+		chunk := buf[:n]
+		size += int64(n)
+		if room := int(half) - len(head); room > 0 {
+			if room > len(chunk) {
+				room = len(chunk)
+			}
+			head = append(head, chunk[:room]...)
+			chunk = chunk[room:]
+		}
+		if len(chunk) > 0 {
+			tail = append(tail, chunk...)
+			if int64(len(tail)) > 2*half {
+				// 메모리를 제한하기 위해 꼬리 버퍼를 주기적으로 줄인다
+				tail = append(tail[:0], tail[int64(len(tail))-half:]...)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return "", size, false, readErr
+		}
+	}
+
+	if isBinary(head) {
+		return "", size, false, errNotText
+	}
+	if size <= limit {
+		return validUTF8(append(head, tail...)), size, false, nil
+	}
+	if int64(len(tail)) > half {
+		tail = tail[int64(len(tail))-half:]
+	}
+	if i := bytes.LastIndexByte(head, '\n'); i > 0 {
+		head = head[:i+1]
+	}
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	omitted := size - int64(len(head)) - int64(len(tail))
+	marker := fmt.Sprintf("\n... (%s 생략) ...\n\n", formatBytes(omitted))
+	return validUTF8(head) + marker + validUTF8(tail), size, true, nil
+}
+
+// validUTF8 drops invalid bytes, including multi-byte characters split at the cut points
+func validUTF8(b []byte) string {
+	return strings.ToValidUTF8(string(b), "")
+}
+
+// isBinary reports whether the start of a file looks like binary data rather than text
+func isBinary(head []byte) bool {
+	sniff := head
+	if len(sniff) > binarySniffBytes {
+		sniff = sniff[:binarySniffBytes]
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return true
+	}
+	// 잘린 멀티바이트 문자 몇 개는 허용하고, UTF-8이 아닌 바이트가 많으면 바이너리로 본다
+	invalid, total := 0, len(sniff)
+	for len(sniff) > 0 {
+		r, n := utf8.DecodeRune(sniff)
+		if r == utf8.RuneError && n == 1 {
+			invalid++
+		}
+		sniff = sniff[n:]
+	}
+	return invalid > total/10+4
+}
+
+// codeLanguage returns the fenced code block language for a filename
+func codeLanguage(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json", ".har":
+		return "json"
+	case ".xml":
+		return "xml"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".csv":
+		return "csv"
+	case ".ini", ".conf", ".cfg", ".properties":
+		return "ini"
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package adapter
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// TestTextAttachmentReader_TruncatesKeepingHeadAndTail는 큰 로그의 앞부분과 마지막 줄이 모두 남는지 검증한다.
+func TestTextAttachmentReader_TruncatesKeepingHeadAndTail(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("FIRST LINE\n")
+	for i := 0; i < 2000; i++ {
+		sb.WriteString(fmt.Sprintf("INFO line %04d\n", i))
+	}
+	sb.WriteString("panic: nil pointer dereference\n")
+	path := filepath.Join(t.TempDir(), "crash.log")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inline, err := NewTextAttachmentReader(1024).Read(path, "crash.log", domain.AttachmentText)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !inline.Truncated || inline.Size != int64(sb.Len()) {
+		t.Errorf("expected truncated content with original size %d, got truncated=%v size=%d", sb.Len(), inline.Truncated, inline.Size)
+	}
+	if !strings.HasPrefix(inline.Content, "FIRST LINE\n") || !strings.HasSuffix(inline.Content, "panic: nil pointer dereference\n") {
+		t.Errorf("expected head and tail to be kept, got:\n%s", inline.Content)
+	}
+	if !strings.Contains(inline.Content, "생략") || len(inline.Content) > 1200 {
+		t.Errorf("expected an omission marker within the limit, got %d bytes", len(inline.Content))
+	}
+	for _, line := range strings.Split(inline.Content, "\n") {
+		if strings.HasPrefix(line, "INFO") && len(line) != len("INFO line 0000") {
+			t.Errorf("expected cuts on line boundaries, got partial line %q", line)
+		}
+	}
+}
+
+func TestTextAttachmentReader_RejectsBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.log")
+	if err := os.WriteFile(path, []byte{0x7f, 'E', 'L', 'F', 0, 0, 1, 2}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTextAttachmentReader(0).Read(path, "dump.log", domain.AttachmentText); err == nil {
+		t.Error("expected binary content to be rejected")
+	}
+}
+
+// TestTextAttachmentReader_Archive는 zip 목록을 만들고 내부 텍스트 파일만 본문에 넣는지 검증한다.
+func TestTextAttachmentReader_Archive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"logs/app.log":      "ERROR boom\n",
+		"../escape.json":    `{"ok":false}`,
+		"screenshots/a.png": "\x89PNG\x00\x00",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	inline, err := NewTextAttachmentReader(0).Read(path, "report.zip", domain.AttachmentArchive)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if inline.Kind != domain.AttachmentArchive || len(inline.Entries) != 3 {
+		t.Fatalf("expected 3 archive entries, got %+v", inline)
+	}
+	files := map[string]domain.InlineAttachment{}
+	for _, file := range inline.Files {
+		files[file.Filename] = file
+	}
+	if len(files) != 2 || files["logs/app.log"].Content != "ERROR boom\n" || files["../escape.json"].Language != "json" {
+		t.Errorf("expected the two text files inlined, got %+v", inline.Files)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "..", "escape.json")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing extracted to disk, got %v", err)
+	}
+}
+
+// writeZip creates a zip archive with the given entries in order
+func writeZip(t *testing.T, path string, names, contents []string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for i, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(contents[i]))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTextAttachmentReader_ArchiveLimits는 실패한 파일도 시도 횟수에 세고,
+// 압축 파일 하나에서 풀어 읽는 전체 크기를 제한하는지 검증한다.
+func TestTextAttachmentReader_ArchiveLimits(t *testing.T) {
+	dir := t.TempDir()
+
+	var names, contents []string
+	for i := 0; i < maxArchiveInlineFiles; i++ {
+		names = append(names, fmt.Sprintf("bin%02d.log", i))
+		contents = append(contents, "\x00\x01binary")
+	}
+	names = append(names, "late.log")
+	contents = append(contents, "ERROR late\n")
+	attemptsPath := filepath.Join(dir, "attempts.zip")
+	writeZip(t, attemptsPath, names, contents)
+
+	inline, err := NewTextAttachmentReader(0).Read(attemptsPath, "attempts.zip", domain.AttachmentArchive)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(inline.Files) != 0 || len(inline.Entries) != maxArchiveInlineFiles+1 {
+		t.Errorf("expected failed reads to use up the attempts, got files=%+v", inline.Files)
+	}
+
+	budgetPath := filepath.Join(dir, "budget.zip")
+	writeZip(t, budgetPath, []string{"a.log", "b.log", "c.log"},
+		[]string{strings.Repeat("a", 60) + "\n", strings.Repeat("b", 60) + "\n", "c\n"})
+	reader := NewTextAttachmentReader(0)
+	reader.archiveScanBytes = 100
+
+	inline, err = reader.Read(budgetPath, "budget.zip", domain.AttachmentArchive)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(inline.Files) != 2 || inline.Files[0].Truncated || !inline.Files[1].Truncated || inline.Files[1].Size != 39 {
+		t.Errorf("expected reads to stop after 100 decompressed bytes, got %+v", inline.Files)
+	}
+}
@@ -0,0 +1,242 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+// CommandTranscriber implements port.Transcriber by extracting the audio track with ffmpeg
+// and running a local speech recognition CLI such as whisper.cpp.
+//
+// 명령 템플릿의 {audio}는 16kHz 모노 WAV 파일 경로로, {output}은 확장자 없는 출력 경로로 바뀐다.
+// 명령이 {output}.srt 또는 {output}.vtt를 만들면 그 파일을, 아니면 표준 출력을 자막 형식으로 읽는다.
+//
+//	whisper-cli -m ~/models/ggml-base.bin -l auto -osrt -of {output} -f {audio}
+type CommandTranscriber struct {
+	mu         sync.RWMutex
+	ffmpegPath string
+	command    string
+}
+
+// NewCommandTranscriber creates a transcriber for the given command template (empty disables transcription)
+func NewCommandTranscriber(command string) *CommandTranscriber {
+	return &CommandTranscriber{
+		ffmpegPath: findExecutable("ffmpeg", ""),
+		command:    strings.TrimSpace(command),
+	}
+}
+
+// SetCommand updates the command template
+func (t *CommandTranscriber) SetCommand(command string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.command = strings.TrimSpace(command)
+}
+
+// IsAvailable checks if a command is configured and ffmpeg is installed
+func (t *CommandTranscriber) IsAvailable() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.command != "" && t.ffmpegPath != ""
+}
+
+// Transcribe extracts the audio of videoPath and returns the recognized segments.
+// 중간 파일은 임시 디렉토리에 만들고 끝나면 지운다.
+func (t *CommandTranscriber) Transcribe(ctx context.Context, videoPath string) ([]domain.TranscriptSegment, error) {
+	t.mu.RLock()
+	command := t.command
+	t.mu.RUnlock()
+	if command == "" || t.ffmpegPath == "" {
+		return nil, fmt.Errorf("transcriber not configured")
+	}
+
+	workDir, err := os.MkdirTemp("", "jira-ai-transcribe-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	audioPath := filepath.Join(workDir, "audio.wav")
+	output, err := exec.CommandContext(ctx, t.ffmpegPath,
+		"-hide_banner", "-nostdin", "-i", videoPath,
+		"-vn", "-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le",
+		"-y", audioPath).CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract audio: %v\nOutput: %s", err, lastLines(string(output), 5))
+	}
+
+	outputBase := filepath.Join(workDir, "transcript")
+	args := expandCommand(command, map[string]string{"{audio}": audioPath, "{output}": outputBase})
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transcription command failed: %v\nOutput: %s", err, lastLines(stderr.String(), 5))
+	}
+
+	text := stdout.String()
+	for _, ext := range []string{".srt", ".vtt"} {
+		if data, err := os.ReadFile(outputBase + ext); err == nil {
+			text = string(data)
+			break
+		}
+	}
+	return parseTranscript(text), nil
+}
+
+// expandCommand splits a command template like a shell and substitutes placeholders in each argument.
+// 셸을 거치지 않으므로 치환된 경로에 공백이 있어도 인자가 나뉘지 않는다.
+func expandCommand(command string, values map[string]string) []string {
+	args := splitCommandLine(command)
+	for i, arg := range args {
+		for placeholder, value := range values {
+			arg = strings.ReplaceAll(arg, placeholder, value)
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// splitCommandLine splits command into arguments on unquoted whitespace.
+// 작은따옴표 안은 그대로, 큰따옴표 안은 \" 와 \\ 만 풀어 쓰고, 따옴표 밖의 \는 다음 글자를 그대로 쓴다.
+// 따옴표 없이 ~ 또는 ~/로 시작하는 인자는 홈 디렉토리로 바꾼다. 변수 치환이나 파이프 등 다른 셸 문법은 지원하지 않는다.
+func splitCommandLine(command string) []string {
+	var args []string
+	var current strings.Builder
+	inArg, tilde := false, false
+	var quote rune
+	escaped := false
+	flush := func() {
+		if !inArg {
+			return
+		}
+		arg := current.String()
+		if tilde && (arg == "~" || strings.HasPrefix(arg, "~/")) {
+			if home, err := os.UserHomeDir(); err == nil {
+				arg = home + arg[1:]
+			}
+		}
+		args = append(args, arg)
+		current.Reset()
+		inArg, tilde = false, false
+	}
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		default:
+			if !inArg {
+				tilde = r == '~'
+			}
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	flush()
+	return args
+}
+
+// cueTimingPattern matches SRT/VTT timing lines ("00:00:01,000 --> 00:00:04,000")
+// and whisper.cpp console lines ("[00:00:01.000 --> 00:00:04.000]  text")
+var cueTimingPattern = regexp.MustCompile(`^\[?\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})[^\]]*\]?\s*(.*)$`)
+
+// parseTranscript reads SRT, WebVTT or whisper.cpp console output into segments, skipping silence markers
+func parseTranscript(text string) []domain.TranscriptSegment {
+	var segments []domain.TranscriptSegment
+	var cue *domain.TranscriptSegment
+	flush := func() {
+		if cue != nil {
+			cue.Text = strings.TrimSpace(cue.Text)
+			if cue.Text != "" && !isSilenceMarker(cue.Text) {
+				segments = append(segments, *cue)
+			}
+			cue = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if m := cueTimingPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			cue = &domain.TranscriptSegment{Start: parseCueTime(m[1]), End: parseCueTime(m[2]), Text: m[3]}
+			if m[3] != "" {
+				// whisper.cpp 콘솔 출력은 시간과 내용이 한 줄에 있다
+				flush()
+			}
+			continue
+		}
+		if line == "" {
+			flush()
+			continue
+		}
+		if cue != nil {
+			cue.Text += " " + line
+		}
+	}
+	flush()
+	return segments
+}
+
+// isSilenceMarker reports whether text is a non-speech annotation such as "[BLANK_AUDIO]" or "(music)"
+func isSilenceMarker(text string) bool {
+	return (strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]")) ||
+		(strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")"))
+}
+
+// parseCueTime parses "HH:MM:SS.mmm", "MM:SS.mmm" or the SRT form "HH:MM:SS,mmm"
+func parseCueTime(value string) time.Duration {
+	parts := strings.Split(strings.ReplaceAll(value, ",", "."), ":")
+	var total time.Duration
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			sec, _ := strconv.ParseFloat(part, 64)
+			total = total*60 + time.Duration(sec*float64(time.Second))
+			break
+		}
+		n, _ := strconv.Atoi(part)
+		total = total*60 + time.Duration(n)*time.Second
+	}
+	return total
+}
@@ -0,0 +1,126 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+func TestParseTranscript(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []domain.TranscriptSegment
+	}{
+		{
+			name:  "srt",
+			input: "1\r\n00:00:01,000 --> 00:00:04,500\r\n로그인 버튼을 누르면\r\n화면이 멈춥니다\r\n\r\n2\r\n00:00:05,000 --> 00:00:06,000\r\n[BLANK_AUDIO]\r\n",
+			want: []domain.TranscriptSegment{
+				{Start: time.Second, End: 4500 * time.Millisecond, Text: "로그인 버튼을 누르면 화면이 멈춥니다"},
+			},
+		},
+		{
+			name:  "webvtt with cue settings",
+			input: "WEBVTT\n\n00:01.000 --> 00:02.000 align:start\nhello\n\n01:02:03.500 --> 01:02:04.000\nworld\n",
+			want: []domain.TranscriptSegment{
+				{Start: time.Second, End: 2 * time.Second, Text: "hello"},
+				{Start: time.Hour + 2*time.Minute + 3500*time.Millisecond, End: time.Hour + 2*time.Minute + 4*time.Second, Text: "world"},
+			},
+		},
+		{
+			name:  "whisper.cpp console",
+			input: "[00:00:00.000 --> 00:00:02.000]   첫 번째 문장\n[00:00:02.000 --> 00:00:03.000]  (music)\n[00:00:03.000 --> 00:00:05.000]   두 번째 문장\n",
+			want: []domain.TranscriptSegment{
+				{Start: 0, End: 2 * time.Second, Text: "첫 번째 문장"},
+				{Start: 3 * time.Second, End: 5 * time.Second, Text: "두 번째 문장"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTranscript(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("segment %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpandCommand(t *testing.T) {
+	args := expandCommand("whisper-cli -of {output} -f {audio}", map[string]string{
+		"{audio}":  "/tmp/my dir/audio.wav",
+		"{output}": "/tmp/my dir/transcript",
+	})
+	want := []string{"whisper-cli", "-of", "/tmp/my dir/transcript", "-f", "/tmp/my dir/audio.wav"}
+	if len(args) != len(want) {
+		t.Fatalf("got %q, want %q", args, want)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("got %q, want %q", args, want)
+		}
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{`whisper-cli  -f {audio}`, []string{"whisper-cli", "-f", "{audio}"}},
+		{`"/Applications/My Tools/whisper" -m '/models/ggml base.bin'`, []string{"/Applications/My Tools/whisper", "-m", "/models/ggml base.bin"}},
+		{`agent --msg "say \"hi\"" a\ b ""`, []string{"agent", "--msg", `say "hi"`, "a b", ""}},
+		{`~/bin/whisper -m ~/models/m.bin "~/quoted" a~b`, []string{home + "/bin/whisper", "-m", home + "/models/m.bin", "~/quoted", "a~b"}},
+	}
+	for _, tt := range tests {
+		got := splitCommandLine(tt.command)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+// TestCommandTranscriber_Transcribe는 가짜 ffmpeg와 음성 인식 명령으로 오디오 추출 → 자막 파일 읽기 흐름을 검증한다.
+func TestCommandTranscriber_Transcribe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stub requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	ffmpeg := filepath.Join(dir, "ffmpeg")
+	os.WriteFile(ffmpeg, []byte("#!/bin/sh\nfor last; do :; done\necho RIFF > \"$last\"\n"), 0755)
+	// 오디오 파일을 받았는지 확인한 뒤 {output}.srt를 만든다
+	recognizer := filepath.Join(dir, "recognizer")
+	os.WriteFile(recognizer, []byte("#!/bin/sh\ntest -s \"$2\" || exit 1\nprintf '1\\n00:00:02,000 --> 00:00:03,000\\n저장이 안 돼요\\n' > \"$1.srt\"\n"), 0755)
+
+	transcriber := &CommandTranscriber{ffmpegPath: ffmpeg, command: recognizer + " {output} {audio}"}
+	if !transcriber.IsAvailable() {
+		t.Fatal("expected transcriber to be available")
+	}
+	segments, err := transcriber.Transcribe(context.Background(), filepath.Join(dir, "clip.mp4"))
+	if err != nil {
+		t.Fatalf("Transcribe failed: %v", err)
+	}
+	if len(segments) != 1 || segments[0].Text != "저장이 안 돼요" || segments[0].Start != 2*time.Second {
+		t.Errorf("unexpected segments %+v", segments)
+	}
+
+	transcriber.SetCommand("")
+	if transcriber.IsAvailable() {
+		t.Error("expected empty command to disable transcription")
+	}
+}
@@ -1,35 +1,55 @@
 package adapter
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
 )
 
 // FFmpegVideoProcessor implements port.VideoProcessor
 type FFmpegVideoProcessor struct {
-	ffmpegPath string
+	ffmpegPath  string
+	ffprobePath string
 }
 
 // NewFFmpegVideoProcessor creates a new video processor
 func NewFFmpegVideoProcessor() *FFmpegVideoProcessor {
-	ffmpegPath, _ := exec.LookPath("ffmpeg")
-	if ffmpegPath == "" {
-		commonPaths := []string{
-			"/usr/local/bin/ffmpeg",
-			"/opt/homebrew/bin/ffmpeg",
-		}
-		for _, p := range commonPaths {
-			if _, err := os.Stat(p); err == nil {
-				ffmpegPath = p
-				break
-			}
+	ffmpegPath := findExecutable("ffmpeg", "")
+	return &FFmpegVideoProcessor{
+		ffmpegPath:  ffmpegPath,
+		ffprobePath: findExecutable("ffprobe", filepath.Dir(ffmpegPath)),
+	}
+}
+
+// findExecutable looks up name in PATH, then next to a sibling tool (ffprobe is installed with ffmpeg),
+// then in the common Homebrew locations
+func findExecutable(name, siblingDir string) string {
+	if path, err := exec.LookPath(name); err == nil {
+		return path
+	}
+	var candidates []string
+	if siblingDir != "" && siblingDir != "." {
+		candidates = append(candidates, filepath.Join(siblingDir, name))
+	}
+	candidates = append(candidates, "/usr/local/bin/"+name, "/opt/homebrew/bin/"+name)
+	for _, p := range candidates {
+		if _, err := os.Stat(p); err == nil {
+			return p
 		}
 	}
-	return &FFmpegVideoProcessor{ffmpegPath: ffmpegPath}
+	return ""
 }
 
 // IsAvailable checks if ffmpeg is available
@@ -37,48 +57,354 @@ func (v *FFmpegVideoProcessor) IsAvailable() bool {
 	return v.ffmpegPath != ""
 }
 
-// ExtractFrames extracts frames from a video file
-func (v *FFmpegVideoProcessor) ExtractFrames(videoPath, outputDir string, interval float64, maxFrames int) ([]string, error) {
+// ExtractFrames extracts frames from a video file and returns their paths in time order.
+// 먼저 동영상 전체를 훑어 길이(와 scene 모드면 화면 전환 시점)를 구하고, 고른 시점마다 프레임을 한 장씩 저장한다.
+// 파일명에 타임스탬프가 들어간다 (domain.FrameFilename).
+// opts.DedupeDistance가 있으면 직전에 남긴 프레임과 거의 같은 프레임은 지운다.
+// ctx가 취소되면 ffmpeg 프로세스를 종료하고 이미 추출된 프레임을 지운다.
+func (v *FFmpegVideoProcessor) ExtractFrames(ctx context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error) {
 	if !v.IsAvailable() {
 		return nil, fmt.Errorf("ffmpeg not found")
 	}
-
-	if interval <= 0 {
-		interval = 1.0
-	}
+	opts = opts.Normalized()
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create frames directory: %w", err)
 	}
 
 	videoName := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
-	framePattern := filepath.Join(outputDir, fmt.Sprintf("%s_frame_%%04d.png", videoName))
+	framesGlob := filepath.Join(outputDir, fmt.Sprintf("%s_frame_*.png", videoName))
+	sheetPath := filepath.Join(outputDir, domain.ContactSheetFilename(videoName))
+	// 이전 실행에서 다른 옵션으로 추출한 프레임이 섞이지 않게 한다
+	removeGlob(framesGlob)
+	os.Remove(sheetPath)
+
+	duration, scenes, err := v.scan(ctx, videoPath, opts)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []string
+	var lastErr error
+	for _, ts := range planFrameTimestamps(duration, scenes, opts) {
+		framePath := filepath.Join(outputDir, domain.FrameFilename(videoName, len(frames)+1, ts))
+		err := v.extractFrameAt(ctx, videoPath, framePath, ts, opts.Scale)
+		if ctx.Err() != nil {
+			removeGlob(framesGlob)
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			// 길이 정보가 부정확해 끝을 넘긴 시점 등은 건너뛴다
+			lastErr = err
+			continue
+		}
+		frames = append(frames, framePath)
+	}
+	if len(frames) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	if opts.DedupeDistance > 0 {
+		frames = dedupeFrames(frames, opts.DedupeDistance)
+	}
+
+	if opts.ContactSheet && len(frames) > 1 {
+		err := v.buildContactSheet(ctx, frames, sheetPath)
+		if ctx.Err() != nil {
+			removeGlob(framesGlob)
+			os.Remove(sheetPath)
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			// 타일 이미지를 만들지 못해도 개별 프레임은 그대로 쓴다
+			logger.Debug("FFmpegVideoProcessor: contact sheet failed for %s: %v", videoName, err)
+		} else {
+			frames = append(frames, sheetPath)
+		}
+	}
+	return frames, nil
+}
 
+// contactSheetWidth caps the width of a contact sheet so it is not downscaled when handed to the AI
+const contactSheetWidth = 1920
+
+// buildContactSheet tiles frames (left to right, top to bottom) into a single PNG with the tile filter.
+// 프레임 파일명에 타임스탬프가 있어 이미지 시퀀스 패턴을 쓸 수 없으므로 concat 목록으로 입력한다.
+func (v *FFmpegVideoProcessor) buildContactSheet(ctx context.Context, frames []string, sheetPath string) error {
+	cols, rows := contactSheetGrid(len(frames))
+	listPath := sheetPath + ".txt"
+	var list strings.Builder
+	list.WriteString("ffconcat version 1.0\n")
+	for _, frame := range frames {
+		list.WriteString("file " + concatQuote(frame) + "\n")
+	}
+	if err := os.WriteFile(listPath, []byte(list.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write frame list: %w", err)
+	}
+	defer os.Remove(listPath)
+
+	filter := fmt.Sprintf("scale='min(iw,%d)':-2,tile=%dx%d:padding=4:margin=4", contactSheetWidth/cols, cols, rows)
 	args := []string{
-		"-i", videoPath,
-		"-vf", fmt.Sprintf("fps=1/%s,scale=640:-1", formatFloat(interval)),
+		"-hide_banner", "-nostdin",
+		"-f", "concat", "-safe", "0", "-i", listPath,
+		"-vf", filter,
+		"-frames:v", "1",
+		"-y", sheetPath,
+	}
+	output, err := exec.CommandContext(ctx, v.ffmpegPath, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg error: %v\nOutput: %s", err, lastLines(string(output), 10))
 	}
+	if _, err := os.Stat(sheetPath); err != nil {
+		return fmt.Errorf("contact sheet was not created")
+	}
+	return nil
+}
 
-	if maxFrames > 0 {
-		args = append(args, "-vframes", strconv.Itoa(maxFrames))
+// contactSheetGrid returns a near-square grid that fits n tiles, wider than tall
+func contactSheetGrid(n int) (cols, rows int) {
+	cols = int(math.Ceil(math.Sqrt(float64(n))))
+	if cols < 1 {
+		cols = 1
 	}
+	rows = (n + cols - 1) / cols
+	return cols, rows
+}
 
-	args = append(args, "-y", framePattern)
+// concatQuote quotes a path for an ffconcat file list
+func concatQuote(path string) string {
+	return "'" + strings.ReplaceAll(filepath.ToSlash(path), "'", `'\''`) + "'"
+}
 
-	cmd := exec.Command(v.ffmpegPath, args...)
-	output, err := cmd.CombinedOutput()
+// Probe reads video metadata with ffprobe
+func (v *FFmpegVideoProcessor) Probe(ctx context.Context, videoPath string) (*domain.VideoInfo, error) {
+	if v.ffprobePath == "" {
+		return nil, fmt.Errorf("ffprobe not found")
+	}
+	output, err := exec.CommandContext(ctx, v.ffprobePath,
+		"-v", "error", "-print_format", "json", "-show_format", "-show_streams", videoPath).Output()
 	if err != nil {
-		return nil, fmt.Errorf("ffmpeg error: %v\nOutput: %s", err, string(output))
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("ffprobe error: %v\nOutput: %s", err, lastLines(string(exitErr.Stderr), 5))
+		}
+		return nil, fmt.Errorf("ffprobe error: %w", err)
 	}
+	return parseProbeOutput(output)
+}
+
+// probeOutput is the subset of `ffprobe -print_format json -show_format -show_streams` that is used
+type probeOutput struct {
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+		CodecName string `json:"codec_name"`
+		Width     int    `json:"width"`
+		Height    int    `json:"height"`
+		Duration  string `json:"duration"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+// parseProbeOutput converts ffprobe JSON output into VideoInfo
+func parseProbeOutput(data []byte) (*domain.VideoInfo, error) {
+	var probe probeOutput
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	info := &domain.VideoInfo{Duration: parseSeconds(probe.Format.Duration)}
+	hasVideo := false
+	for _, stream := range probe.Streams {
+		switch stream.CodecType {
+		case "video":
+			// 앨범 아트 등 추가 영상 스트림보다 첫 번째 영상 스트림을 쓴다
+			if hasVideo {
+				continue
+			}
+			hasVideo = true
+			info.Width, info.Height, info.Codec = stream.Width, stream.Height, stream.CodecName
+			if info.Duration == 0 {
+				info.Duration = parseSeconds(stream.Duration)
+			}
+		case "audio":
+			info.HasAudio = true
+		}
+	}
+	if !hasVideo {
+		return nil, fmt.Errorf("no video stream found")
+	}
+	return info, nil
+}
 
-	frames, err := filepath.Glob(filepath.Join(outputDir, fmt.Sprintf("%s_frame_*.png", videoName)))
+// parseSeconds parses an ffprobe duration such as "83.500000" (0 if missing or "N/A")
+func parseSeconds(value string) time.Duration {
+	sec, err := strconv.ParseFloat(value, 64)
+	if err != nil || sec < 0 {
+		return 0
+	}
+	return time.Duration(sec * float64(time.Second))
+}
+
+// scan decodes the video once to find its duration and, in scene mode, the scene change timestamps
+func (v *FFmpegVideoProcessor) scan(ctx context.Context, videoPath string, opts domain.FrameOptions) (time.Duration, []time.Duration, error) {
+	args := []string{"-hide_banner", "-nostdin", "-i", videoPath}
+	if opts.Mode == domain.FrameModeScene {
+		args = append(args,
+			"-vf", fmt.Sprintf("select='gt(scene,%s)',showinfo", strconv.FormatFloat(opts.SceneThreshold, 'f', -1, 64)),
+			"-an", "-f", "null", "-")
+	}
+
+	output, err := exec.CommandContext(ctx, v.ffmpegPath, args...).CombinedOutput()
+	if ctx.Err() != nil {
+		return 0, nil, ctx.Err()
+	}
+	duration := parseFFmpegDuration(string(output))
+	if opts.Mode != domain.FrameModeScene {
+		// 출력 파일 없이 실행하면 ffmpeg는 실패로 끝나므로 길이만 확인한다
+		if duration == 0 && !strings.Contains(string(output), "Stream #") {
+			return 0, nil, fmt.Errorf("ffmpeg could not read video: %s", lastLines(string(output), 5))
+		}
+		return duration, nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to find extracted frames: %w", err)
+		return 0, nil, fmt.Errorf("ffmpeg scene detection error: %v\nOutput: %s", err, lastLines(string(output), 10))
 	}
+	return duration, parseShowinfoTimestamps(string(output)), nil
+}
 
-	return frames, nil
+// extractFrameAt saves the frame at ts into framePath
+func (v *FFmpegVideoProcessor) extractFrameAt(ctx context.Context, videoPath, framePath string, ts time.Duration, scale int) error {
+	args := []string{
+		"-hide_banner", "-nostdin",
+		"-ss", strconv.FormatFloat(ts.Seconds(), 'f', 3, 64),
+		"-i", videoPath,
+		"-frames:v", "1",
+	}
+	if scale > 0 {
+		args = append(args, "-vf", fmt.Sprintf("scale=%d:-2", scale))
+	}
+	args = append(args, "-y", framePath)
+
+	output, err := exec.CommandContext(ctx, v.ffmpegPath, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg error: %v\nOutput: %s", err, lastLines(string(output), 10))
+	}
+	if _, err := os.Stat(framePath); err != nil {
+		return fmt.Errorf("no frame at %s", domain.FormatTimestamp(ts))
+	}
+	return nil
+}
+
+// planFrameTimestamps chooses when to capture frames.
+// interval 모드는 interval초 간격, scene 모드는 첫 화면과 화면 전환 시점을 후보로 한다.
+// 후보가 MaxFrames보다 많으면 고르게 골라내고, scene 모드에서 모자라면 전체 길이에 고르게 채운다.
+func planFrameTimestamps(duration time.Duration, scenes []time.Duration, opts domain.FrameOptions) []time.Duration {
+	step := time.Duration(opts.Interval * float64(time.Second))
+	candidates := []time.Duration{0}
+	if opts.Mode == domain.FrameModeScene {
+		candidates = append(candidates, scenes...)
+	} else {
+		for ts := step; ts < duration; ts += step {
+			candidates = append(candidates, ts)
+		}
+		if duration == 0 {
+			// 길이를 알 수 없으면 처음부터 간격대로 최대 개수만큼 시도한다
+			for len(candidates) < opts.MaxFrames {
+				candidates = append(candidates, candidates[len(candidates)-1]+step)
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+
+	max := opts.MaxFrames
+	if max <= 0 || len(candidates) == max {
+		return candidates
+	}
+	if len(candidates) > max {
+		return spreadEvenly(candidates, max)
+	}
+	if opts.Mode != domain.FrameModeScene || duration <= 0 {
+		return candidates
+	}
+
+	// 화면 전환이 적으면 가장 긴 빈 구간(끝까지 포함)의 가운데를 반복해서 채운다
+	for len(candidates) < max {
+		gapStart, gap := time.Duration(0), time.Duration(0)
+		for i, ts := range candidates {
+			next := duration
+			if i+1 < len(candidates) {
+				next = candidates[i+1]
+			}
+			if next-ts >= gap {
+				gapStart, gap = ts, next-ts
+			}
+		}
+		if gap < 2*time.Second {
+			break
+		}
+		candidates = append(candidates, gapStart+gap/2)
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	}
+	return candidates
+}
+
+// spreadEvenly picks n items spread across sorted, always keeping the first and last
+func spreadEvenly(sorted []time.Duration, n int) []time.Duration {
+	if n == 1 {
+		return sorted[:1]
+	}
+	picked := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		picked = append(picked, sorted[i*(len(sorted)-1)/(n-1)])
+	}
+	return picked
 }
 
-func formatFloat(f float64) string {
-	return strconv.FormatFloat(f, 'f', 2, 64)
+var (
+	ffmpegDurationPattern = regexp.MustCompile(`Duration: (\d+):(\d{2}):(\d{2}(?:\.\d+)?)`)
+	showinfoPTSPattern    = regexp.MustCompile(`\bpts_time:\s*(-?[0-9.]+)`)
+)
+
+// parseFFmpegDuration reads the input duration from ffmpeg's banner (0 if unknown, e.g. "Duration: N/A")
+func parseFFmpegDuration(output string) time.Duration {
+	m := ffmpegDurationPattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0
+	}
+	h, _ := strconv.Atoi(m[1])
+	min, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.ParseFloat(m[3], 64)
+	return time.Duration(h)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec*float64(time.Second))
+}
+
+// parseShowinfoTimestamps collects pts_time values printed by the showinfo filter
+func parseShowinfoTimestamps(output string) []time.Duration {
+	var timestamps []time.Duration
+	for _, m := range showinfoPTSPattern.FindAllStringSubmatch(output, -1) {
+		sec, err := strconv.ParseFloat(m[1], 64)
+		if err != nil || sec < 0 {
+			continue
+		}
+		timestamps = append(timestamps, time.Duration(sec*float64(time.Second)))
+	}
+	return timestamps
+}
+
+// lastLines returns the last n lines of ffmpeg output for error messages
+func lastLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// removeGlob removes every file matching pattern
+func removeGlob(pattern string) {
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		os.Remove(match)
+	}
 }
@@ -0,0 +1,223 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
+
+func seconds(values ...float64) []time.Duration {
+	var out []time.Duration
+	for _, v := range values {
+		out = append(out, time.Duration(v*float64(time.Second)))
+	}
+	return out
+}
+
+func TestPlanFrameTimestamps(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		scenes   []time.Duration
+		opts     domain.FrameOptions
+		want     []time.Duration
+	}{
+		{
+			// 3분 녹화를 1초 간격으로 뽑으면 처음 10초가 아니라 전체에 고르게 분산되어야 한다
+			name:     "interval spreads over full duration",
+			duration: 180 * time.Second,
+			opts:     domain.FrameOptions{Mode: domain.FrameModeInterval, Interval: 1, MaxFrames: 4},
+			want:     seconds(0, 59, 119, 179),
+		},
+		{
+			name:     "interval under the cap keeps every step",
+			duration: 3500 * time.Millisecond,
+			opts:     domain.FrameOptions{Mode: domain.FrameModeInterval, Interval: 1, MaxFrames: 10},
+			want:     seconds(0, 1, 2, 3),
+		},
+		{
+			name:     "scene changes fill up with evenly spread samples",
+			duration: 100 * time.Second,
+			scenes:   seconds(12),
+			opts:     domain.FrameOptions{Mode: domain.FrameModeScene, MaxFrames: 4},
+			want:     seconds(0, 12, 56, 78),
+		},
+		{
+			name:     "too many scene changes are thinned evenly",
+			duration: 60 * time.Second,
+			scenes:   seconds(5, 10, 15, 20, 25, 30, 35, 40),
+			opts:     domain.FrameOptions{Mode: domain.FrameModeScene, MaxFrames: 3},
+			want:     seconds(0, 20, 40),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planFrameTimestamps(tt.duration, tt.scenes, tt.opts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFFmpegOutput(t *testing.T) {
+	output := `Input #0, mov,mp4,m4a,3gp,3g2,mj2, from 'clip.mp4':
+  Duration: 00:03:05.52, start: 0.000000, bitrate: 1200 kb/s
+  Stream #0:0(und): Video: h264
+[Parsed_showinfo_1 @ 0x1] n:   0 pts:  38400 pts_time:2.5     duration:512
+[Parsed_showinfo_1 @ 0x1] n:   1 pts: 512000 pts_time:33.333  duration:512
+`
+	if got := parseFFmpegDuration(output); got != 185520*time.Millisecond {
+		t.Errorf("parseFFmpegDuration = %v", got)
+	}
+	if got := parseFFmpegDuration("Duration: N/A, start: 0"); got != 0 {
+		t.Errorf("expected unknown duration to be 0, got %v", got)
+	}
+	got := parseShowinfoTimestamps(output)
+	if len(got) != 2 || got[0] != 2500*time.Millisecond || got[1] != 33333*time.Millisecond {
+		t.Errorf("parseShowinfoTimestamps = %v", got)
+	}
+}
+
+// TestFFmpegVideoProcessor_ExtractFrames는 가짜 ffmpeg로 장면 감지 → 시점별 추출 흐름과 파일명을 검증한다.
+func TestFFmpegVideoProcessor_ExtractFrames(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stub requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "ffmpeg")
+	script := `#!/bin/sh
+for last; do :; done
+case "$*" in
+  *showinfo*)
+    echo "  Duration: 00:00:40.00, start: 0.000000" >&2
+    echo "[Parsed_showinfo_1 @ 0x1] n: 0 pts: 1 pts_time:12.5 duration:1" >&2
+    exit 0 ;;
+esac
+echo frame > "$last"
+`
+	if err := os.WriteFile(stub, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	outputDir := filepath.Join(dir, "frames")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(outputDir, "clip_frame_0001.png")
+	os.WriteFile(stale, []byte("old"), 0644)
+
+	processor := &FFmpegVideoProcessor{ffmpegPath: stub}
+	frames, err := processor.ExtractFrames(context.Background(), filepath.Join(dir, "clip.mp4"), outputDir,
+		domain.FrameOptions{Mode: domain.FrameModeScene, MaxFrames: 3})
+	if err != nil {
+		t.Fatalf("ExtractFrames failed: %v", err)
+	}
+
+	var names []string
+	for _, frame := range frames {
+		names = append(names, filepath.Base(frame))
+	}
+	want := "clip_frame_0001_0ms.png clip_frame_0002_12500ms.png clip_frame_0003_26250ms.png"
+	if strings.Join(names, " ") != want {
+		t.Errorf("expected %s, got %v", want, names)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected frames from an earlier run to be removed, got %v", err)
+	}
+}
+
+func TestFFmpegVideoProcessor_ExtractFrames_ContactSheet(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stub requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "ffmpeg")
+	// 장면 감지 없이 길이만 알려 주고, 마지막 인자(출력 파일)를 만든다. concat 목록은 검사용으로 복사해 둔다.
+	script := `#!/bin/sh
+for last; do :; done
+case "$*" in
+  *showinfo*) echo "  Duration: 00:00:30.00, start: 0.000000" >&2; exit 0 ;;
+  *concat*) cat "$8" > "$last.list" ;;
+esac
+echo image > "$last"
+`
+	if err := os.WriteFile(stub, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	processor := &FFmpegVideoProcessor{ffmpegPath: stub}
+	outputDir := filepath.Join(dir, "frames")
+	frames, err := processor.ExtractFrames(context.Background(), filepath.Join(dir, "clip.mp4"), outputDir,
+		domain.FrameOptions{Mode: domain.FrameModeScene, MaxFrames: 3, ContactSheet: true})
+	if err != nil {
+		t.Fatalf("ExtractFrames failed: %v", err)
+	}
+	if len(frames) != 4 {
+		t.Fatalf("expected 3 frames and a contact sheet, got %v", frames)
+	}
+	sheet := frames[len(frames)-1]
+	if filepath.Base(sheet) != "clip_contact_sheet.png" {
+		t.Errorf("expected contact sheet last, got %s", sheet)
+	}
+	list, err := os.ReadFile(sheet + ".list")
+	if err != nil {
+		t.Fatalf("expected concat list to be passed to ffmpeg: %v", err)
+	}
+	if !strings.Contains(string(list), "file '"+filepath.ToSlash(frames[0])+"'") {
+		t.Errorf("expected frames in concat list, got:\n%s", list)
+	}
+	if _, err := os.Stat(sheet + ".txt"); !os.IsNotExist(err) {
+		t.Errorf("expected temporary concat list to be removed")
+	}
+}
+
+func TestContactSheetGrid(t *testing.T) {
+	tests := []struct{ n, cols, rows int }{
+		{2, 2, 1},
+		{4, 2, 2},
+		{5, 3, 2},
+		{10, 4, 3},
+	}
+	for _, tt := range tests {
+		if cols, rows := contactSheetGrid(tt.n); cols != tt.cols || rows != tt.rows {
+			t.Errorf("contactSheetGrid(%d) = %dx%d, want %dx%d", tt.n, cols, rows, tt.cols, tt.rows)
+		}
+	}
+	if got := concatQuote("/tmp/it's.png"); got != `'/tmp/it'\''s.png'` {
+		t.Errorf("concatQuote = %s", got)
+	}
+}
+
+func TestParseProbeOutput(t *testing.T) {
+	output := `{
+  "streams": [
+    {"codec_type": "video", "codec_name": "h264", "width": 1920, "height": 1080, "duration": "83.4"},
+    {"codec_type": "audio", "codec_name": "aac"},
+    {"codec_type": "video", "codec_name": "mjpeg", "width": 300, "height": 300}
+  ],
+  "format": {"duration": "83.500000"}
+}`
+	info, err := parseProbeOutput([]byte(output))
+	if err != nil {
+		t.Fatalf("parseProbeOutput failed: %v", err)
+	}
+	want := domain.VideoInfo{Duration: 83500 * time.Millisecond, Width: 1920, Height: 1080, Codec: "h264", HasAudio: true}
+	if *info != want {
+		t.Errorf("got %+v, want %+v", *info, want)
+	}
+
+	if _, err := parseProbeOutput([]byte(`{"streams": [{"codec_type": "audio"}], "format": {}}`)); err == nil {
+		t.Error("expected error for a file without a video stream")
+	}
+}
@@ -0,0 +1,403 @@
+package adapter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	wikiHeadingPattern   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListPattern      = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiCodeOpenPattern  = regexp.MustCompile(`^\{(code|noformat)(?::([^}]*))?\}(.*)$`)
+	wikiPanelOpenPattern = regexp.MustCompile(`^\{(panel|quote|info|note|warning|tip)(?::([^}]*))?\}(.*)$`)
+	wikiRulePattern      = regexp.MustCompile(`^-{4,}$`)
+)
+
+// wikiPanelLabels는 정보성 매크로({info}, {warning} 등)의 머리말이다.
+var wikiPanelLabels = map[string]string{
+	"info":    "ℹ️ 정보",
+	"note":    "📝 참고",
+	"warning": "⚠️ 경고",
+	"tip":     "💡 팁",
+}
+
+// wikiToMarkdown은 Jira Server/Data Center(REST v2)의 wiki markup을 Markdown으로 변환한다.
+// 첨부 이미지(!파일명!)는 ADF 변환과 같은 {{MEDIA:파일명}} 플레이스홀더로 남긴다.
+func wikiToMarkdown(wiki string) string {
+	lines := strings.Split(strings.ReplaceAll(wiki, "\r\n", "\n"), "\n")
+
+	// 블록 매크로 앞뒤에 넣은 빈 줄과 원문의 빈 줄이 겹치지 않게 연속된 빈 줄을 하나로 줄인다.
+	// 코드 블록은 한 항목으로 들어 있으므로 내부 빈 줄은 영향을 받지 않는다.
+	var out []string
+	for _, line := range renderWikiLines(lines) {
+		if line == "" && len(out) > 0 && out[len(out)-1] == "" {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// renderWikiLines는 wiki 줄 목록을 블록 단위로 변환한다.
+func renderWikiLines(lines []string) []string {
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		// {code}/{noformat}: 닫는 태그까지 원문 그대로 펜스 코드로 옮긴다.
+		if m := wikiCodeOpenPattern.FindStringSubmatch(trimmed); m != nil {
+			closing := "{" + m[1] + "}"
+			var body []string
+			rest := m[3]
+			for {
+				if idx := strings.Index(rest, closing); idx >= 0 {
+					body = append(body, rest[:idx])
+					break
+				}
+				body = append(body, rest)
+				i++
+				if i >= len(lines) {
+					break
+				}
+				rest = lines[i]
+			}
+			if len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+				body = body[1:]
+			}
+			if len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+				body = body[:len(body)-1]
+			}
+			out = append(out, wikiFence(strings.Join(body, "\n"), wikiCodeLanguage(m[1], m[2])))
+			continue
+		}
+
+		// {quote}/{panel}/{info} 등: 내부를 재귀 변환한 뒤 인용으로 감싼다.
+		if m := wikiPanelOpenPattern.FindStringSubmatch(trimmed); m != nil {
+			closing := "{" + m[1] + "}"
+			var inner []string
+			rest := m[3]
+			for {
+				if idx := strings.Index(rest, closing); idx >= 0 {
+					inner = append(inner, rest[:idx])
+					break
+				}
+				inner = append(inner, rest)
+				i++
+				if i >= len(lines) {
+					break
+				}
+				rest = lines[i]
+			}
+			body := strings.TrimSpace(strings.Join(renderWikiLines(inner), "\n"))
+			if title := wikiMacroTitle(m[1], m[2]); title != "" {
+				body = "**" + title + "**\n\n" + body
+			}
+			out = append(out, "", prefixMarkdownLines(body, "> "), "")
+			continue
+		}
+
+		if m := wikiHeadingPattern.FindStringSubmatch(trimmed); m != nil {
+			level := int(m[1][0] - '0')
+			out = append(out, strings.Repeat("#", level)+" "+convertWikiInline(m[2]))
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "bq. ") {
+			out = append(out, "> "+convertWikiInline(strings.TrimPrefix(trimmed, "bq. ")))
+			continue
+		}
+
+		if wikiRulePattern.MatchString(trimmed) {
+			out = append(out, "---")
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") {
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			out = append(out, renderWikiTable(rows))
+			continue
+		}
+
+		if m := wikiListPattern.FindStringSubmatch(trimmed); m != nil && !wikiRulePattern.MatchString(trimmed) {
+			out = append(out, renderWikiListItem(m[1], m[2]))
+			continue
+		}
+
+		out = append(out, convertWikiInline(line))
+	}
+	return out
+}
+
+// wikiCodeLanguage는 {code:java} 또는 {code:title=x|language=go} 매개변수에서 언어를 찾는다.
+func wikiCodeLanguage(macro, params string) string {
+	if macro != "code" || params == "" {
+		return ""
+	}
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			return strings.TrimSpace(key)
+		}
+		if strings.TrimSpace(key) == "language" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// wikiMacroTitle은 패널류 매크로의 제목(title=...)이나 기본 머리말을 반환한다.
+func wikiMacroTitle(macro, params string) string {
+	for _, param := range strings.Split(params, "|") {
+		if key, value, found := strings.Cut(param, "="); found && strings.TrimSpace(key) == "title" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return wikiPanelLabels[macro]
+}
+
+// wikiFence는 본문에 포함되지 않는 길이의 펜스로 코드 블록을 만든다.
+func wikiFence(body, language string) string {
+	fence := "```"
+	for strings.Contains(body, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + body + "\n" + fence
+}
+
+// renderWikiListItem은 "*", "#", "*#" 같은 중첩 목록 마커를 들여쓴 Markdown 목록 항목으로 바꾼다.
+// 하위 항목은 상위 마커 폭("- "는 2칸, "1. "은 3칸)만큼 들여써야 같은 항목에 속한다.
+func renderWikiListItem(markers, text string) string {
+	indent := 0
+	for _, m := range markers[:len(markers)-1] {
+		indent += len(wikiListMarker(byte(m)))
+	}
+	return strings.Repeat(" ", indent) + wikiListMarker(markers[len(markers)-1]) + convertWikiInline(text)
+}
+
+// wikiListMarker는 wiki 목록 기호에 대응하는 Markdown 마커를 반환한다.
+func wikiListMarker(m byte) string {
+	if m == '#' {
+		return "1. "
+	}
+	return "- "
+}
+
+// renderWikiTable은 ||머리글|| 및 |셀| 행을 GFM 표로 변환한다.
+func renderWikiTable(rows []string) string {
+	var cells [][]string
+	columns := 0
+	hasHeader := false
+	for i, row := range rows {
+		if i == 0 {
+			hasHeader = strings.HasPrefix(row, "||")
+		}
+		// 머리글 셀 구분자(||)를 일반 구분자로 맞춘 뒤 양 끝 구분자를 떼어낸다.
+		row = strings.ReplaceAll(row, "||", "|")
+		row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+		var rowCells []string
+		for _, cell := range splitWikiTableRow(row) {
+			rowCells = append(rowCells, strings.ReplaceAll(strings.TrimSpace(convertWikiInline(cell)), "|", "\\|"))
+		}
+		if len(rowCells) > columns {
+			columns = len(rowCells)
+		}
+		cells = append(cells, rowCells)
+	}
+
+	// 머리글 행이 없으면 빈 머리글을 만들어 GFM 표 형식을 맞춘다.
+	if !hasHeader {
+		cells = append([][]string{nil}, cells...)
+	}
+	var sb strings.Builder
+	for i, row := range cells {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// splitWikiTableRow는 링크([a|b])나 이미지(!a|b!) 안의 파이프를 무시하고 셀을 나눈다.
+func splitWikiTableRow(row string) []string {
+	var cells []string
+	var current strings.Builder
+	bracket, brace := 0, 0
+	inImage := false
+	for _, r := range row {
+		switch r {
+		case '[':
+			bracket++
+		case ']':
+			if bracket > 0 {
+				bracket--
+			}
+		case '{':
+			brace++
+		case '}':
+			if brace > 0 {
+				brace--
+			}
+		case '!':
+			inImage = !inImage
+		case '|':
+			if bracket == 0 && brace == 0 && !inImage {
+				cells = append(cells, current.String())
+				current.Reset()
+				continue
+			}
+		}
+		current.WriteRune(r)
+	}
+	return append(cells, current.String())
+}
+
+// wikiMarks는 wiki 인라인 서식 기호와 대응하는 Markdown 표기다.
+var wikiMarks = map[byte][2]string{
+	'*': {"**", "**"},
+	'_': {"*", "*"},
+	'-': {"~~", "~~"},
+	'+': {"<u>", "</u>"},
+	'^': {"<sup>", "</sup>"},
+	'~': {"<sub>", "</sub>"},
+}
+
+// convertWikiInline은 한 줄의 wiki 인라인 서식을 Markdown으로 변환한다.
+func convertWikiInline(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "{{"):
+			if end := strings.Index(rest[2:], "}}"); end >= 0 {
+				sb.WriteString(markdownCodeSpan(rest[2 : 2+end]))
+				i += end + 4
+				continue
+			}
+
+		case strings.HasPrefix(rest, `\\`):
+			sb.WriteString("\n")
+			i += 2
+			continue
+
+		case strings.HasPrefix(rest, "{color"):
+			if end := strings.Index(rest, "}"); end >= 0 {
+				i += end + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if end := strings.Index(rest, "]"); end > 0 {
+				sb.WriteString(convertWikiLink(rest[1:end]))
+				i += end + 1
+				continue
+			}
+
+		case rest[0] == '!':
+			if end := strings.Index(rest[1:], "!"); end > 0 && !strings.ContainsAny(rest[1:1+end], " \t") {
+				sb.WriteString(convertWikiImage(rest[1 : 1+end]))
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "??"):
+			// ??인용?? (citation)
+			if end := strings.Index(rest[2:], "??"); end > 0 {
+				sb.WriteString("*" + convertWikiInline(rest[2:2+end]) + "*")
+				i += end + 4
+				continue
+			}
+		}
+
+		if mark, ok := wikiMarks[rest[0]]; ok && wikiMarkOpens(text, i) {
+			if end := wikiMarkClose(text, i); end > 0 {
+				sb.WriteString(mark[0] + convertWikiInline(text[i+1:end]) + mark[1])
+				i = end + 1
+				continue
+			}
+		}
+
+		sb.WriteByte(text[i])
+		i++
+	}
+	return sb.String()
+}
+
+// wikiMarkOpens는 i 위치의 기호가 서식 시작으로 쓰였는지 확인한다 (앞은 경계, 뒤는 공백이 아님).
+func wikiMarkOpens(text string, i int) bool {
+	if i+1 >= len(text) || text[i+1] == ' ' || text[i+1] == text[i] {
+		return false
+	}
+	return i == 0 || !isWikiWordByte(text[i-1])
+}
+
+// wikiMarkClose는 i 위치에서 시작한 서식의 닫는 기호 위치를 찾는다 (없으면 -1).
+func wikiMarkClose(text string, i int) int {
+	mark := text[i]
+	for j := i + 2; j < len(text); j++ {
+		if text[j] != mark || text[j-1] == ' ' {
+			continue
+		}
+		if j+1 == len(text) || !isWikiWordByte(text[j+1]) {
+			return j
+		}
+	}
+	return -1
+}
+
+// isWikiWordByte는 서식 경계를 판단할 때 단어의 일부로 보는 문자인지 확인한다.
+func isWikiWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b >= 0x80
+}
+
+// convertWikiLink는 [텍스트|URL], [URL], [~사용자], [^첨부파일] 링크를 변환한다.
+func convertWikiLink(inner string) string {
+	switch {
+	case strings.HasPrefix(inner, "~"):
+		return "@" + strings.TrimPrefix(strings.TrimPrefix(inner, "~"), "accountid:")
+	case strings.HasPrefix(inner, "^"):
+		return fmt.Sprintf("{{MEDIA:%s}}", strings.TrimPrefix(inner, "^"))
+	}
+
+	label, target, found := strings.Cut(inner, "|")
+	if !found {
+		target = label
+	}
+	target = strings.TrimSpace(target)
+	if !strings.Contains(target, "://") && !strings.HasPrefix(target, "mailto:") {
+		// 외부 주소가 아니면 링크가 아닌 일반 대괄호 텍스트로 본다.
+		return "[" + inner + "]"
+	}
+	return fmt.Sprintf("[%s](%s)", convertWikiInline(strings.TrimSpace(label)), target)
+}
+
+// convertWikiImage는 !파일명|옵션! 또는 !URL! 이미지를 변환한다.
+func convertWikiImage(inner string) string {
+	source, _, _ := strings.Cut(inner, "|")
+	if strings.Contains(source, "://") {
+		return fmt.Sprintf("![](%s)", source)
+	}
+	return fmt.Sprintf("{{MEDIA:%s}}", source)
+}
+
+// markdownCodeSpan은 백틱이 포함된 텍스트도 깨지지 않는 인라인 코드로 감싼다.
+func markdownCodeSpan(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if len(fence) > 1 {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
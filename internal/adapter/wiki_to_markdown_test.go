@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWikiToMarkdown_Golden은 testdata/wiki/*.txt 샘플을 변환하여 같은 이름의 .md 파일과 비교한다.
+// 출력이 의도적으로 바뀐 경우 `go test ./internal/adapter -run Golden -update`로 갱신한다.
+func TestWikiToMarkdown_Golden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "wiki", "*.txt"))
+	if err != nil {
+		t.Fatalf("failed to list samples: %v", err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no wiki samples found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".txt")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatalf("failed to read sample: %v", err)
+			}
+
+			got := wikiToMarkdown(string(data)) + "\n"
+			goldenPath := strings.TrimSuffix(input, ".txt") + ".md"
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+					t.Fatalf("failed to update golden: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("markdown mismatch for %s\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+			}
+		})
+	}
+}
+
+func TestConvertWikiInline(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"*bold* and _em_", "**bold** and *em*"},
+		{"*a* *b*", "**a** **b**"},
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"2 * 3 * 4", "2 * 3 * 4"},
+		{"e-mail - dash", "e-mail - dash"},
+		{"-removed- +added+", "~~removed~~ <u>added</u>"},
+		{"{{a*b*c}}", "`a*b*c`"},
+		{"see [docs|https://example.com] or [https://x.io]", "see [docs](https://example.com) or [https://x.io](https://x.io)"},
+		{"array[0] stays", "array[0] stays"},
+		{"Hello! World!", "Hello! World!"},
+		{"!https://example.com/a.png!", "![](https://example.com/a.png)"},
+		{"??cited??", "*cited*"},
+	}
+	for _, tt := range tests {
+		if got := convertWikiInline(tt.input); got != tt.expected {
+			t.Errorf("convertWikiInline(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMarkdownToWiki(t *testing.T) {
+	markdown := "# Title\n\nUse **bold** and `code` with [docs](https://example.com)\n\n" +
+		"1. first\n   - child\n2. second\n\n```go\nfmt.Println(1)\n```\n\n> quoted\n\n| A | B |\n| --- | --- |\n| 1 | 2 |"
+
+	expected := "h1. Title\n\nUse *bold* and {{code}} with [docs|https://example.com]\n\n" +
+		"# first\n#* child\n# second\n\n{code:go}\nfmt.Println(1)\n{code}\n\n{quote}\nquoted\n{quote}\n\n||A||B||\n|1|2|"
+	if got := markdownToWiki(markdown); got != expected {
+		t.Errorf("unexpected wiki markup:\n got: %q\nwant: %q", got, expected)
+	}
+}
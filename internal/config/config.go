@@ -4,33 +4,96 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/ini.v1"
+
+	"jira-ai-generator/internal/domain"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Jira   JiraConfig
-	Output OutputConfig
-	AI     AIConfig
-	Claude ClaudeConfig
+	Jira         JiraConfig
+	CustomFields []CustomField // [custom_fields] 섹션 순서대로 문서 메타데이터 표에 표시
+	Output       OutputConfig
+	Video        VideoConfig
+	Image        ImageConfig
+	AI           AIConfig
+	Claude       ClaudeConfig
+}
+
+// CustomField maps a Jira custom field ID to the name shown in the generated document
+type CustomField struct {
+	ID   string // 예: customfield_10010
+	Name string // 예: 환경
 }
 
 // JiraConfig holds Jira-related settings
 type JiraConfig struct {
-	URL    string
-	Email  string
-	APIKey string
+	URL               string
+	Email             string
+	APIKey            string         // Cloud: API 토큰, Server/Data Center: Personal Access Token
+	AuthMode          string         // basic (이메일 + API 토큰) 또는 bearer (PAT)
+	APIVersion        string         // auto, 2 (Server/Data Center), 3 (Cloud)
+	AutoAttachResults bool           // 2차/3차 완료 시 _plan.md / _execution.md 자동 첨부
+	PhaseTransitions  map[int]string // 단계 완료 시 실행할 워크플로 전환 이름 (2: 플랜 완료, 3: 실행 완료)
+	FetchRelated      bool           // 상위/하위/링크된 이슈의 설명까지 조회하여 문서에 포함
+	MaxRetries        int            // 429/502/503/504 응답 시 재시도 횟수
+	MaxConcurrency    int            // Jira 호스트별 동시 요청 수 (0이면 제한 없음)
 }
 
+// Jira authentication modes and REST API versions
+const (
+	AuthModeBasic  = "basic"
+	AuthModeBearer = "bearer"
+
+	APIVersionAuto = "auto"
+)
+
+// AuthModes lists the supported Jira authentication modes
+var AuthModes = []string{AuthModeBasic, AuthModeBearer}
+
+// APIVersions lists the selectable Jira REST API versions
+var APIVersions = []string{APIVersionAuto, "3", "2"}
+
+// TransitionPhases lists the phases that can trigger a Jira workflow transition
+var TransitionPhases = []int{2, 3}
+
 // OutputConfig holds output-related settings
 type OutputConfig struct {
-	Dir string
+	Dir                string
+	CommentLimit       int      // 문서에 포함할 최근 코멘트 수 (0이면 전체)
+	ExcludeBotComments bool     // Jira 앱/자동화 계정 코멘트 제외
+	BotCommentAuthors  []string // 봇으로 볼 계정 (사용자 이름, accountId 또는 표시 이름; Server/Data Center용)
+	MaxAttachmentMB    int      // 이보다 큰 첨부파일은 받지 않음 (MB, 0이면 제한 없음)
+	DownloadWorkers    int      // 동시에 받을 첨부파일 수
+	MaxInlineKB        int      // 로그/텍스트 첨부파일을 문서에 넣을 최대 크기 (KB, 앞뒤를 남기고 자름)
+}
+
+// VideoConfig holds video frame extraction settings
+type VideoConfig struct {
+	FrameMode         string  // scene (화면 전환 감지) 또는 interval (일정 간격)
+	FrameInterval     float64 // interval 모드의 추출 간격 (초)
+	SceneThreshold    float64 // scene 모드의 화면 전환 임계값 (0~1, 낮을수록 민감)
+	MaxFrames         int     // 동영상당 최대 프레임 수
+	FrameWidth        int     // 프레임 가로 크기 (픽셀, 0이면 원본)
+	ContactSheet      bool    // 프레임을 한 장의 타일 이미지로 합쳐 문서에 넣음
+	DedupeDistance    int     // 직전 프레임과 거의 같은 프레임을 버리는 해밍 거리 (0이면 사용 안 함)
+	TranscribeCommand string  // 음성 인식 명령 템플릿 ({audio}, {output}, 비어 있으면 사용 안 함)
+}
+
+// ImageConfig holds settings for preparing screenshots and frames before they are put in documents
+type ImageConfig struct {
+	MaxDimension int // 긴 변 최대 픽셀 (넘으면 줄임, 0이면 줄이지 않음)
+	ConvertPNGKB int // 이보다 큰 PNG는 JPEG로 바꿔 더 작으면 JPEG 사용 (KB, 0이면 변환 안 함)
+	JPEGQuality  int // JPEG 품질 (1~100)
 }
 
 // AIConfig holds AI-related settings
 type AIConfig struct {
 	PromptTemplate string
+	Backend        string // 분석/실행에 사용할 AI 백엔드: claude 또는 cli (agent_command로 실행)
+	AgentCommand   string // cli 백엔드 명령 템플릿 ({prompt_file}, {workdir}, 비어 있으면 사용 안 함)
 }
 
 // ClaudeConfig holds Claude Code CLI settings
@@ -38,8 +101,11 @@ type ClaudeConfig struct {
 	CLIPath        string
 	ChannelPaths   [3]string // 채널별 프로젝트 경로
 	Enabled        bool
-	Model          string // Claude 모델 (claude-sonnet-4-20250514, claude-opus-4-20250514 등)
-	HookScriptPath string // Claude 실행 시 강제 적용할 프로젝트 전용 Hook 스크립트 경로
+	Model          string  // Claude 모델 (claude-sonnet-4-20250514, claude-opus-4-20250514 등)
+	HookScriptPath string  // Claude 실행 시 강제 적용할 프로젝트 전용 Hook 스크립트 경로
+	OutputFormat   string  // CLI 출력 형식: stream-json (진행 상황 실시간 표시) 또는 text
+	DailyBudgetUSD float64 // 하루(로컬 시간) 비용 한도 (USD, 0이면 제한 없음). 넘으면 새 분석/실행을 시작하지 않음
+	ResumeSession  bool    // 3차 실행 시 2차 플랜 세션을 --resume으로 이어서 실행 (세션이 없거나 만료되면 플랜 전체 재전송)
 }
 
 // Available Claude models
@@ -62,14 +128,65 @@ func Load(path string) (*Config, error) {
 	config.Jira.URL = jiraSection.Key("url").String()
 	config.Jira.Email = jiraSection.Key("email").String()
 	config.Jira.APIKey = jiraSection.Key("api_key").String()
+	config.Jira.AuthMode = jiraSection.Key("auth_mode").In(AuthModeBasic, AuthModes)
+	config.Jira.APIVersion = jiraSection.Key("api_version").In(APIVersionAuto, APIVersions)
+	config.Jira.AutoAttachResults = jiraSection.Key("auto_attach_results").MustBool(false)
+	config.Jira.FetchRelated = jiraSection.Key("fetch_related_descriptions").MustBool(false)
+	config.Jira.MaxRetries = jiraSection.Key("max_retries").MustInt(3)
+	config.Jira.MaxConcurrency = jiraSection.Key("max_concurrent_requests").MustInt(4)
+	config.Jira.PhaseTransitions = make(map[int]string)
+	for _, phase := range TransitionPhases {
+		name := strings.TrimSpace(jiraSection.Key(fmt.Sprintf("transition_phase_%d", phase)).String())
+		if name != "" {
+			config.Jira.PhaseTransitions[phase] = name
+		}
+	}
+
+	// Custom fields section (customfield_10010 = 환경)
+	for _, key := range cfg.Section("custom_fields").Keys() {
+		id := strings.TrimSpace(key.Name())
+		name := strings.TrimSpace(key.String())
+		if id == "" {
+			continue
+		}
+		if name == "" {
+			name = id
+		}
+		config.CustomFields = append(config.CustomFields, CustomField{ID: id, Name: name})
+	}
 
 	// Output section
 	outputSection := cfg.Section("output")
 	config.Output.Dir = outputSection.Key("dir").MustString("./output")
+	config.Output.CommentLimit = outputSection.Key("comment_limit").MustInt(0)
+	config.Output.ExcludeBotComments = outputSection.Key("exclude_bot_comments").MustBool(false)
+	config.Output.BotCommentAuthors = outputSection.Key("bot_comment_authors").Strings(",")
+	config.Output.MaxAttachmentMB = outputSection.Key("max_attachment_mb").MustInt(0)
+	config.Output.DownloadWorkers = outputSection.Key("download_workers").MustInt(3)
+	config.Output.MaxInlineKB = outputSection.Key("max_inline_kb").MustInt(64)
+
+	// Video section
+	videoSection := cfg.Section("video")
+	config.Video.FrameMode = videoSection.Key("frame_mode").In("scene", []string{"scene", "interval"})
+	config.Video.FrameInterval = videoSection.Key("frame_interval").MustFloat64(1.0)
+	config.Video.SceneThreshold = videoSection.Key("scene_threshold").MustFloat64(0.3)
+	config.Video.MaxFrames = videoSection.Key("max_frames").MustInt(10)
+	config.Video.FrameWidth = videoSection.Key("frame_width").MustInt(640)
+	config.Video.ContactSheet = videoSection.Key("contact_sheet").MustBool(false)
+	config.Video.DedupeDistance = videoSection.Key("dedupe_distance").MustInt(4)
+	config.Video.TranscribeCommand = videoSection.Key("transcribe_command").String()
+
+	// Image section
+	imageSection := cfg.Section("image")
+	config.Image.MaxDimension = imageSection.Key("max_dimension").MustInt(1568)
+	config.Image.ConvertPNGKB = imageSection.Key("convert_png_kb").MustInt(1024)
+	config.Image.JPEGQuality = imageSection.Key("jpeg_quality").MustInt(85)
 
 	// AI section
 	aiSection := cfg.Section("ai")
 	config.AI.PromptTemplate = aiSection.Key("prompt_template").String()
+	config.AI.Backend = aiSection.Key("backend").In(domain.AIBackendClaude, domain.AIBackends)
+	config.AI.AgentCommand = strings.TrimSpace(aiSection.Key("agent_command").String())
 
 	// Claude section
 	claudeSection := cfg.Section("claude")
@@ -82,6 +199,12 @@ func Load(path string) (*Config, error) {
 	config.Claude.Enabled = claudeSection.Key("enabled").MustBool(false)
 	config.Claude.Model = claudeSection.Key("model").MustString("claude-sonnet-4-20250514")
 	config.Claude.HookScriptPath = claudeSection.Key("hook_script_path").MustString("")
+	config.Claude.OutputFormat = claudeSection.Key("output_format").In("stream-json", []string{"stream-json", "text"})
+	config.Claude.DailyBudgetUSD = claudeSection.Key("daily_budget_usd").MustFloat64(0)
+	if config.Claude.DailyBudgetUSD < 0 {
+		config.Claude.DailyBudgetUSD = 0
+	}
+	config.Claude.ResumeSession = claudeSection.Key("resume_session").MustBool(true)
 
 	return config, nil
 }
@@ -110,8 +233,8 @@ func (c *Config) Validate() error {
 	if c.Jira.URL == "" {
 		return fmt.Errorf("jira.url is required")
 	}
-	if c.Jira.Email == "" {
-		return fmt.Errorf("jira.email is required")
+	if c.Jira.Email == "" && c.Jira.AuthMode != AuthModeBearer {
+		return fmt.Errorf("jira.email is required when jira.auth_mode=basic")
 	}
 	if c.Jira.APIKey == "" {
 		return fmt.Errorf("jira.api_key is required")
@@ -138,14 +261,54 @@ func (c *Config) Save(path string) error {
 	jiraSection.NewKey("url", c.Jira.URL)
 	jiraSection.NewKey("email", c.Jira.Email)
 	jiraSection.NewKey("api_key", c.Jira.APIKey)
+	jiraSection.NewKey("auth_mode", c.Jira.AuthMode)
+	jiraSection.NewKey("api_version", c.Jira.APIVersion)
+	jiraSection.NewKey("auto_attach_results", fmt.Sprintf("%v", c.Jira.AutoAttachResults))
+	jiraSection.NewKey("fetch_related_descriptions", fmt.Sprintf("%v", c.Jira.FetchRelated))
+	jiraSection.NewKey("max_retries", fmt.Sprintf("%d", c.Jira.MaxRetries))
+	jiraSection.NewKey("max_concurrent_requests", fmt.Sprintf("%d", c.Jira.MaxConcurrency))
+	for _, phase := range TransitionPhases {
+		jiraSection.NewKey(fmt.Sprintf("transition_phase_%d", phase), c.Jira.PhaseTransitions[phase])
+	}
+
+	// Custom fields section
+	customFieldsSection, _ := cfg.NewSection("custom_fields")
+	for _, field := range c.CustomFields {
+		customFieldsSection.NewKey(field.ID, field.Name)
+	}
 
 	// Output section
 	outputSection, _ := cfg.NewSection("output")
 	outputSection.NewKey("dir", c.Output.Dir)
+	outputSection.NewKey("comment_limit", fmt.Sprintf("%d", c.Output.CommentLimit))
+	outputSection.NewKey("exclude_bot_comments", fmt.Sprintf("%v", c.Output.ExcludeBotComments))
+	outputSection.NewKey("bot_comment_authors", strings.Join(c.Output.BotCommentAuthors, ", "))
+	outputSection.NewKey("max_attachment_mb", fmt.Sprintf("%d", c.Output.MaxAttachmentMB))
+	outputSection.NewKey("download_workers", fmt.Sprintf("%d", c.Output.DownloadWorkers))
+	outputSection.NewKey("max_inline_kb", fmt.Sprintf("%d", c.Output.MaxInlineKB))
+
+	// Video section
+	videoSection, _ := cfg.NewSection("video")
+	videoSection.NewKey("frame_mode", c.Video.FrameMode)
+	videoSection.NewKey("frame_interval", fmt.Sprintf("%g", c.Video.FrameInterval))
+	videoSection.NewKey("scene_threshold", fmt.Sprintf("%g", c.Video.SceneThreshold))
+	videoSection.NewKey("max_frames", fmt.Sprintf("%d", c.Video.MaxFrames))
+	videoSection.NewKey("frame_width", fmt.Sprintf("%d", c.Video.FrameWidth))
+	videoSection.NewKey("contact_sheet", fmt.Sprintf("%t", c.Video.ContactSheet))
+	videoSection.NewKey("dedupe_distance", fmt.Sprintf("%d", c.Video.DedupeDistance))
+	videoSection.NewKey("transcribe_command", c.Video.TranscribeCommand)
+
+	// Image section
+	imageSection, _ := cfg.NewSection("image")
+	imageSection.NewKey("max_dimension", fmt.Sprintf("%d", c.Image.MaxDimension))
+	imageSection.NewKey("convert_png_kb", fmt.Sprintf("%d", c.Image.ConvertPNGKB))
+	imageSection.NewKey("jpeg_quality", fmt.Sprintf("%d", c.Image.JPEGQuality))
 
 	// AI section
 	aiSection, _ := cfg.NewSection("ai")
 	aiSection.NewKey("prompt_template", c.AI.PromptTemplate)
+	aiSection.NewKey("backend", c.AI.Backend)
+	aiSection.NewKey("agent_command", c.AI.AgentCommand)
 
 	// Claude section
 	claudeSection, _ := cfg.NewSection("claude")
@@ -156,6 +319,9 @@ func (c *Config) Save(path string) error {
 	claudeSection.NewKey("enabled", fmt.Sprintf("%v", c.Claude.Enabled))
 	claudeSection.NewKey("model", c.Claude.Model)
 	claudeSection.NewKey("hook_script_path", c.Claude.HookScriptPath)
+	claudeSection.NewKey("output_format", c.Claude.OutputFormat)
+	claudeSection.NewKey("daily_budget_usd", fmt.Sprintf("%g", c.Claude.DailyBudgetUSD))
+	claudeSection.NewKey("resume_session", fmt.Sprintf("%v", c.Claude.ResumeSession))
 
 	return cfg.SaveTo(path)
 }
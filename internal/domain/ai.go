@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// AI analyzer backends
+const (
+	AIBackendClaude = "claude" // Claude Code CLI
+	AIBackendCLI    = "cli"    // 명령 템플릿으로 실행하는 임의의 CLI 에이전트
+	AIBackendFake   = "fake"   // 테스트용 (CLI 없이 즉시 결과 생성)
+)
+
+// AIBackends lists the selectable AI analyzer backends
+var AIBackends = []string{AIBackendClaude, AIBackendCLI}
+
+// AIRun identifies a background run started by an AI analyzer.
+// 실행이 끝나면 StatusPath의 상태 파일(JSON)에 finished=true가 기록되고, 그 전에 OutputPath의 결과 파일이 완성된다.
+type AIRun struct {
+	Backend        string // 실행한 백엔드 (AIBackendClaude 등)
+	OutputPath     string // 결과 파일 (_plan.md 또는 _execution.md)
+	StatusPath     string // 실행 상태 파일 경로
+	LogPath        string // 에이전트 stderr 파일 경로
+	PID            int    // 에이전트 프로세스 ID (프로세스가 없으면 0)
+	ResumedSession string // 이어서 실행한 이전 세션 ID (새 세션이면 빈 문자열)
+}
+
+// AIRunStatus is the state of an AI run reported by the analyzer that started it
+type AIRunStatus struct {
+	Finished       bool          // 종료 후 결과 파일까지 조립됨
+	ExitCode       int           // 시그널로 종료되면 -1
+	Error          string        // 실행 또는 결과 조립 실패 사유
+	Duration       time.Duration // 실행 시간 (실행 중이면 0)
+	Usage          AnalysisUsage // 토큰 사용량과 비용 (CLI가 보고하지 않았으면 실행 시간만 채워짐)
+	SessionID      string        // 이어서 실행할 수 있는 CLI 세션 ID (없으면 빈 문자열)
+	SessionExpired bool          // 이어서 실행할 세션을 CLI가 찾지 못해 실패함
+	OutputFormat   string        // 에이전트 stdout 형식 (text 또는 stream-json)
+	StdoutPath     string        // 에이전트 stdout 파일 경로
+}
+
+// Failed reports whether a finished run exited with a non-zero code or could not be completed
+func (s *AIRunStatus) Failed() bool {
+	return s.Finished && (s.ExitCode != 0 || s.Error != "")
+}
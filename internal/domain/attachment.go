@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// AttachmentKind classifies an attachment by how it is used in the generated document
+type AttachmentKind string
+
+const (
+	AttachmentUnsupported AttachmentKind = ""
+	AttachmentImage       AttachmentKind = "image"
+	AttachmentVideo       AttachmentKind = "video"
+	AttachmentText        AttachmentKind = "text"    // 로그, 텍스트, JSON 등 본문에 그대로 넣는 파일
+	AttachmentArchive     AttachmentKind = "archive" // zip 압축 파일 (목록과 내부 텍스트 파일을 넣음)
+)
+
+// textExtensions lists extensions treated as text even when Jira reports application/octet-stream
+var textExtensions = map[string]bool{
+	".log": true, ".txt": true, ".json": true, ".xml": true, ".yaml": true, ".yml": true,
+	".csv": true, ".md": true, ".ini": true, ".conf": true, ".cfg": true, ".properties": true,
+	".trace": true, ".stacktrace": true, ".out": true, ".err": true, ".har": true,
+}
+
+// ClassifyAttachment decides the kind of an attachment from its MIME type and filename extension
+func ClassifyAttachment(mimeType, filename string) AttachmentKind {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return AttachmentImage
+	case strings.HasPrefix(mimeType, "video/"):
+		return AttachmentVideo
+	case mimeType == "application/zip" || mimeType == "application/x-zip-compressed" || ext == ".zip":
+		return AttachmentArchive
+	case strings.HasPrefix(mimeType, "text/"), mimeType == "application/json", mimeType == "application/xml",
+		strings.HasSuffix(mimeType, "+json"), strings.HasSuffix(mimeType, "+xml"), textExtensions[ext]:
+		return AttachmentText
+	}
+	return AttachmentUnsupported
+}
+
+// GeneratedOutputNames lists the documents the app writes into an issue folder.
+// 첨부파일이 이 이름으로 저장되면 생성 문서나 AI 결과를 덮어쓰게 된다.
+func GeneratedOutputNames(issueKey string) []string {
+	return []string{
+		issueKey + ".md",
+		issueKey + "_plan.md",
+		issueKey + "_execution.md",
+		issueKey + "_analysis.md",
+	}
+}
+
+// InlineAttachment is the content of a text attachment (or zip archive) to be embedded in the document
+type InlineAttachment struct {
+	Filename  string         // 원래 파일명 (압축 파일 내부 파일은 압축 파일 안의 경로)
+	Kind      AttachmentKind // AttachmentText 또는 AttachmentArchive
+	Language  string         // 코드 블록 언어 (json, xml 등, 없으면 빈 문자열)
+	Content   string         // 앞뒤만 남기고 잘라낸 내용
+	Size      int64          // 원본 크기 (바이트)
+	Truncated bool           // Content가 원본의 일부인지 여부
+
+	Entries []ArchiveEntry     // 압축 파일의 전체 목록
+	Files   []InlineAttachment // 압축 파일 안에서 본문에 넣은 텍스트 파일
+}
+
+// ArchiveEntry is a single file listed in a zip archive
+type ArchiveEntry struct {
+	Name string
+	Size int64
+}
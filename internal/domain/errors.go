@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// ErrNotFound is returned when a remote or persisted resource no longer exists
+var ErrNotFound = errors.New("not found")
+
+// ErrBudgetExceeded is returned when today's AI usage cost has reached the configured daily budget
+var ErrBudgetExceeded = errors.New("daily budget exceeded")
+
+// ErrSessionUnsupported is returned by AI analyzers that cannot resume a previous session
+var ErrSessionUnsupported = errors.New("session resume not supported")
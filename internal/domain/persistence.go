@@ -19,24 +19,30 @@ type IssueRecord struct {
 
 // AnalysisResult represents the result of AI analysis
 type AnalysisResult struct {
-	ID            int64      `json:"id"`
-	IssueID       int64      `json:"issue_id"`
-	AnalysisPhase int        `json:"analysis_phase"` // 1: PhaseAnalyze, 2: PhaseExecute
-	ResultPath    string     `json:"result_path"`
-	PlanPath      string     `json:"plan_path"`
-	ExecutionPath string     `json:"execution_path"`
-	Status        string     `json:"status"` // pending, running, completed, failed
-	StartedAt     *time.Time `json:"started_at"`
-	CompletedAt   *time.Time `json:"completed_at"`
-	ErrorMessage  string     `json:"error_message"`
+	ID            int64         `json:"id"`
+	IssueID       int64         `json:"issue_id"`
+	AnalysisPhase int           `json:"analysis_phase"` // 1: PhaseAnalyze, 2: PhaseExecute
+	ResultPath    string        `json:"result_path"`
+	PlanPath      string        `json:"plan_path"`
+	ExecutionPath string        `json:"execution_path"`
+	Status        string        `json:"status"` // pending, running, completed, failed
+	StartedAt     *time.Time    `json:"started_at"`
+	CompletedAt   *time.Time    `json:"completed_at"`
+	ErrorMessage  string        `json:"error_message"`
+	JiraCommentID string        `json:"jira_comment_id"` // Jira에 게시된 코멘트 ID (재게시 시 갱신용)
+	Usage         AnalysisUsage `json:"usage"`           // CLI가 보고한 토큰 사용량과 비용
+	SessionID     string        `json:"session_id"`      // CLI 세션 ID (2차 세션은 3차에서 --resume으로 이어서 실행)
 }
 
 // AttachmentRecord represents a persisted attachment
 type AttachmentRecord struct {
-	ID        int64  `json:"id"`
-	IssueID   int64  `json:"issue_id"`
-	Filename  string `json:"filename"`
-	LocalPath string `json:"local_path"`
-	MimeType  string `json:"mime_type"`
-	IsVideo   bool   `json:"is_video"`
+	ID          int64  `json:"id"`
+	IssueID     int64  `json:"issue_id"`
+	Filename    string `json:"filename"`   // Jira의 원래 파일명
+	LocalPath   string `json:"local_path"` // 실제 저장 경로 (파일명은 정리/중복 처리된 이름)
+	MimeType    string `json:"mime_type"`
+	IsVideo     bool   `json:"is_video"`
+	JiraID      string `json:"jira_id"`      // Jira 첨부파일 ID
+	Size        int64  `json:"size"`         // 바이트 단위 크기
+	ContentHash string `json:"content_hash"` // 내용의 SHA-256 (hex)
 }
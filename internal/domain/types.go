@@ -1,12 +1,72 @@
 package domain
 
+import "time"
+
 // JiraIssue represents a Jira issue with its details
 type JiraIssue struct {
-	Key         string       `json:"key"`
-	Summary     string       `json:"summary"`
-	Description string       `json:"description"`
-	Attachments []Attachment `json:"attachments"`
-	Link        string       `json:"link"`
+	Key         string         `json:"key"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description"`
+	Attachments []Attachment   `json:"attachments"`
+	Comments    []Comment      `json:"comments"`
+	Related     []RelatedIssue `json:"related"`
+	Metadata    IssueMetadata  `json:"metadata"`
+	Link        string         `json:"link"`
+
+	// MediaNames maps original attachment filenames to the local file names they were saved as
+	// (다운로드 후 채워지며, 문서의 {{MEDIA:파일명}}을 실제 파일로 연결할 때 사용)
+	MediaNames map[string]string `json:"-"`
+	// Inlined holds text attachments and zip archives to embed in the document (다운로드 후 채움)
+	Inlined []InlineAttachment `json:"-"`
+	// Videos maps local video file names to their probed metadata (다운로드 후 채움)
+	Videos map[string]VideoInfo `json:"-"`
+	// Transcripts maps local video file names to the speech recognized in them (다운로드 후 채움)
+	Transcripts map[string][]TranscriptSegment `json:"-"`
+}
+
+// IssueMetadata holds standard Jira fields and configured custom fields of an issue
+type IssueMetadata struct {
+	Status       string        `json:"status"`
+	Priority     string        `json:"priority"`
+	Reporter     string        `json:"reporter"`
+	Assignee     string        `json:"assignee"`
+	Labels       []string      `json:"labels"`
+	Components   []string      `json:"components"`
+	FixVersions  []string      `json:"fixVersions"`
+	CustomFields []CustomField `json:"customFields"`
+}
+
+// CustomField represents a Jira custom field mapped to a display name
+type CustomField struct {
+	ID    string `json:"id"`    // 예: customfield_10010
+	Name  string `json:"name"`  // 문서에 표시할 이름 (예: 환경)
+	Value string `json:"value"` // 표시용 문자열로 변환된 값
+}
+
+// Relation labels for parent/subtask relationships (issue links use the Jira link type wording)
+const (
+	RelationParent  = "상위 이슈"
+	RelationSubtask = "하위 작업"
+)
+
+// RelatedIssue represents an issue connected to a Jira issue via parent, subtask or issue link
+type RelatedIssue struct {
+	Key         string `json:"key"`
+	Summary     string `json:"summary"`
+	Status      string `json:"status"`
+	Relation    string `json:"relation"` // RelationParent, RelationSubtask 또는 링크 방향 이름 (예: "is blocked by")
+	Link        string `json:"link"`
+	Description string `json:"description"` // 옵션 활성화 시 1단계 깊이까지 조회한 설명
+}
+
+// Comment represents a comment on a Jira issue (Body is Markdown converted from ADF)
+type Comment struct {
+	ID          string    `json:"id"`
+	Author      string    `json:"author"`
+	AuthorID    string    `json:"authorId"`    // Cloud: accountId, Server/Data Center: 사용자 이름
+	AuthorIsBot bool      `json:"authorIsBot"` // Jira 앱/자동화 계정이 작성한 코멘트 (Cloud에서만 판별)
+	Created     time.Time `json:"created"`
+	Body        string    `json:"body"`
 }
 
 // Attachment represents a file attached to a Jira issue
@@ -18,6 +78,21 @@ type Attachment struct {
 	URL      string `json:"content"`
 }
 
+// IssueSearchResult represents a single page of JQL search results
+type IssueSearchResult struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []JiraIssue `json:"issues"`
+}
+
+// Transition represents a Jira workflow transition available for an issue
+type Transition struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ToStatus string `json:"toStatus"`
+}
+
 // GeneratedDocument represents the output document for AI processing
 type GeneratedDocument struct {
 	IssueKey   string
@@ -34,12 +109,25 @@ type ProcessResult struct {
 	Document     *GeneratedDocument
 	MDPath       string
 	ErrorMessage string
+	Attachments  []DownloadResult // 다운로드(또는 캐시 재사용)한 첨부파일
 }
 
 // DownloadResult represents the result of downloading an attachment
 type DownloadResult struct {
-	Attachment Attachment
-	LocalPath  string
-	Error      error
-	IsVideo    bool
+	Attachment  Attachment
+	LocalPath   string
+	Error       error
+	IsVideo     bool
+	Kind        AttachmentKind
+	ContentHash string // 내용의 SHA-256 (hex)
+	FromCache   bool   // 다운로드 없이 로컬 캐시에서 가져옴
+}
+
+// DownloadProgress reports the progress of a single attachment within a DownloadAll call
+type DownloadProgress struct {
+	Filename   string
+	BytesDone  int64 // 현재 파일에서 받은 바이트 수
+	BytesTotal int64 // 현재 파일 크기 (알 수 없으면 0)
+	Completed  int   // 끝난(성공·실패·건너뜀) 파일 수
+	Total      int   // 받을 파일 수
 }
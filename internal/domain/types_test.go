@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"jira-ai-generator/internal/domain"
 )
@@ -69,3 +70,81 @@ func TestProcessResult_Success(t *testing.T) {
 		t.Error("expected document to not be nil")
 	}
 }
+
+func TestClassifyAttachment(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		filename string
+		want     domain.AttachmentKind
+	}{
+		{"image/png", "shot.png", domain.AttachmentImage},
+		{"video/mp4", "clip.mp4", domain.AttachmentVideo},
+		{"text/plain", "notes.txt", domain.AttachmentText},
+		{"application/json", "dump.json", domain.AttachmentText},
+		{"application/octet-stream", "server.log", domain.AttachmentText},
+		{"application/zip", "logs.zip", domain.AttachmentArchive},
+		{"application/octet-stream", "logs.ZIP", domain.AttachmentArchive},
+		{"application/pdf", "spec.pdf", domain.AttachmentUnsupported},
+		{"application/octet-stream", "setup.exe", domain.AttachmentUnsupported},
+	}
+	for _, tt := range tests {
+		if got := domain.ClassifyAttachment(tt.mimeType, tt.filename); got != tt.want {
+			t.Errorf("ClassifyAttachment(%q, %q) = %q, want %q", tt.mimeType, tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestFrameFilename_RoundTrip(t *testing.T) {
+	name := domain.FrameFilename("clip.final", 3, 75250*time.Millisecond)
+	if name != "clip.final_frame_0003_75250ms.png" {
+		t.Fatalf("unexpected frame filename %q", name)
+	}
+	video, ts, hasTimestamp, ok := domain.ParseFrameFilename(name)
+	if !ok || !hasTimestamp || video != "clip.final" || ts != 75250*time.Millisecond {
+		t.Errorf("ParseFrameFilename(%q) = %q, %v, %v, %v", name, video, ts, hasTimestamp, ok)
+	}
+
+	// 이전 형식의 프레임 파일명도 동영상 이름은 알아낼 수 있어야 한다
+	video, _, hasTimestamp, ok = domain.ParseFrameFilename("clip_frame_0001.png")
+	if !ok || hasTimestamp || video != "clip" {
+		t.Errorf("expected legacy frame name to parse without timestamp, got %q, %v, %v", video, hasTimestamp, ok)
+	}
+	// 이미지 최적화로 JPEG로 바뀐 프레임
+	video, ts, _, ok = domain.ParseFrameFilename("clip_frame_0002_1500ms.png.jpg")
+	if !ok || video != "clip" || ts != 1500*time.Millisecond {
+		t.Errorf("expected converted frame name to parse, got %q, %v, %v", video, ts, ok)
+	}
+	if _, _, _, ok := domain.ParseFrameFilename("shot.png"); ok {
+		t.Error("expected non-frame filename to be rejected")
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00.0"},
+		{12500 * time.Millisecond, "00:12.5"},
+		{75260 * time.Millisecond, "01:15.3"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03.0"},
+	}
+	for _, tt := range tests {
+		if got := domain.FormatTimestamp(tt.d); got != tt.want {
+			t.Errorf("FormatTimestamp(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestVideoInfo_String(t *testing.T) {
+	info := domain.VideoInfo{Duration: 83500 * time.Millisecond, Width: 1280, Height: 720, Codec: "h264", HasAudio: true}
+	if got := info.String(); got != "길이 01:23.5 · 1280x720 · h264 · 오디오 있음" {
+		t.Errorf("unexpected description %q", got)
+	}
+	if got := (domain.VideoInfo{}).String(); got != "오디오 없음" {
+		t.Errorf("expected unknown fields to be skipped, got %q", got)
+	}
+	if name, ok := domain.ParseContactSheetFilename(domain.ContactSheetFilename("clip.final")); !ok || name != "clip.final" {
+		t.Errorf("expected contact sheet name to round-trip, got %q, %v", name, ok)
+	}
+}
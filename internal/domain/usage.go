@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnalysisUsage is the token usage and cost of one AI CLI run (stream-json result 이벤트 기준)
+type AnalysisUsage struct {
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+	DurationMS          int64   `json:"duration_ms"`
+	NumTurns            int     `json:"num_turns"`
+}
+
+// Add accumulates other into u
+func (u *AnalysisUsage) Add(other AnalysisUsage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationTokens += other.CacheCreationTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CostUSD += other.CostUSD
+	u.DurationMS += other.DurationMS
+	u.NumTurns += other.NumTurns
+}
+
+// TotalTokens sums input, output and cache tokens
+func (u AnalysisUsage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// Duration returns the run time reported by the CLI
+func (u AnalysisUsage) Duration() time.Duration {
+	return time.Duration(u.DurationMS) * time.Millisecond
+}
+
+// String describes the usage, e.g. "입력 100 · 출력 50 · 캐시 쓰기 10 · 캐시 읽기 2000 토큰, $0.1234, 3턴, 5s"
+func (u AnalysisUsage) String() string {
+	return fmt.Sprintf("입력 %d · 출력 %d · 캐시 쓰기 %d · 캐시 읽기 %d 토큰, $%.4f, %d턴, %s",
+		u.InputTokens, u.OutputTokens, u.CacheCreationTokens, u.CacheReadTokens,
+		u.CostUSD, u.NumTurns, u.Duration().Round(time.Second))
+}
+
+// UsageGroupBy selects how analysis usage is summed
+type UsageGroupBy string
+
+const (
+	UsageByIssue   UsageGroupBy = "issue"   // 이슈 키별
+	UsageByChannel UsageGroupBy = "channel" // 채널 인덱스별
+	UsageByDay     UsageGroupBy = "day"     // 완료일(로컬 시간, YYYY-MM-DD)별
+)
+
+// UsageTotal is the summed usage of the analysis runs sharing a key
+type UsageTotal struct {
+	Key   string        `json:"key"` // 이슈 키, 채널 인덱스 또는 날짜
+	Runs  int           `json:"runs"`
+	Usage AnalysisUsage `json:"usage"`
+}
+
+// BudgetExceeded reports whether spent has reached a daily budget (budget이 0 이하면 제한 없음)
+func BudgetExceeded(spent, budget float64) bool {
+	return budget > 0 && spent >= budget
+}
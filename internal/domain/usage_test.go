@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestAnalysisUsage_Add(t *testing.T) {
+	total := AnalysisUsage{InputTokens: 10, CostUSD: 0.5, NumTurns: 1}
+	total.Add(AnalysisUsage{InputTokens: 5, OutputTokens: 3, CacheCreationTokens: 2, CacheReadTokens: 100, CostUSD: 0.25, DurationMS: 1500, NumTurns: 2})
+
+	want := AnalysisUsage{InputTokens: 15, OutputTokens: 3, CacheCreationTokens: 2, CacheReadTokens: 100, CostUSD: 0.75, DurationMS: 1500, NumTurns: 3}
+	if total != want {
+		t.Errorf("Add() = %+v, want %+v", total, want)
+	}
+	if got := total.TotalTokens(); got != 120 {
+		t.Errorf("TotalTokens() = %d, want 120", got)
+	}
+	if got := total.String(); got != "입력 15 · 출력 3 · 캐시 쓰기 2 · 캐시 읽기 100 토큰, $0.7500, 3턴, 2s" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	tests := []struct {
+		spent, budget float64
+		want          bool
+	}{
+		{spent: 10, budget: 0, want: false},
+		{spent: 4.99, budget: 5, want: false},
+		{spent: 5, budget: 5, want: true},
+		{spent: 7, budget: 5, want: true},
+	}
+	for _, tt := range tests {
+		if got := BudgetExceeded(tt.spent, tt.budget); got != tt.want {
+			t.Errorf("BudgetExceeded(%g, %g) = %v, want %v", tt.spent, tt.budget, got, tt.want)
+		}
+	}
+}
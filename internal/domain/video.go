@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frame extraction modes
+const (
+	FrameModeInterval = "interval" // interval초마다 추출 (최대 개수를 넘으면 전체 길이에 고르게 분산)
+	FrameModeScene    = "scene"    // 화면 전환 감지 + 부족하면 전체 길이에 고르게 채움
+)
+
+// FrameModes lists the selectable frame extraction modes
+var FrameModes = []string{FrameModeScene, FrameModeInterval}
+
+// FrameOptions controls how frames are extracted from video attachments
+type FrameOptions struct {
+	Mode           string  // FrameModeScene 또는 FrameModeInterval
+	Interval       float64 // interval 모드의 추출 간격 (초)
+	SceneThreshold float64 // scene 모드의 화면 전환 임계값 (0~1, 낮을수록 민감)
+	MaxFrames      int     // 동영상당 최대 프레임 수 (0이면 제한 없음)
+	Scale          int     // 프레임 가로 크기 (픽셀, 0이면 원본 크기)
+	ContactSheet   bool    // 추출한 프레임을 한 장의 타일 이미지로 합쳐 문서에 넣음
+	DedupeDistance int     // 직전에 남긴 프레임과 dHash 해밍 거리가 이 값 이하이면 버림 (0이면 사용 안 함)
+}
+
+// DefaultFrameOptions returns the options used when nothing is configured
+func DefaultFrameOptions() FrameOptions {
+	return FrameOptions{
+		Mode:           FrameModeScene,
+		Interval:       1.0,
+		SceneThreshold: 0.3,
+		MaxFrames:      10,
+		Scale:          640,
+		DedupeDistance: 4,
+	}
+}
+
+// Normalized fills invalid values with defaults
+func (o FrameOptions) Normalized() FrameOptions {
+	def := DefaultFrameOptions()
+	if o.Mode != FrameModeInterval && o.Mode != FrameModeScene {
+		o.Mode = def.Mode
+	}
+	if o.Interval <= 0 {
+		o.Interval = def.Interval
+	}
+	if o.SceneThreshold <= 0 || o.SceneThreshold >= 1 {
+		o.SceneThreshold = def.SceneThreshold
+	}
+	if o.MaxFrames < 0 {
+		o.MaxFrames = 0
+	}
+	if o.Scale < 0 {
+		o.Scale = 0
+	}
+	if o.DedupeDistance < 0 {
+		o.DedupeDistance = 0
+	}
+	return o
+}
+
+// String describes the options; 프레임 재사용 여부를 판단하는 키로도 쓴다
+func (o FrameOptions) String() string {
+	return fmt.Sprintf("mode=%s interval=%g threshold=%g max=%d scale=%d sheet=%t dedupe=%d",
+		o.Mode, o.Interval, o.SceneThreshold, o.MaxFrames, o.Scale, o.ContactSheet, o.DedupeDistance)
+}
+
+// VideoInfo is the metadata of a video attachment read with ffprobe
+type VideoInfo struct {
+	Duration time.Duration // 0이면 알 수 없음
+	Width    int
+	Height   int
+	Codec    string // 영상 코덱 (h264, hevc 등)
+	HasAudio bool
+}
+
+// String describes the video, e.g. "길이 01:23.5 · 1920x1080 · h264 · 오디오 있음"
+func (v VideoInfo) String() string {
+	var parts []string
+	if v.Duration > 0 {
+		parts = append(parts, "길이 "+FormatTimestamp(v.Duration))
+	}
+	if v.Width > 0 && v.Height > 0 {
+		parts = append(parts, fmt.Sprintf("%dx%d", v.Width, v.Height))
+	}
+	if v.Codec != "" {
+		parts = append(parts, v.Codec)
+	}
+	if v.HasAudio {
+		parts = append(parts, "오디오 있음")
+	} else {
+		parts = append(parts, "오디오 없음")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// frameFilenamePattern matches "<video>_frame_0001_12500ms.png" (타임스탬프가 없는 이전 형식과
+// 이미지 최적화로 JPEG로 바꾼 "….png.jpg"도 허용)
+var frameFilenamePattern = regexp.MustCompile(`^(.+)_frame_(\d+)(?:_(\d+)ms)?\.png(?:\.jpg)?$`)
+
+// TranscriptSegment is one timed line of speech recognized from a video's audio track
+type TranscriptSegment struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// ContactSheetFilename names the tiled image of all frames of a video
+func ContactSheetFilename(videoName string) string {
+	return videoName + "_contact_sheet.png"
+}
+
+// ParseContactSheetFilename returns the video name of a contact sheet filename
+func ParseContactSheetFilename(name string) (videoName string, ok bool) {
+	videoName = strings.TrimSuffix(strings.TrimSuffix(name, ".jpg"), "_contact_sheet.png")
+	return videoName, videoName != strings.TrimSuffix(name, ".jpg") && videoName != ""
+}
+
+// FrameFilename names the index-th (1-based) frame of a video captured at timestamp
+func FrameFilename(videoName string, index int, timestamp time.Duration) string {
+	return fmt.Sprintf("%s_frame_%04d_%dms.png", videoName, index, timestamp.Milliseconds())
+}
+
+// ParseFrameFilename extracts the video name and timestamp from a frame filename.
+// hasTimestamp는 타임스탬프가 없는 이전 형식(<video>_frame_0001.png)이면 false다.
+func ParseFrameFilename(name string) (videoName string, timestamp time.Duration, hasTimestamp, ok bool) {
+	m := frameFilenamePattern.FindStringSubmatch(name)
+	if m == nil {
+		return "", 0, false, false
+	}
+	if m[3] == "" {
+		return m[1], 0, false, true
+	}
+	ms, err := strconv.ParseInt(m[3], 10, 64)
+	if err != nil {
+		return m[1], 0, false, true
+	}
+	return m[1], time.Duration(ms) * time.Millisecond, true, true
+}
+
+// FormatTimestamp formats a video position as "01:02.5" (1시간 이상이면 "1:02:03.5")
+func FormatTimestamp(d time.Duration) string {
+	tenths := d.Round(100*time.Millisecond) / (100 * time.Millisecond)
+	h := tenths / 36000
+	m := tenths / 600 % 60
+	s := tenths % 600
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d.%d", h, m, s/10, s%10)
+	}
+	return fmt.Sprintf("%02d:%02d.%d", m, s/10, s%10)
+}
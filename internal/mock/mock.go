@@ -1,35 +1,97 @@
 package mock
 
-import "jira-ai-generator/internal/domain"
+import (
+	"context"
+	"io"
+	"time"
+
+	"jira-ai-generator/internal/domain"
+)
 
 // JiraRepository is a mock implementation of port.JiraRepository
 type JiraRepository struct {
-	GetIssueFunc           func(issueKey string) (*domain.JiraIssue, error)
-	DownloadAttachmentFunc func(url string) ([]byte, error)
+	GetIssueFunc           func(ctx context.Context, issueKey string) (*domain.JiraIssue, error)
+	GetIssueBriefFunc      func(ctx context.Context, issueKey string) (*domain.JiraIssue, error)
+	SearchIssuesFunc       func(ctx context.Context, jql string, startAt, maxResults int) (*domain.IssueSearchResult, error)
+	DownloadAttachmentFunc func(ctx context.Context, url string, dst io.Writer) (int64, error)
+	AddCommentFunc         func(ctx context.Context, issueKey, markdown string) (string, error)
+	UpdateCommentFunc      func(ctx context.Context, issueKey, commentID, markdown string) error
+	UploadAttachmentFunc   func(ctx context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error)
+	GetTransitionsFunc     func(ctx context.Context, issueKey string) ([]domain.Transition, error)
+	DoTransitionFunc       func(ctx context.Context, issueKey, transitionID string) error
 }
 
-func (m *JiraRepository) GetIssue(issueKey string) (*domain.JiraIssue, error) {
+func (m *JiraRepository) GetIssue(ctx context.Context, issueKey string) (*domain.JiraIssue, error) {
 	if m.GetIssueFunc != nil {
-		return m.GetIssueFunc(issueKey)
+		return m.GetIssueFunc(ctx, issueKey)
 	}
 	return nil, nil
 }
 
-func (m *JiraRepository) DownloadAttachment(url string) ([]byte, error) {
+func (m *JiraRepository) GetIssueBrief(ctx context.Context, issueKey string) (*domain.JiraIssue, error) {
+	if m.GetIssueBriefFunc != nil {
+		return m.GetIssueBriefFunc(ctx, issueKey)
+	}
+	return nil, nil
+}
+
+func (m *JiraRepository) SearchIssues(ctx context.Context, jql string, startAt, maxResults int) (*domain.IssueSearchResult, error) {
+	if m.SearchIssuesFunc != nil {
+		return m.SearchIssuesFunc(ctx, jql, startAt, maxResults)
+	}
+	return &domain.IssueSearchResult{StartAt: startAt, MaxResults: maxResults}, nil
+}
+
+func (m *JiraRepository) DownloadAttachment(ctx context.Context, url string, dst io.Writer) (int64, error) {
 	if m.DownloadAttachmentFunc != nil {
-		return m.DownloadAttachmentFunc(url)
+		return m.DownloadAttachmentFunc(ctx, url, dst)
+	}
+	return 0, nil
+}
+
+func (m *JiraRepository) AddComment(ctx context.Context, issueKey, markdown string) (string, error) {
+	if m.AddCommentFunc != nil {
+		return m.AddCommentFunc(ctx, issueKey, markdown)
+	}
+	return "", nil
+}
+
+func (m *JiraRepository) UpdateComment(ctx context.Context, issueKey, commentID, markdown string) error {
+	if m.UpdateCommentFunc != nil {
+		return m.UpdateCommentFunc(ctx, issueKey, commentID, markdown)
+	}
+	return nil
+}
+
+func (m *JiraRepository) UploadAttachment(ctx context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error) {
+	if m.UploadAttachmentFunc != nil {
+		return m.UploadAttachmentFunc(ctx, issueKey, filename, content)
+	}
+	return &domain.Attachment{Filename: filename}, nil
+}
+
+func (m *JiraRepository) GetTransitions(ctx context.Context, issueKey string) ([]domain.Transition, error) {
+	if m.GetTransitionsFunc != nil {
+		return m.GetTransitionsFunc(ctx, issueKey)
 	}
 	return nil, nil
 }
 
+func (m *JiraRepository) DoTransition(ctx context.Context, issueKey, transitionID string) error {
+	if m.DoTransitionFunc != nil {
+		return m.DoTransitionFunc(ctx, issueKey, transitionID)
+	}
+	return nil
+}
+
 // AttachmentDownloader is a mock implementation of port.AttachmentDownloader
 type AttachmentDownloader struct {
-	DownloadAllFunc func(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error)
+	DownloadAllFunc func(ctx context.Context, issueKey string, attachments []domain.Attachment, onProgress func(domain.DownloadProgress)) ([]domain.DownloadResult, error)
 }
 
-func (m *AttachmentDownloader) DownloadAll(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error) {
+func (m *AttachmentDownloader) DownloadAll(ctx context.Context, issueKey string, attachments []domain.Attachment, onProgress func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
 	if m.DownloadAllFunc != nil {
-		return m.DownloadAllFunc(issueKey, attachments)
+		return m.DownloadAllFunc(ctx, issueKey, attachments, onProgress)
 	}
 	return nil, nil
 }
@@ -37,7 +99,8 @@ func (m *AttachmentDownloader) DownloadAll(issueKey string, attachments []domain
 // VideoProcessor is a mock implementation of port.VideoProcessor
 type VideoProcessor struct {
 	IsAvailableFunc   func() bool
-	ExtractFramesFunc func(videoPath, outputDir string, interval float64, maxFrames int) ([]string, error)
+	ExtractFramesFunc func(ctx context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error)
+	ProbeFunc         func(ctx context.Context, videoPath string) (*domain.VideoInfo, error)
 }
 
 func (m *VideoProcessor) IsAvailable() bool {
@@ -47,9 +110,60 @@ func (m *VideoProcessor) IsAvailable() bool {
 	return false
 }
 
-func (m *VideoProcessor) ExtractFrames(videoPath, outputDir string, interval float64, maxFrames int) ([]string, error) {
+func (m *VideoProcessor) ExtractFrames(ctx context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error) {
 	if m.ExtractFramesFunc != nil {
-		return m.ExtractFramesFunc(videoPath, outputDir, interval, maxFrames)
+		return m.ExtractFramesFunc(ctx, videoPath, outputDir, opts)
+	}
+	return nil, nil
+}
+
+func (m *VideoProcessor) Probe(ctx context.Context, videoPath string) (*domain.VideoInfo, error) {
+	if m.ProbeFunc != nil {
+		return m.ProbeFunc(ctx, videoPath)
+	}
+	return nil, nil
+}
+
+// ImageProcessor is a mock implementation of port.ImageProcessor
+type ImageProcessor struct {
+	ProcessFunc func(ctx context.Context, imagePath string) (string, error)
+}
+
+func (m *ImageProcessor) Process(ctx context.Context, imagePath string) (string, error) {
+	if m.ProcessFunc != nil {
+		return m.ProcessFunc(ctx, imagePath)
+	}
+	return imagePath, nil
+}
+
+// Transcriber is a mock implementation of port.Transcriber
+type Transcriber struct {
+	IsAvailableFunc func() bool
+	TranscribeFunc  func(ctx context.Context, videoPath string) ([]domain.TranscriptSegment, error)
+}
+
+func (m *Transcriber) IsAvailable() bool {
+	if m.IsAvailableFunc != nil {
+		return m.IsAvailableFunc()
+	}
+	return false
+}
+
+func (m *Transcriber) Transcribe(ctx context.Context, videoPath string) ([]domain.TranscriptSegment, error) {
+	if m.TranscribeFunc != nil {
+		return m.TranscribeFunc(ctx, videoPath)
+	}
+	return nil, nil
+}
+
+// AttachmentReader is a mock implementation of port.AttachmentReader
+type AttachmentReader struct {
+	ReadFunc func(localPath, filename string, kind domain.AttachmentKind) (*domain.InlineAttachment, error)
+}
+
+func (m *AttachmentReader) Read(localPath, filename string, kind domain.AttachmentKind) (*domain.InlineAttachment, error) {
+	if m.ReadFunc != nil {
+		return m.ReadFunc(localPath, filename, kind)
 	}
 	return nil, nil
 }
@@ -94,3 +208,107 @@ func (m *Clipboard) SetContent(content string) {
 		m.SetContentFunc(content)
 	}
 }
+
+// AnalysisResultStore is a mock implementation of port.AnalysisResultStore
+type AnalysisResultStore struct {
+	CreateAnalysisResultFunc       func(result *domain.AnalysisResult) error
+	GetAnalysisResultFunc          func(issueID int64, phase int) (*domain.AnalysisResult, error)
+	UpdateAnalysisResultFunc       func(result *domain.AnalysisResult) error
+	ListAnalysisResultsByIssueFunc func(issueID int64) ([]*domain.AnalysisResult, error)
+	SumAnalysisUsageFunc           func(groupBy domain.UsageGroupBy, since time.Time) ([]*domain.UsageTotal, error)
+}
+
+func (m *AnalysisResultStore) CreateAnalysisResult(result *domain.AnalysisResult) error {
+	if m.CreateAnalysisResultFunc != nil {
+		return m.CreateAnalysisResultFunc(result)
+	}
+	return nil
+}
+
+func (m *AnalysisResultStore) GetAnalysisResult(issueID int64, phase int) (*domain.AnalysisResult, error) {
+	if m.GetAnalysisResultFunc != nil {
+		return m.GetAnalysisResultFunc(issueID, phase)
+	}
+	return nil, nil
+}
+
+func (m *AnalysisResultStore) UpdateAnalysisResult(result *domain.AnalysisResult) error {
+	if m.UpdateAnalysisResultFunc != nil {
+		return m.UpdateAnalysisResultFunc(result)
+	}
+	return nil
+}
+
+func (m *AnalysisResultStore) ListAnalysisResultsByIssue(issueID int64) ([]*domain.AnalysisResult, error) {
+	if m.ListAnalysisResultsByIssueFunc != nil {
+		return m.ListAnalysisResultsByIssueFunc(issueID)
+	}
+	return nil, nil
+}
+
+func (m *AnalysisResultStore) SumAnalysisUsage(groupBy domain.UsageGroupBy, since time.Time) ([]*domain.UsageTotal, error) {
+	if m.SumAnalysisUsageFunc != nil {
+		return m.SumAnalysisUsageFunc(groupBy, since)
+	}
+	return nil, nil
+}
+
+// AIAnalyzer is a mock implementation of port.AIAnalyzer
+type AIAnalyzer struct {
+	NameFunc         func() string
+	IsEnabledFunc    func() bool
+	GeneratePlanFunc func(mdFilePath, prompt, workDir string) (*domain.AIRun, error)
+	ExecutePlanFunc  func(planPath, workDir string) (*domain.AIRun, error)
+	ResumePlanFunc   func(planPath, sessionID, workDir string) (*domain.AIRun, error)
+	CancelFunc       func(run *domain.AIRun) error
+	RunStatusFunc    func(run *domain.AIRun) (*domain.AIRunStatus, error)
+}
+
+func (m *AIAnalyzer) Name() string {
+	if m.NameFunc != nil {
+		return m.NameFunc()
+	}
+	return ""
+}
+
+func (m *AIAnalyzer) IsEnabled() bool {
+	if m.IsEnabledFunc != nil {
+		return m.IsEnabledFunc()
+	}
+	return false
+}
+
+func (m *AIAnalyzer) GeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error) {
+	if m.GeneratePlanFunc != nil {
+		return m.GeneratePlanFunc(mdFilePath, prompt, workDir)
+	}
+	return nil, nil
+}
+
+func (m *AIAnalyzer) ExecutePlan(planPath, workDir string) (*domain.AIRun, error) {
+	if m.ExecutePlanFunc != nil {
+		return m.ExecutePlanFunc(planPath, workDir)
+	}
+	return nil, nil
+}
+
+func (m *AIAnalyzer) ResumePlan(planPath, sessionID, workDir string) (*domain.AIRun, error) {
+	if m.ResumePlanFunc != nil {
+		return m.ResumePlanFunc(planPath, sessionID, workDir)
+	}
+	return nil, nil
+}
+
+func (m *AIAnalyzer) Cancel(run *domain.AIRun) error {
+	if m.CancelFunc != nil {
+		return m.CancelFunc(run)
+	}
+	return nil
+}
+
+func (m *AIAnalyzer) RunStatus(run *domain.AIRun) (*domain.AIRunStatus, error) {
+	if m.RunStatusFunc != nil {
+		return m.RunStatusFunc(run)
+	}
+	return nil, nil
+}
@@ -1,27 +1,96 @@
 package port
 
-import "jira-ai-generator/internal/domain"
+import (
+	"context"
+	"io"
+	"time"
 
-// JiraRepository defines the interface for Jira operations
+	"jira-ai-generator/internal/domain"
+)
+
+// JiraRepository defines the interface for Jira operations.
+// 모든 메서드는 ctx가 취소되면 진행 중인 요청을 중단하고 ctx.Err()를 감싼 에러를 반환한다.
 type JiraRepository interface {
 	// GetIssue fetches a Jira issue by its key
-	GetIssue(issueKey string) (*domain.JiraIssue, error)
-	// DownloadAttachment downloads an attachment and returns its data
-	DownloadAttachment(url string) ([]byte, error)
+	GetIssue(ctx context.Context, issueKey string) (*domain.JiraIssue, error)
+	// GetIssueBrief fetches only the key, summary and description of an issue (no comments, attachments or links)
+	GetIssueBrief(ctx context.Context, issueKey string) (*domain.JiraIssue, error)
+	// SearchIssues fetches a single page of issues matching the JQL query
+	SearchIssues(ctx context.Context, jql string, startAt, maxResults int) (*domain.IssueSearchResult, error)
+	// DownloadAttachment streams an attachment into dst and returns the number of bytes written
+	DownloadAttachment(ctx context.Context, url string, dst io.Writer) (int64, error)
+	// AddComment posts a Markdown comment to the issue and returns the created comment ID
+	AddComment(ctx context.Context, issueKey, markdown string) (string, error)
+	// UpdateComment replaces the body of an existing comment
+	UpdateComment(ctx context.Context, issueKey, commentID, markdown string) error
+	// UploadAttachment uploads a file to the issue and returns the created attachment
+	UploadAttachment(ctx context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error)
+	// GetTransitions lists the workflow transitions currently available for the issue
+	GetTransitions(ctx context.Context, issueKey string) ([]domain.Transition, error)
+	// DoTransition moves the issue through the given workflow transition
+	DoTransition(ctx context.Context, issueKey, transitionID string) error
 }
 
 // AttachmentDownloader defines the interface for downloading attachments
 type AttachmentDownloader interface {
-	// DownloadAll downloads all media attachments for an issue
-	DownloadAll(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error)
+	// DownloadAll downloads all media attachments for an issue.
+	// ctx가 취소되면 중단하고, 쓰다 만 파일은 남기지 않는다.
+	// onProgress는 nil일 수 있으며, 여러 파일을 병렬로 받더라도 한 번에 하나씩 호출된다.
+	DownloadAll(ctx context.Context, issueKey string, attachments []domain.Attachment, onProgress func(domain.DownloadProgress)) ([]domain.DownloadResult, error)
 }
 
 // VideoProcessor defines the interface for video processing
 type VideoProcessor interface {
 	// IsAvailable checks if video processing is available
 	IsAvailable() bool
-	// ExtractFrames extracts frames from a video file
-	ExtractFrames(videoPath, outputDir string, interval float64, maxFrames int) ([]string, error)
+	// ExtractFrames extracts frames from a video file and returns their paths in time order
+	// (opts.ContactSheet이면 마지막에 모든 프레임을 합친 타일 이미지 경로가 추가됨)
+	ExtractFrames(ctx context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error)
+	// Probe reads the duration, resolution, codec and audio presence of a video file
+	Probe(ctx context.Context, videoPath string) (*domain.VideoInfo, error)
+}
+
+// ImageProcessor prepares screenshots and video frames before they are referenced in documents
+type ImageProcessor interface {
+	// Process returns the path of a downscaled/compressed copy without metadata, or imagePath if nothing changed.
+	// 원본 파일은 수정하지 않는다.
+	Process(ctx context.Context, imagePath string) (string, error)
+}
+
+// Transcriber converts the narration of a video into timed text
+type Transcriber interface {
+	// IsAvailable checks if transcription is configured and its tools are installed
+	IsAvailable() bool
+	// Transcribe extracts the audio track of a video and returns the recognized speech in time order
+	Transcribe(ctx context.Context, videoPath string) ([]domain.TranscriptSegment, error)
+}
+
+// AIAnalyzer defines the interface for an AI coding agent that turns an issue document into a plan
+// and applies the plan to a project. 실행은 백그라운드로 시작하고 곧바로 반환하며,
+// 진행 상태와 결과는 반환된 AIRun의 상태 파일과 결과 파일로 확인한다.
+type AIAnalyzer interface {
+	// Name returns the backend name (domain.AIBackendClaude 등)
+	Name() string
+	// IsEnabled reports whether the backend is configured to start runs
+	IsEnabled() bool
+	// GeneratePlan starts a read-only analysis of the issue document and writes "<base>_plan.md" when it finishes
+	GeneratePlan(mdFilePath, prompt, workDir string) (*domain.AIRun, error)
+	// ExecutePlan starts applying a plan file in workDir and writes "<base>_execution.md" when it finishes
+	ExecutePlan(planPath, workDir string) (*domain.AIRun, error)
+	// ResumePlan continues the plan-generation session sessionID so the agent applies the plan it wrote
+	// without re-reading it. 세션을 이어갈 수 없는 백엔드는 domain.ErrSessionUnsupported를 반환하고,
+	// 세션이 없거나 만료되면 실행이 실패하며 상태 파일에 session_expired가 기록된다.
+	ResumePlan(planPath, sessionID, workDir string) (*domain.AIRun, error)
+	// Cancel stops a run that is still in progress (이미 끝난 실행이면 아무것도 하지 않음)
+	Cancel(run *domain.AIRun) error
+	// RunStatus returns the current state of a run (상태를 아직 읽을 수 없으면 에러)
+	RunStatus(run *domain.AIRun) (*domain.AIRunStatus, error)
+}
+
+// AttachmentReader reads downloaded text attachments and archives so they can be embedded in documents
+type AttachmentReader interface {
+	// Read loads the (truncated) content of a text file, or the listing and text files of a zip archive
+	Read(localPath, filename string, kind domain.AttachmentKind) (*domain.InlineAttachment, error)
 }
 
 // DocumentGenerator defines the interface for document generation
@@ -61,6 +130,7 @@ type AnalysisResultStore interface {
 	GetAnalysisResult(issueID int64, phase int) (*domain.AnalysisResult, error)
 	UpdateAnalysisResult(result *domain.AnalysisResult) error
 	ListAnalysisResultsByIssue(issueID int64) ([]*domain.AnalysisResult, error)
+	SumAnalysisUsage(groupBy domain.UsageGroupBy, since time.Time) ([]*domain.UsageTotal, error)
 }
 
 // AttachmentStore defines the interface for persisting attachment records
@@ -12,6 +12,7 @@ import (
 
 	"jira-ai-generator/internal/adapter"
 	"jira-ai-generator/internal/config"
+	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/port"
 	"jira-ai-generator/internal/usecase"
 )
@@ -25,8 +26,20 @@ type App struct {
 
 	// Use cases
 	processIssueUC *usecase.ProcessIssueUseCase
+	jqlImportUC    *usecase.JQLImportUseCase
+	postCommentUC  *usecase.PostCommentUseCase
+	attachResultUC *usecase.AttachResultUseCase
+	transitionUC   *usecase.TransitionIssueUseCase
+	jiraClient     *adapter.JiraClient
+	downloader     *adapter.AttachmentDownloader
+	textReader     *adapter.TextAttachmentReader
+	transcriber    *adapter.CommandTranscriber
+	imageProcessor *adapter.ImagePreprocessor
 	docGenerator   *adapter.MarkdownGenerator
 	claudeAdapter  *adapter.ClaudeCodeAdapter
+	agentAdapter   *adapter.CommandAgentAdapter
+	analyzerMu     sync.RWMutex
+	aiAnalyzer     port.AIAnalyzer // 분석/실행 백엔드 ([ai] backend에 따라 claudeAdapter 또는 agentAdapter, analyzerMu로 보호)
 
 	// Database stores
 	issueStore      port.IssueStore
@@ -50,6 +63,7 @@ type App struct {
 	// V2 실행 작업 추적
 	runningTasksMu sync.Mutex
 	runningTasks   [3]map[string]*RunningTask
+	phase1Runs     [3]*phase1Run // 채널별 진행 중인 1차 처리 (runningTasksMu로 보호)
 
 	// 채널별 이슈 목록 로딩 요청 추적 (최신 요청만 UI 반영)
 	issueListLoadMu  sync.Mutex
@@ -70,11 +84,52 @@ type RunningTask struct {
 	ChannelIndex    int
 	PhaseLabel      string
 	PID             int
-	ScriptPath      string
-	LogPath         string
+	StatusPath      string          // 실행 상태 파일 (Analyzer.RunStatus로 읽음)
+	LogPath         string          // AI CLI stderr 파일
+	Analyzer        port.AIAnalyzer // 실행을 시작한 백엔드
+	Cancel          func() error    // 실행을 시작한 백엔드로 프로세스 종료 (nil이면 PID에 SIGTERM)
 	CancelRequested bool
 }
 
+// toDomainCustomFields converts configured custom field mappings for the Jira client
+func toDomainCustomFields(fields []config.CustomField) []domain.CustomField {
+	result := make([]domain.CustomField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, domain.CustomField{ID: field.ID, Name: field.Name})
+	}
+	return result
+}
+
+// jiraRetryPolicy applies the configured retry count and concurrency limit to the default policy
+func jiraRetryPolicy(cfg config.JiraConfig) adapter.RetryPolicy {
+	policy := adapter.DefaultRetryPolicy()
+	policy.MaxRetries = cfg.MaxRetries
+	policy.MaxConcurrentPerHost = cfg.MaxConcurrency
+	return policy
+}
+
+// frameOptions converts the [video] settings into frame extraction options
+func frameOptions(cfg config.VideoConfig) domain.FrameOptions {
+	return domain.FrameOptions{
+		Mode:           cfg.FrameMode,
+		Interval:       cfg.FrameInterval,
+		SceneThreshold: cfg.SceneThreshold,
+		MaxFrames:      cfg.MaxFrames,
+		Scale:          cfg.FrameWidth,
+		ContactSheet:   cfg.ContactSheet,
+		DedupeDistance: cfg.DedupeDistance,
+	}.Normalized()
+}
+
+// imageOptions converts the [image] settings into image preprocessing options
+func imageOptions(cfg config.ImageConfig) adapter.ImageOptions {
+	return adapter.ImageOptions{
+		MaxDimension:    cfg.MaxDimension,
+		ConvertMinBytes: int64(cfg.ConvertPNGKB) << 10,
+		JPEGQuality:     cfg.JPEGQuality,
+	}
+}
+
 // NewApp creates a new application instance with dependency injection
 func NewApp(cfg *config.Config) (*App, error) {
 	fyneApp := app.New()
@@ -89,20 +144,56 @@ func NewApp(cfg *config.Config) (*App, error) {
 
 	// Create adapters
 	jiraClient := adapter.NewJiraClient(cfg.Jira.URL, cfg.Jira.Email, cfg.Jira.APIKey)
+	jiraClient.SetAuthMode(adapter.AuthMode(cfg.Jira.AuthMode))
+	jiraClient.SetAPIVersion(cfg.Jira.APIVersion)
+	jiraClient.SetCustomFields(toDomainCustomFields(cfg.CustomFields))
+	jiraClient.SetRetryPolicy(jiraRetryPolicy(cfg.Jira))
 	docGenerator := adapter.NewMarkdownGenerator(cfg.AI.PromptTemplate)
+	docGenerator.SetCommentOptions(adapter.CommentOptions{
+		Limit:       cfg.Output.CommentLimit,
+		ExcludeBots: cfg.Output.ExcludeBotComments,
+		BotAuthors:  cfg.Output.BotCommentAuthors,
+	})
 	claudeAdapter := adapter.NewClaudeCodeAdapter(cfg.Claude.CLIPath, cfg.Claude.Enabled, cfg.Claude.Model, cfg.Claude.HookScriptPath)
+	claudeAdapter.SetOutputFormat(cfg.Claude.OutputFormat)
+	agentAdapter := adapter.NewCommandAgentAdapter(cfg.AI.AgentCommand)
 	videoProcessor := adapter.NewFFmpegVideoProcessor()
 	downloader := adapter.NewAttachmentDownloader(jiraClient, cfg.Output.Dir)
+	downloader.SetMaxSize(int64(cfg.Output.MaxAttachmentMB) << 20)
+	downloader.SetWorkers(cfg.Output.DownloadWorkers)
+	downloader.SetCache(adapter.NewAttachmentCache(filepath.Join(cfg.Output.Dir, ".cache", "attachments")))
 
 	// Create use cases
 	processIssueUC := usecase.NewProcessIssueUseCase(jiraClient, downloader, videoProcessor, docGenerator, cfg.Output.Dir)
+	processIssueUC.SetFetchRelatedDescriptions(cfg.Jira.FetchRelated)
+	processIssueUC.SetFrameOptions(frameOptions(cfg.Video))
+	textReader := adapter.NewTextAttachmentReader(int64(cfg.Output.MaxInlineKB) << 10)
+	processIssueUC.SetAttachmentReader(textReader)
+	transcriber := adapter.NewCommandTranscriber(cfg.Video.TranscribeCommand)
+	processIssueUC.SetTranscriber(transcriber)
+	imageProcessor := adapter.NewImagePreprocessor(imageOptions(cfg.Image))
+	processIssueUC.SetImageProcessor(imageProcessor)
+	jqlImportUC := usecase.NewJQLImportUseCase(jiraClient, processIssueUC)
+	postCommentUC := usecase.NewPostCommentUseCase(jiraClient, repo)
+	attachResultUC := usecase.NewAttachResultUseCase(jiraClient)
+	transitionUC := usecase.NewTransitionIssueUseCase(jiraClient)
 
 	appInstance := &App{
 		fyneApp:         fyneApp,
 		config:          cfg,
 		processIssueUC:  processIssueUC,
+		jqlImportUC:     jqlImportUC,
+		postCommentUC:   postCommentUC,
+		attachResultUC:  attachResultUC,
+		transitionUC:    transitionUC,
+		jiraClient:      jiraClient,
+		downloader:      downloader,
+		textReader:      textReader,
+		transcriber:     transcriber,
+		imageProcessor:  imageProcessor,
 		docGenerator:    docGenerator,
 		claudeAdapter:   claudeAdapter,
+		agentAdapter:    agentAdapter,
 		issueStore:      repo,
 		analysisStore:   repo,
 		attachmentStore: repo,
@@ -122,10 +213,41 @@ func NewApp(cfg *config.Config) (*App, error) {
 	for i := 0; i < 3; i++ {
 		appInstance.runningTasks[i] = make(map[string]*RunningTask)
 	}
+	appInstance.selectAIAnalyzer(cfg.AI.Backend)
+
+	// 이전 실행에서 AI 작업 도중 앱이 종료되었으면 남은 출력으로 결과 파일과 상태를 마무리한다
+	for _, statusPath := range adapter.RecoverAgentRuns(cfg.Output.Dir) {
+		fmt.Printf("[App] 중단된 AI 실행을 마무리했습니다: %s\n", statusPath)
+	}
 
 	return appInstance, nil
 }
 
+// selectAIAnalyzer sets the analysis/execution backend by name (알 수 없는 이름이면 claude)
+func (a *App) selectAIAnalyzer(backend string) {
+	a.analyzerMu.Lock()
+	defer a.analyzerMu.Unlock()
+	if backend == domain.AIBackendCLI {
+		a.aiAnalyzer = a.agentAdapter
+		return
+	}
+	a.aiAnalyzer = a.claudeAdapter
+}
+
+// analyzer returns the currently selected analysis/execution backend
+func (a *App) analyzer() port.AIAnalyzer {
+	a.analyzerMu.RLock()
+	defer a.analyzerMu.RUnlock()
+	return a.aiAnalyzer
+}
+
+// cancelFunc returns a function that stops run through the backend that started it
+func cancelFunc(analyzer port.AIAnalyzer, run *domain.AIRun) func() error {
+	return func() error {
+		return analyzer.Cancel(run)
+	}
+}
+
 // UseV2UI returns whether V2 UI is enabled
 func (a *App) UseV2UI() bool {
 	return a.useV2UI
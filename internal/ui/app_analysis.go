@@ -166,7 +166,7 @@ func (a *App) loadJobResultToChannel(job *AnalysisJob) {
 		ch.AnalysisText.SetText(string(content))
 	}
 	ch.CurrentAnalysisPath = analysisPath
-	ch.CurrentScriptPath = job.ScriptPath
+	ch.CurrentStatusPath = job.StatusPath
 	if job.PlanPath != "" {
 		ch.CurrentPlanPath = job.PlanPath
 	}
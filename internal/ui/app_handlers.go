@@ -39,7 +39,7 @@ func (a *App) onChannelProcess(channelIndex int) {
 	ch.CurrentMDPath = ""
 	ch.CurrentAnalysisPath = ""
 	ch.CurrentPlanPath = ""
-	ch.CurrentScriptPath = ""
+	ch.CurrentStatusPath = ""
 
 	go a.processIssue(issueKey, channelIndex)
 }
@@ -52,8 +52,11 @@ func (a *App) processIssue(issueKey string, channelIndex int) {
 		ch.ProgressBar.Hide()
 	}()
 
+	ctx, done := a.beginPhase1Run(channelIndex)
+	defer done()
+
 	// Use UseCase to process the issue
-	result, err := a.processIssueUC.Execute(issueKey, func(progress float64, status string) {
+	result, err := a.processIssueUC.Execute(ctx, issueKey, func(progress float64, status string) {
 		ch.ProgressBar.SetValue(progress)
 		ch.StatusLabel.SetText(status)
 	})
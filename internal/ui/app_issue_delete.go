@@ -56,7 +56,7 @@ func (a *App) handleIssueDeleteRequestV2(channelIndex int, payload map[string]in
 				ch.CurrentMDPath = ""
 				ch.CurrentAnalysisPath = ""
 				ch.CurrentPlanPath = ""
-				ch.CurrentScriptPath = ""
+				ch.CurrentStatusPath = ""
 				v2.resultPanels[channel].Reset()
 			}
 
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/ui/state"
+)
+
+// autoAttachResultV2는 설정이 켜져 있으면 2차/3차 결과 파일을 Jira 첨부파일로 업로드한다.
+// 업로드 실패는 로그로만 남기고 분석 결과에는 영향을 주지 않는다.
+func (a *App) autoAttachResultV2(channelIndex int, issueKey, path string, v2 *AppV2State) {
+	if a == nil || a.config == nil || !a.config.Jira.AutoAttachResults || a.attachResultUC == nil {
+		return
+	}
+	if path == "" {
+		return
+	}
+
+	attachment, err := a.attachResultUC.Execute(context.Background(), issueKey, path)
+	if err != nil {
+		logger.Debug("autoAttachResultV2: upload failed, issueKey=%s, path=%s, err=%v", issueKey, path, err)
+		if v2 != nil {
+			v2.appState.AddLog(channelIndex, state.LogWarning, fmt.Sprintf("Jira 첨부 실패: %s (%v)", issueKey, err), "App")
+		}
+		return
+	}
+
+	logger.Debug("autoAttachResultV2: uploaded, issueKey=%s, attachmentID=%s", issueKey, attachment.ID)
+	if v2 != nil {
+		v2.appState.AddLog(channelIndex, state.LogInfo, fmt.Sprintf("Jira 첨부 완료: %s ← %s", issueKey, filepath.Base(path)), "App")
+	}
+}
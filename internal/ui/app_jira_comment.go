@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/ui/state"
+	"jira-ai-generator/internal/usecase"
+)
+
+// handleJiraCommentRequestV2는 분석 선택기에서 발생한 Jira 코멘트 게시 요청을 처리한다.
+// 2차 목록(listPhase=2)은 AI 플랜을, 3차 목록(listPhase=3)은 AI 실행 결과를 게시한다.
+func (a *App) handleJiraCommentRequestV2(channelIndex int, payload map[string]interface{}, v2 *AppV2State) {
+	if a == nil || v2 == nil || a.postCommentUC == nil {
+		return
+	}
+	if channelIndex < 0 || channelIndex >= 3 {
+		return
+	}
+	if payload == nil {
+		return
+	}
+
+	record, ok := payload["issueRecord"].(*domain.IssueRecord)
+	if !ok || record == nil {
+		return
+	}
+
+	analysisPhase := usecase.AnalysisPhasePlan
+	label := "AI 플랜"
+	if listPhase, _ := payload["listPhase"].(int); listPhase == 3 {
+		analysisPhase = usecase.AnalysisPhaseExecution
+		label = "AI 실행 결과"
+	}
+
+	v2.appState.AddLog(channelIndex, state.LogInfo, fmt.Sprintf("Jira 코멘트 게시 중: %s (%s)", record.IssueKey, label), "App")
+
+	go func(issue *domain.IssueRecord, channel int) {
+		result, err := a.postCommentUC.Execute(context.Background(), issue, analysisPhase)
+		if err != nil {
+			logger.Debug("handleJiraCommentRequestV2: post failed, issueKey=%s, channel=%d, err=%v", issue.IssueKey, channel, err)
+			fyne.Do(func() {
+				v2.appState.AddLog(channel, state.LogError, fmt.Sprintf("Jira 코멘트 게시 실패: %s (%v)", issue.IssueKey, err), "App")
+				a.channels[channel].StatusLabel.SetText(fmt.Sprintf("코멘트 게시 실패: %s", issue.IssueKey))
+			})
+			return
+		}
+
+		action := "게시"
+		if result.Updated {
+			action = "갱신"
+		}
+		fyne.Do(func() {
+			logger.Debug("handleJiraCommentRequestV2: %s success, issueKey=%s, commentID=%s", action, issue.IssueKey, result.CommentID)
+			v2.appState.AddLog(channel, state.LogInfo, fmt.Sprintf("Jira 코멘트 %s 완료: %s (ID %s)", action, issue.IssueKey, result.CommentID), "App")
+			a.channels[channel].StatusLabel.SetText(fmt.Sprintf("💬 %s %s 코멘트 %s 완료", issue.IssueKey, label, action))
+			v2.statusBar.SetRecentActivity(fmt.Sprintf("💬 %s 코멘트 %s", issue.IssueKey, action))
+		})
+	}(record, channelIndex)
+}
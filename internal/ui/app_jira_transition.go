@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/ui/state"
+)
+
+// transitionIssueForPhaseV2는 단계 완료 시 설정된 Jira 워크플로 전환을 실행한다.
+// 전환이 없거나 권한이 없어 실패해도 경고 로그만 남기고 분석 흐름은 계속 진행한다.
+func (a *App) transitionIssueForPhaseV2(channelIndex int, record *domain.IssueRecord, phase int, v2 *AppV2State) {
+	if a == nil || a.config == nil || a.transitionUC == nil || record == nil {
+		return
+	}
+	transitionName := strings.TrimSpace(a.config.Jira.PhaseTransitions[phase])
+	if transitionName == "" {
+		return
+	}
+
+	transition, err := a.transitionUC.Execute(context.Background(), record.IssueKey, transitionName)
+	if err != nil {
+		logger.Debug("transitionIssueForPhaseV2: transition failed, issueKey=%s, phase=%d, name=%s, err=%v", record.IssueKey, phase, transitionName, err)
+		if v2 != nil {
+			v2.appState.AddLog(channelIndex, state.LogWarning, fmt.Sprintf("Jira 상태 전환 실패: %s → %s (%v)", record.IssueKey, transitionName, err), "App")
+		}
+		return
+	}
+
+	logger.Debug("transitionIssueForPhaseV2: transitioned, issueKey=%s, transitionID=%s", record.IssueKey, transition.ID)
+	if v2 != nil {
+		v2.appState.AddLog(channelIndex, state.LogInfo, fmt.Sprintf("Jira 상태 전환 완료: %s → %s", record.IssueKey, transition.Name), "App")
+	}
+}
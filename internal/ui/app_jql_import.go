@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/ui/state"
+	"jira-ai-generator/internal/usecase"
+)
+
+// defaultJQLImportMax는 JQL 가져오기 기본 최대 이슈 수다.
+const defaultJQLImportMax = 50
+
+// showJQLImportDialogV2는 JQL 입력 대화상자를 표시하고 확인 시 일괄 가져오기를 시작한다.
+func (a *App) showJQLImportDialogV2(v2 *AppV2State) {
+	if a == nil || v2 == nil || a.jqlImportUC == nil {
+		return
+	}
+
+	jqlEntry := widget.NewMultiLineEntry()
+	jqlEntry.SetPlaceHolder("예: sprint in openSprints() AND labels = ai-candidate")
+	jqlEntry.SetMinRowsVisible(3)
+
+	maxEntry := widget.NewEntry()
+	maxEntry.SetText(strconv.Itoa(defaultJQLImportMax))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("JQL", jqlEntry),
+		widget.NewFormItem("최대 개수", maxEntry),
+	}
+
+	formDialog := dialog.NewForm("📥 JQL 가져오기", "가져오기", "취소", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		jql := strings.TrimSpace(jqlEntry.Text)
+		if jql == "" {
+			dialog.ShowError(fmt.Errorf("JQL을 입력해주세요"), a.mainWindow)
+			return
+		}
+
+		maxIssues, err := strconv.Atoi(strings.TrimSpace(maxEntry.Text))
+		if err != nil || maxIssues <= 0 {
+			dialog.ShowError(fmt.Errorf("최대 개수는 1 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+
+		a.runJQLImportV2(jql, maxIssues, v2)
+	}, a.mainWindow)
+	formDialog.Resize(fyne.NewSize(600, 260))
+	formDialog.Show()
+}
+
+// runJQLImportV2는 JQL 검색 결과를 3개 채널에 분배하여 1차 분석을 실행하고 DB에 저장한다.
+func (a *App) runJQLImportV2(jql string, maxIssues int, v2 *AppV2State) {
+	channelCount := len(a.channels)
+	for i := 0; i < channelCount; i++ {
+		v2.progressPanels[i].Reset()
+	}
+	v2.appState.AddLog(v2.appState.ActiveChannel, state.LogInfo, "JQL 가져오기 시작: "+jql, "App")
+	v2.statusBar.SetRecentActivity("📥 JQL 검색 중...")
+
+	go func() {
+		onProgress := func(channelIndex int, issueKey string, progress float64, status string) {
+			fyne.Do(func() {
+				v2.progressPanels[channelIndex].SetProgress(progress, fmt.Sprintf("[%s] %s", issueKey, status))
+			})
+		}
+
+		onItem := func(item usecase.JQLImportItem) {
+			channel := item.ChannelIndex
+			issueKey := item.Issue.Key
+
+			if item.Err != nil || item.Result == nil || !item.Result.Success || item.Result.Document == nil {
+				errMsg := "알 수 없는 오류"
+				if item.Err != nil {
+					errMsg = item.Err.Error()
+				} else if item.Result != nil && item.Result.ErrorMessage != "" {
+					errMsg = item.Result.ErrorMessage
+				}
+				logger.Debug("runJQLImportV2: process failed, issueKey=%s, channel=%d, err=%s", issueKey, channel, errMsg)
+				fyne.Do(func() {
+					v2.appState.AddLog(channel, state.LogError, fmt.Sprintf("가져오기 실패: %s (%s)", issueKey, errMsg), "App")
+				})
+				return
+			}
+
+			doc := item.Result.Document
+			mdPath := item.Result.MDPath
+			fyne.Do(func() {
+				savedIssue, err := v2.appState.SaveIssueToDBAfterPhase1(channel, doc.IssueKey, doc.Title, doc.Content, item.Issue.Link, mdPath)
+				if err != nil {
+					logger.Debug("runJQLImportV2: DB save error: %v", err)
+					v2.appState.AddLog(channel, state.LogError, "DB 저장 실패: "+doc.IssueKey, "App")
+					return
+				}
+				if savedIssue != nil {
+					a.recordAttachments(savedIssue.ID, item.Result.Attachments)
+					v2.sidebar.AddHistoryItem(buildHistoryID(channel, savedIssue.ID), doc.IssueKey, "완료", "")
+				}
+				v2.appState.AddLog(channel, state.LogInfo, "가져오기 완료: "+doc.IssueKey, "App")
+				a.refreshIssueListsForChannel(channel, 1, v2)
+			})
+		}
+
+		// 가져오기는 여러 채널에 걸쳐 실행되므로 어느 채널의 중지 버튼으로도 전체를 취소한다
+		ctx, done := a.beginPhase1Run(channelIndices(channelCount)...)
+		defer done()
+
+		items, err := a.jqlImportUC.Execute(ctx, jql, maxIssues, channelCount, onProgress, onItem)
+		if usecase.IsCancelled(err) {
+			logger.Debug("runJQLImportV2: cancelled, processed=%d", len(items))
+			fyne.Do(func() {
+				v2.statusBar.SetRecentActivity(fmt.Sprintf("⏹ JQL 가져오기 중지됨 (%d개 처리)", len(items)))
+			})
+			return
+		}
+		if err != nil {
+			logger.Debug("runJQLImportV2: search error: %v", err)
+			fyne.Do(func() {
+				v2.statusBar.SetGlobalStatus("JQL 가져오기 실패", true)
+				dialog.ShowError(fmt.Errorf("JQL 가져오기 실패: %w", err), a.mainWindow)
+			})
+			return
+		}
+
+		succeeded := 0
+		for _, item := range items {
+			if item.Err == nil && item.Result != nil && item.Result.Success {
+				succeeded++
+			}
+		}
+
+		fyne.Do(func() {
+			for i := 0; i < channelCount; i++ {
+				v2.progressPanels[i].SetComplete()
+			}
+			v2.statusBar.SetRecentActivity(fmt.Sprintf("📥 JQL 가져오기 완료 (%d/%d)", succeeded, len(items)))
+			dialog.ShowInformation("JQL 가져오기", fmt.Sprintf("%d개 중 %d개 이슈를 가져왔습니다.", len(items), succeeded), a.mainWindow)
+		})
+	}()
+}
+
+// channelIndices는 0부터 count-1까지의 채널 인덱스 목록을 반환한다.
+func channelIndices(count int) []int {
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
@@ -3,9 +3,7 @@ package ui
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +13,8 @@ import (
 
 	"jira-ai-generator/internal/adapter"
 	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/port"
 	"jira-ai-generator/internal/ui/state"
 )
 
@@ -44,17 +44,17 @@ type ChannelState struct {
 	CurrentMDPath       string
 	CurrentAnalysisPath string
 	CurrentPlanPath     string
-	CurrentScriptPath   string
+	CurrentStatusPath   string
 }
 
 // AnalysisJob represents a running analysis task
 type AnalysisJob struct {
 	IssueKey        string
-	ScriptPath      string
+	StatusPath      string // 실행 상태 파일 (Analyzer.RunStatus로 읽음) 경로
 	AnalysisPath    string
 	PlanPath        string // Phase 1 결과: _plan.md 경로
 	ExecutionPath   string // Phase 2 결과: _execution.md 경로
-	LogPath         string // 로그 파일 경로
+	LogPath         string // Claude stderr 파일 경로
 	MDPath          string
 	StartTime       string
 	PID             int
@@ -62,6 +62,7 @@ type AnalysisJob struct {
 	ChannelIndex    int                   // 실행된 채널 인덱스
 	CancelRequested bool                  // 사용자 중단 요청 여부
 	Outcome         QueueJobOutcome       // 마지막 실행 결과
+	Analyzer        port.AIAnalyzer       // 실행을 시작한 백엔드
 }
 
 // QueueJobOutcome은 큐 작업의 최종 실행 결과를 나타낸다.
@@ -142,7 +143,7 @@ func (a *App) addToQueue(channelIndex int) {
 		MDPath:       ch.CurrentMDPath,
 		PlanPath:     strings.TrimSuffix(ch.CurrentMDPath, ".md") + "_plan.md",
 		AnalysisPath: strings.TrimSuffix(ch.CurrentMDPath, ".md") + "_plan.md",
-		ScriptPath:   strings.TrimSuffix(ch.CurrentMDPath, ".md") + "_plan_run.sh",
+		StatusPath:   strings.TrimSuffix(ch.CurrentMDPath, ".md") + "_plan_status.json",
 		Phase:        adapter.PhaseAnalyze,
 		ChannelIndex: channelIndex,
 	}
@@ -172,6 +173,11 @@ func (a *App) stopQueueCurrent(channelIndex int) {
 		stopped++
 	}
 
+	// 진행 중인 1차 처리(이슈 조회·다운로드) 취소
+	if a.cancelPhase1Run(channelIndex) {
+		stopped++
+	}
+
 	if queue.Current == nil {
 		if stopped > 0 {
 			ch.StatusLabel.SetText(fmt.Sprintf("채널 %d 중지 요청됨 (%d개 실행)", channelIndex+1, stopped))
@@ -182,21 +188,23 @@ func (a *App) stopQueueCurrent(channelIndex int) {
 	// 현재 작업에 중단 요청 상태를 기록한다.
 	queue.Current.CancelRequested = true
 
-	// PID 기반 종료를 우선하고, 실패 시에만 pkill -f fallback 사용
-	if queue.Current.PID > 0 {
-		exec.Command("kill", "-TERM", strconv.Itoa(queue.Current.PID)).Run()
-	} else if queue.Current.ScriptPath != "" {
-		cleaned := filepath.Clean(queue.Current.ScriptPath)
-		if filepath.IsAbs(cleaned) {
-			exec.Command("pkill", "-f", cleaned).Run()
-		}
-	}
+	a.cancelQueueJob(queue.Current)
 
 	stopped++
 	ch.StatusLabel.SetText(fmt.Sprintf("%s의 %s 중지 요청됨 (총 %d개)", queue.Name, queue.Current.IssueKey, stopped))
 	ch.QueueList.Refresh()
 }
 
+// cancelQueueJob stops the AI process of a queued job that has been started
+func (a *App) cancelQueueJob(job *AnalysisJob) {
+	if job == nil || job.PID <= 0 || job.Analyzer == nil {
+		return
+	}
+	if err := job.Analyzer.Cancel(&domain.AIRun{PID: job.PID, StatusPath: job.StatusPath}); err != nil {
+		logger.Debug("cancelQueueJob: %s: %v", job.IssueKey, err)
+	}
+}
+
 // processQueue processes jobs in a queue sequentially
 func (a *App) processQueue(channelIndex int) {
 	queue := a.queues[channelIndex]
@@ -250,29 +258,37 @@ func (a *App) processQueue(channelIndex int) {
 func (a *App) executePhase1(channelIndex int, job *AnalysisJob) QueueJobOutcome {
 	ch := a.channels[channelIndex]
 
+	if err := a.checkDailyBudget(); err != nil {
+		fmt.Printf("[Queue] %s: 실행 중단 - %s: %v\n", a.queues[channelIndex].Name, job.IssueKey, err)
+		ch.StatusLabel.SetText(fmt.Sprintf("⛔ %s - %v", job.IssueKey, err))
+		return QueueJobOutcomeFailed
+	}
+
 	// 기존 plan 파일 삭제
 	os.Remove(job.PlanPath)
 
 	prompt := adapter.BuildAnalysisPlanPrompt(job.IssueKey, job.MDPath)
 	projectPath := strings.TrimSpace(ch.ProjectPathEntry.Text)
-	result, err := a.claudeAdapter.AnalyzeAndGeneratePlan(job.MDPath, prompt, projectPath)
+	analyzer := a.analyzer()
+	result, err := analyzer.GeneratePlan(job.MDPath, prompt, projectPath)
 	if err != nil {
 		fmt.Printf("[Queue] %s: 오류 - %s: %v\n", a.queues[channelIndex].Name, job.IssueKey, err)
 		ch.StatusLabel.SetText(fmt.Sprintf("오류: %s - %v", job.IssueKey, err))
 		return QueueJobOutcomeFailed
 	}
 
+	job.Analyzer = analyzer
 	job.PID = result.PID
-	job.PlanPath = result.PlanPath
-	job.AnalysisPath = result.PlanPath
-	job.ScriptPath = result.ScriptPath
+	job.PlanPath = result.OutputPath
+	job.AnalysisPath = result.OutputPath
+	job.StatusPath = result.StatusPath
 	job.LogPath = result.LogPath
 	job.Phase = adapter.PhaseAnalyze
 
 	// 채널별 상태 업데이트
-	ch.CurrentAnalysisPath = result.PlanPath
-	ch.CurrentPlanPath = result.PlanPath
-	ch.CurrentScriptPath = result.ScriptPath
+	ch.CurrentAnalysisPath = result.OutputPath
+	ch.CurrentPlanPath = result.OutputPath
+	ch.CurrentStatusPath = result.StatusPath
 
 	ch.QueueList.Refresh()
 
@@ -284,23 +300,31 @@ func (a *App) executePhase1(channelIndex int, job *AnalysisJob) QueueJobOutcome
 func (a *App) executePhase2(channelIndex int, job *AnalysisJob) QueueJobOutcome {
 	ch := a.channels[channelIndex]
 
+	if err := a.checkDailyBudget(); err != nil {
+		fmt.Printf("[Queue] %s: Phase 2 실행 중단 - %s: %v\n", a.queues[channelIndex].Name, job.IssueKey, err)
+		ch.StatusLabel.SetText(fmt.Sprintf("⛔ %s - %v", job.IssueKey, err))
+		return QueueJobOutcomeFailed
+	}
+
 	projectPath := strings.TrimSpace(ch.ProjectPathEntry.Text)
-	result, err := a.claudeAdapter.ExecutePlan(job.PlanPath, projectPath)
+	analyzer := a.analyzer()
+	result, err := analyzer.ExecutePlan(job.PlanPath, projectPath)
 	if err != nil {
 		fmt.Printf("[Queue] %s: Phase 2 오류 - %s: %v\n", a.queues[channelIndex].Name, job.IssueKey, err)
 		ch.StatusLabel.SetText(fmt.Sprintf("Phase 2 오류: %s - %v", job.IssueKey, err))
 		return QueueJobOutcomeFailed
 	}
 
+	job.Analyzer = analyzer
 	job.PID = result.PID
 	job.AnalysisPath = result.OutputPath
 	job.ExecutionPath = result.OutputPath
-	job.ScriptPath = result.ScriptPath
-	job.LogPath = strings.TrimSuffix(result.OutputPath, "_execution.md") + "_exec_log.txt"
+	job.StatusPath = result.StatusPath
+	job.LogPath = result.LogPath
 
 	// 채널별 상태 업데이트
 	ch.CurrentAnalysisPath = result.OutputPath
-	ch.CurrentScriptPath = result.ScriptPath
+	ch.CurrentStatusPath = result.StatusPath
 
 	ch.QueueList.Refresh()
 
@@ -315,6 +339,8 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 
 	startTime := time.Now()
 	var lastLogSize int64
+	var lastEvent string
+	events := newRunEventTailer(job.Analyzer, job.StatusPath, nil)
 
 	phaseLabel := "Phase 1"
 	if job.Phase == adapter.PhaseExecute {
@@ -328,16 +354,13 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 		elapsed := time.Since(startTime).Round(time.Second)
 		elapsedStr := fmt.Sprintf("%dm %ds", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
 
-		// Check if process is still running
-		checkCmd := exec.Command("ps", "-p", fmt.Sprintf("%d", job.PID))
-		if err := checkCmd.Run(); err != nil {
-			// Process finished
-			time.Sleep(500 * time.Millisecond)
-
+		// 상태 파일에 종료가 기록되면 결과 파일까지 조립된 상태다
+		if isRunFinished(job.Analyzer, job.StatusPath, job.PID) {
 			job.StartTime = elapsedStr
 
 			// 사용자 중단 요청된 작업은 중단 상태로 처리한다.
 			if job.CancelRequested {
+				a.recordQueueJobRun(job, "cancelled", "cancelled by user")
 				fmt.Printf("[Queue] %s: %s %s 중단됨 (%s)\n", queueName, job.IssueKey, phaseLabel, elapsedStr)
 				ch.StatusLabel.SetText(fmt.Sprintf("⏹ %s %s 중단됨 (%s)", job.IssueKey, phaseLabel, elapsedStr))
 				return QueueJobOutcomeCancelled
@@ -350,6 +373,7 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 			}
 			content, readErr := os.ReadFile(resultPath)
 			if readErr != nil {
+				a.recordQueueJobRun(job, "failed", readErr.Error())
 				fmt.Printf("[Queue] %s: %s %s 실패 - 결과 파일 읽기 오류: %v\n", queueName, job.IssueKey, phaseLabel, readErr)
 				ch.StatusLabel.SetText(fmt.Sprintf("❌ %s %s 실패 (결과 파일 읽기 오류)", job.IssueKey, phaseLabel))
 				return QueueJobOutcomeFailed
@@ -363,6 +387,8 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 				ch.ExecutePlanBtn.Enable()
 			}
 
+			a.recordQueueJobRun(job, "completed", "")
+
 			// 완료 작업 목록에 추가
 			a.mu.Lock()
 			a.completedJobs = append([]*AnalysisJob{job}, a.completedJobs...)
@@ -376,28 +402,21 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 			return QueueJobOutcomeCompleted
 		}
 
-		// 로그 파일 읽기 → 진행상황 UI에 표시
+		// Claude 출력 → 진행상황 UI에 표시 (stream-json이면 마지막 도구 호출/응답을 보여준다)
 		status := "분석 중..."
-		if job.LogPath != "" {
-			logContent, err := os.ReadFile(job.LogPath)
-			if err == nil {
-				if int64(len(logContent)) != lastLogSize {
-					lastLogSize = int64(len(logContent))
-					fmt.Printf("[Queue] %s %s: %s (경과: %s, 로그: %d bytes)\n", queueName, phaseLabel, job.IssueKey, elapsedStr, len(logContent))
-				}
-
-				logStr := string(logContent)
-				if strings.Contains(logStr, "Building plan") {
-					status = "Plan 파일 조립 중..."
-				} else if strings.Contains(logStr, "Running Claude") {
-					status = "Claude 실행 중..."
-				} else if strings.Contains(logStr, "Phase 1") {
-					status = "시작 중..."
-				} else if strings.Contains(logStr, "Phase 2") {
-					status = "실행 중..."
-				}
+		if runStatus, err := readRunStatus(job.Analyzer, job.StatusPath); err == nil {
+			status = "Claude 실행 중..."
+			if info, err := os.Stat(runStatus.StdoutPath); err == nil && info.Size() != lastLogSize {
+				lastLogSize = info.Size()
+				fmt.Printf("[Queue] %s %s: %s (경과: %s, 출력: %d bytes)\n", queueName, phaseLabel, job.IssueKey, elapsedStr, lastLogSize)
 			}
 		}
+		if event, ok := events.poll(); ok {
+			lastEvent = event.Summary()
+		}
+		if lastEvent != "" {
+			status = lastEvent
+		}
 		// 매 틱마다 경과시간 갱신
 		if job.CancelRequested {
 			status = "중지 요청됨..."
@@ -408,6 +427,67 @@ func (a *App) waitForJobCompletion(channelIndex int, job *AnalysisJob) QueueJobO
 	return QueueJobOutcomeFailed
 }
 
+// recordQueueJobRun은 큐 작업의 실행 결과를 사용량과 함께 분석 결과로 기록해 일일 한도 계산에 포함시킨다.
+// 큐로 실행한 이슈가 아직 DB에 없으면 1차 완료 상태로 만들어 기록한다.
+// 완료된 작업은 2차/3차 실행과 같이 단계를 올리고 완료 이벤트를 발행해 Jira 워크플로 전환을 따른다.
+func (a *App) recordQueueJobRun(job *AnalysisJob, status, errMsg string) {
+	if a.analysisStore == nil || a.issueStore == nil || job == nil {
+		return
+	}
+	record, err := a.issueStore.GetIssueByKeyAndChannel(job.IssueKey, job.ChannelIndex)
+	if err != nil || record == nil {
+		record = &domain.IssueRecord{
+			IssueKey:     job.IssueKey,
+			MDPath:       job.MDPath,
+			Phase:        1,
+			Status:       "active",
+			ChannelIndex: job.ChannelIndex,
+		}
+		if err := a.issueStore.UpsertIssue(record); err != nil {
+			logger.Debug("recordQueueJobRun: UpsertIssue failed: %v", err)
+			return
+		}
+	}
+
+	now := time.Now()
+	result := &domain.AnalysisResult{
+		IssueID:       record.ID,
+		AnalysisPhase: 1,
+		ResultPath:    job.AnalysisPath,
+		PlanPath:      job.PlanPath,
+		Status:        status,
+		CompletedAt:   &now,
+		ErrorMessage:  errMsg,
+		Usage:         runUsage(job.Analyzer, job.StatusPath),
+		SessionID:     runSessionID(job.Analyzer, job.StatusPath),
+	}
+	if job.Phase == adapter.PhaseExecute {
+		result.AnalysisPhase = 2
+		result.ExecutionPath = job.ExecutionPath
+	}
+	if err := a.analysisStore.CreateAnalysisResult(result); err != nil {
+		logger.Debug("recordQueueJobRun: CreateAnalysisResult failed: %v", err)
+	}
+	if status != "completed" {
+		return
+	}
+
+	// 큐의 Phase 1(플랜 생성)은 2차, Phase 2(플랜 실행)는 3차에 해당한다
+	phase, eventType := 2, state.EventPhase2Complete
+	if job.Phase == adapter.PhaseExecute {
+		phase, eventType = 3, state.EventPhase3Complete
+	}
+	if record.Phase < phase {
+		record.Phase = phase
+		if err := a.issueStore.UpdateIssue(record); err != nil {
+			logger.Debug("recordQueueJobRun: UpdateIssue failed: %v", err)
+		}
+	}
+	if a.v2State != nil {
+		a.v2State.appState.EventBus.Publish(state.Event{Type: eventType, Channel: job.ChannelIndex, Data: record})
+	}
+}
+
 // onStopAllQueues stops all running and pending jobs in all queues
 func (a *App) onStopAllQueues() {
 	stoppedCount := 0
@@ -421,13 +501,15 @@ func (a *App) onStopAllQueues() {
 			a.markRunningTaskCancelledInDB(task)
 			stoppedCount++
 		}
+		if a.cancelPhase1Run(i) {
+			stoppedCount++
+		}
 
 		// Stop current job
 		if queue.Current != nil {
 			// 현재 실행 중 작업은 중단 요청 상태로 표시한다.
 			queue.Current.CancelRequested = true
-			cmd := exec.Command("pkill", "-f", queue.Current.ScriptPath)
-			cmd.Run()
+			a.cancelQueueJob(queue.Current)
 			stoppedCount++
 		}
 
@@ -1,6 +1,14 @@
 package ui
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jira-ai-generator/internal/adapter"
+	"jira-ai-generator/internal/domain"
+)
 
 // TestRecordQueueJobByOutcome_CompletedOnlyOnSuccess는 성공 시에만 Completed 목록이 증가하는지 검증한다.
 func TestRecordQueueJobByOutcome_CompletedOnlyOnSuccess(t *testing.T) {
@@ -56,3 +64,42 @@ func TestRecordQueueJobByOutcome_CancelledDoesNotIncreaseCompleted(t *testing.T)
 		t.Fatalf("cancelled[0].IssueKey = %s, want ITSM-102", got)
 	}
 }
+
+// TestRecordQueueJobRun_RecordsUsage는 큐 작업의 사용량이 분석 결과로 기록되어 일일 합계에 포함되는지 검증한다.
+func TestRecordQueueJobRun_RecordsUsage(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := adapter.NewSQLiteRepository(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	statusPath := filepath.Join(dir, "ITSM-200_plan_status.json")
+	raw := `{"finished": true, "usage": {"input_tokens": 100, "output_tokens": 10, "cost_usd": 0.75}}`
+	if err := os.WriteFile(statusPath, []byte(raw), 0644); err != nil {
+		t.Fatalf("failed to write status file: %v", err)
+	}
+	app := &App{issueStore: repo, analysisStore: repo}
+	job := &AnalysisJob{IssueKey: "ITSM-200", StatusPath: statusPath, Analyzer: adapter.NewFakeAIAnalyzer(""), PlanPath: filepath.Join(dir, "ITSM-200_plan.md"), Phase: adapter.PhaseAnalyze, ChannelIndex: 1}
+
+	app.recordQueueJobRun(job, "completed", "")
+
+	totals, err := repo.SumAnalysisUsage(domain.UsageByDay, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("SumAnalysisUsage failed: %v", err)
+	}
+	if len(totals) != 1 || totals[0].Runs != 1 || totals[0].Usage.CostUSD != 0.75 || totals[0].Usage.InputTokens != 100 {
+		t.Fatalf("unexpected usage totals: %+v", totals)
+	}
+	record, err := repo.GetIssueByKeyAndChannel("ITSM-200", 1)
+	if err != nil {
+		t.Fatalf("expected queue issue to be recorded: %v", err)
+	}
+	if record.Phase != 2 {
+		t.Fatalf("completed plan job should move the issue to phase 2, got %d", record.Phase)
+	}
+	results, err := repo.ListAnalysisResultsByIssue(record.ID)
+	if err != nil || len(results) != 1 || results[0].AnalysisPhase != 1 || results[0].Status != "completed" {
+		t.Fatalf("unexpected analysis results: %+v (err=%v)", results, err)
+	}
+}
@@ -2,13 +2,17 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
+	"jira-ai-generator/internal/adapter"
 	"jira-ai-generator/internal/config"
+	"jira-ai-generator/internal/domain"
 )
 
 // showSettingsDialog 설정 다이얼로그 표시
@@ -24,10 +28,56 @@ func (a *App) showSettingsDialog() {
 	jiraAPIKeyEntry := widget.NewPasswordEntry()
 	jiraAPIKeyEntry.SetText(a.config.Jira.APIKey)
 
+	// 인증 방식 / REST API 버전 (Cloud: basic + v3, Server/Data Center: bearer PAT + v2)
+	authModeSelect := widget.NewSelect(config.AuthModes, nil)
+	authModeSelect.SetSelected(a.config.Jira.AuthMode)
+	if authModeSelect.Selected == "" {
+		authModeSelect.SetSelected(config.AuthModeBasic)
+	}
+
+	apiVersionSelect := widget.NewSelect(config.APIVersions, nil)
+	apiVersionSelect.SetSelected(a.config.Jira.APIVersion)
+	if apiVersionSelect.Selected == "" {
+		apiVersionSelect.SetSelected(config.APIVersionAuto)
+	}
+
+	autoAttachCheck := widget.NewCheck("2차/3차 완료 시 결과 파일을 Jira에 자동 첨부", nil)
+	autoAttachCheck.SetChecked(a.config.Jira.AutoAttachResults)
+
+	fetchRelatedCheck := widget.NewCheck("관련 이슈(상위/하위/링크) 설명까지 문서에 포함", nil)
+	fetchRelatedCheck.SetChecked(a.config.Jira.FetchRelated)
+
+	// 429/5xx 재시도 및 호스트별 동시 요청 수
+	maxRetriesEntry := widget.NewEntry()
+	maxRetriesEntry.SetPlaceHolder("0 = 재시도 안 함")
+	maxRetriesEntry.SetText(strconv.Itoa(a.config.Jira.MaxRetries))
+
+	maxConcurrencyEntry := widget.NewEntry()
+	maxConcurrencyEntry.SetPlaceHolder("0 = 제한 없음")
+	maxConcurrencyEntry.SetText(strconv.Itoa(a.config.Jira.MaxConcurrency))
+
+	// 단계 완료 시 Jira 워크플로 전환
+	transitionPhase2Entry := widget.NewEntry()
+	transitionPhase2Entry.SetPlaceHolder("예: AI Plan Ready (비우면 사용 안 함)")
+	transitionPhase2Entry.SetText(a.config.Jira.PhaseTransitions[2])
+
+	transitionPhase3Entry := widget.NewEntry()
+	transitionPhase3Entry.SetPlaceHolder("예: In Review (비우면 사용 안 함)")
+	transitionPhase3Entry.SetText(a.config.Jira.PhaseTransitions[3])
+
+	// 커스텀 필드 매핑 (한 줄에 "customfield_10010 = 환경")
+	customFieldsEntry := widget.NewMultiLineEntry()
+	customFieldsEntry.SetPlaceHolder("customfield_10010 = 환경\ncustomfield_10011 = 앱 버전")
+	customFieldsEntry.SetMinRowsVisible(3)
+	customFieldsEntry.SetText(formatCustomFieldsText(a.config.CustomFields))
+
 	// Claude 설정
 	claudeEnabledCheck := widget.NewCheck("Claude Code 활성화", nil)
 	claudeEnabledCheck.SetChecked(a.config.Claude.Enabled)
 
+	resumeSessionCheck := widget.NewCheck("3차 실행 시 2차 세션 이어서 실행 (없거나 만료되면 플랜 재전송)", nil)
+	resumeSessionCheck.SetChecked(a.config.Claude.ResumeSession)
+
 	claudePathEntry := widget.NewEntry()
 	claudePathEntry.SetText(a.config.Claude.CLIPath)
 
@@ -42,10 +92,102 @@ func (a *App) showSettingsDialog() {
 		modelSelect.SetSelected(config.AvailableModels[0])
 	}
 
+	// Claude 출력 형식 (stream-json이면 진행 상황을 실시간으로 표시)
+	outputFormatSelect := widget.NewSelect(adapter.OutputFormats, nil)
+	if a.config.Claude.OutputFormat == adapter.OutputFormatText {
+		outputFormatSelect.SetSelected(adapter.OutputFormatText)
+	} else {
+		outputFormatSelect.SetSelected(adapter.OutputFormatStreamJSON)
+	}
+
+	// AI 백엔드 (claude 또는 명령 템플릿으로 실행하는 CLI 에이전트)
+	aiBackendSelect := widget.NewSelect(domain.AIBackends, nil)
+	if a.config.AI.Backend == domain.AIBackendCLI {
+		aiBackendSelect.SetSelected(domain.AIBackendCLI)
+	} else {
+		aiBackendSelect.SetSelected(domain.AIBackendClaude)
+	}
+
+	agentCommandEntry := widget.NewEntry()
+	agentCommandEntry.SetPlaceHolder("예: aider --yes-always --no-auto-commits --message-file {prompt_file}")
+	agentCommandEntry.SetText(a.config.AI.AgentCommand)
+
+	// 일일 비용 한도 (USD)
+	dailyBudgetEntry := widget.NewEntry()
+	dailyBudgetEntry.SetPlaceHolder("0 = 제한 없음")
+	dailyBudgetEntry.SetText(strconv.FormatFloat(a.config.Claude.DailyBudgetUSD, 'g', -1, 64))
+
 	// 출력 디렉토리
 	outputDirEntry := widget.NewEntry()
 	outputDirEntry.SetText(a.config.Output.Dir)
 
+	// 문서에 포함할 코멘트
+	commentLimitEntry := widget.NewEntry()
+	commentLimitEntry.SetPlaceHolder("0 = 전체")
+	commentLimitEntry.SetText(strconv.Itoa(a.config.Output.CommentLimit))
+
+	excludeBotCommentsCheck := widget.NewCheck("봇(자동화) 계정 코멘트 제외", nil)
+	excludeBotCommentsCheck.SetChecked(a.config.Output.ExcludeBotComments)
+
+	// Server/Data Center는 계정 종류를 알려주지 않으므로 봇 계정을 직접 지정한다
+	botAuthorsEntry := widget.NewEntry()
+	botAuthorsEntry.SetPlaceHolder("jenkins, automation (쉼표로 구분)")
+	botAuthorsEntry.SetText(strings.Join(a.config.Output.BotCommentAuthors, ", "))
+
+	// 첨부파일 다운로드
+	maxAttachmentEntry := widget.NewEntry()
+	maxAttachmentEntry.SetPlaceHolder("0 = 제한 없음")
+	maxAttachmentEntry.SetText(strconv.Itoa(a.config.Output.MaxAttachmentMB))
+
+	downloadWorkersEntry := widget.NewEntry()
+	downloadWorkersEntry.SetText(strconv.Itoa(a.config.Output.DownloadWorkers))
+
+	maxInlineEntry := widget.NewEntry()
+	maxInlineEntry.SetText(strconv.Itoa(a.config.Output.MaxInlineKB))
+
+	// 동영상 프레임 추출
+	frameModeSelect := widget.NewSelect(domain.FrameModes, nil)
+	frameModeSelect.SetSelected(frameOptions(a.config.Video).Mode)
+
+	frameIntervalEntry := widget.NewEntry()
+	frameIntervalEntry.SetText(strconv.FormatFloat(a.config.Video.FrameInterval, 'g', -1, 64))
+
+	sceneThresholdEntry := widget.NewEntry()
+	sceneThresholdEntry.SetPlaceHolder("0~1, 낮을수록 민감")
+	sceneThresholdEntry.SetText(strconv.FormatFloat(a.config.Video.SceneThreshold, 'g', -1, 64))
+
+	maxFramesEntry := widget.NewEntry()
+	maxFramesEntry.SetPlaceHolder("0 = 제한 없음")
+	maxFramesEntry.SetText(strconv.Itoa(a.config.Video.MaxFrames))
+
+	frameWidthEntry := widget.NewEntry()
+	frameWidthEntry.SetPlaceHolder("0 = 원본 크기")
+	frameWidthEntry.SetText(strconv.Itoa(a.config.Video.FrameWidth))
+
+	dedupeDistanceEntry := widget.NewEntry()
+	dedupeDistanceEntry.SetPlaceHolder("0 = 사용 안 함, 0~64")
+	dedupeDistanceEntry.SetText(strconv.Itoa(a.config.Video.DedupeDistance))
+
+	transcribeCommandEntry := widget.NewEntry()
+	transcribeCommandEntry.SetPlaceHolder("예: whisper-cli -m ggml-base.bin -l auto -osrt -of {output} -f {audio}")
+	transcribeCommandEntry.SetText(a.config.Video.TranscribeCommand)
+
+	contactSheetCheck := widget.NewCheck("프레임을 한 장의 컨택트 시트로 합쳐 문서에 넣기", nil)
+	contactSheetCheck.SetChecked(a.config.Video.ContactSheet)
+
+	// 이미지 최적화
+	maxDimensionEntry := widget.NewEntry()
+	maxDimensionEntry.SetPlaceHolder("0 = 줄이지 않음")
+	maxDimensionEntry.SetText(strconv.Itoa(a.config.Image.MaxDimension))
+
+	convertPNGEntry := widget.NewEntry()
+	convertPNGEntry.SetPlaceHolder("0 = 변환 안 함")
+	convertPNGEntry.SetText(strconv.Itoa(a.config.Image.ConvertPNGKB))
+
+	jpegQualityEntry := widget.NewEntry()
+	jpegQualityEntry.SetPlaceHolder("1~100")
+	jpegQualityEntry.SetText(strconv.Itoa(a.config.Image.JPEGQuality))
+
 	// 채널별 프로젝트 경로
 	projectPath1Entry := widget.NewEntry()
 	projectPath1Entry.SetText(a.config.Claude.ChannelPaths[0])
@@ -63,14 +205,47 @@ func (a *App) showSettingsDialog() {
 	form := widget.NewForm(
 		widget.NewFormItem("Jira URL", jiraURLEntry),
 		widget.NewFormItem("Jira Email", jiraEmailEntry),
-		widget.NewFormItem("Jira API Key", jiraAPIKeyEntry),
+		widget.NewFormItem("Jira API Key / PAT", jiraAPIKeyEntry),
+		widget.NewFormItem("인증 방식", authModeSelect),
+		widget.NewFormItem("REST API 버전", apiVersionSelect),
+		widget.NewFormItem("", autoAttachCheck),
+		widget.NewFormItem("", fetchRelatedCheck),
+		widget.NewFormItem("재시도 횟수", maxRetriesEntry),
+		widget.NewFormItem("동시 요청 수", maxConcurrencyEntry),
+		widget.NewFormItem("2차 완료 시 전환", transitionPhase2Entry),
+		widget.NewFormItem("3차 완료 시 전환", transitionPhase3Entry),
+		widget.NewFormItem("커스텀 필드", customFieldsEntry),
 		widget.NewFormItem("", widget.NewSeparator()),
+		widget.NewFormItem("AI 백엔드", aiBackendSelect),
+		widget.NewFormItem("에이전트 명령", agentCommandEntry),
 		widget.NewFormItem("", claudeEnabledCheck),
 		widget.NewFormItem("Claude CLI 경로", claudePathEntry),
 		widget.NewFormItem("Claude Hook 스크립트", hookScriptEntry),
 		widget.NewFormItem("Claude 모델", modelSelect),
+		widget.NewFormItem("Claude 출력 형식", outputFormatSelect),
+		widget.NewFormItem("일일 비용 한도 (USD)", dailyBudgetEntry),
+		widget.NewFormItem("", resumeSessionCheck),
 		widget.NewFormItem("", widget.NewSeparator()),
 		widget.NewFormItem("출력 디렉토리", outputDirEntry),
+		widget.NewFormItem("최근 코멘트 수", commentLimitEntry),
+		widget.NewFormItem("", excludeBotCommentsCheck),
+		widget.NewFormItem("봇 계정", botAuthorsEntry),
+		widget.NewFormItem("첨부파일 최대 크기 (MB)", maxAttachmentEntry),
+		widget.NewFormItem("동시 다운로드 수", downloadWorkersEntry),
+		widget.NewFormItem("텍스트 첨부 최대 크기 (KB)", maxInlineEntry),
+		widget.NewFormItem("", widget.NewSeparator()),
+		widget.NewFormItem("프레임 추출 방식", frameModeSelect),
+		widget.NewFormItem("추출 간격 (초)", frameIntervalEntry),
+		widget.NewFormItem("화면 전환 임계값", sceneThresholdEntry),
+		widget.NewFormItem("최대 프레임 수", maxFramesEntry),
+		widget.NewFormItem("프레임 가로 크기", frameWidthEntry),
+		widget.NewFormItem("중복 프레임 제거 거리", dedupeDistanceEntry),
+		widget.NewFormItem("", contactSheetCheck),
+		widget.NewFormItem("음성 인식 명령", transcribeCommandEntry),
+		widget.NewFormItem("", widget.NewSeparator()),
+		widget.NewFormItem("이미지 최대 크기 (px)", maxDimensionEntry),
+		widget.NewFormItem("PNG→JPEG 변환 기준 (KB)", convertPNGEntry),
+		widget.NewFormItem("JPEG 품질", jpegQualityEntry),
 		widget.NewFormItem("", widget.NewSeparator()),
 		widget.NewFormItem("채널 1 프로젝트", projectPath1Entry),
 		widget.NewFormItem("채널 2 프로젝트", projectPath2Entry),
@@ -81,21 +256,180 @@ func (a *App) showSettingsDialog() {
 	var settingsDialog dialog.Dialog
 
 	saveBtn := widget.NewButton("저장", func() {
+		commentLimit, err := strconv.Atoi(strings.TrimSpace(commentLimitEntry.Text))
+		if err != nil || commentLimit < 0 {
+			dialog.ShowError(fmt.Errorf("최근 코멘트 수는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxRetries, err := strconv.Atoi(strings.TrimSpace(maxRetriesEntry.Text))
+		if err != nil || maxRetries < 0 {
+			dialog.ShowError(fmt.Errorf("재시도 횟수는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxConcurrency, err := strconv.Atoi(strings.TrimSpace(maxConcurrencyEntry.Text))
+		if err != nil || maxConcurrency < 0 {
+			dialog.ShowError(fmt.Errorf("동시 요청 수는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxAttachmentMB, err := strconv.Atoi(strings.TrimSpace(maxAttachmentEntry.Text))
+		if err != nil || maxAttachmentMB < 0 {
+			dialog.ShowError(fmt.Errorf("첨부파일 최대 크기는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		downloadWorkers, err := strconv.Atoi(strings.TrimSpace(downloadWorkersEntry.Text))
+		if err != nil || downloadWorkers < 1 {
+			dialog.ShowError(fmt.Errorf("동시 다운로드 수는 1 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxInlineKB, err := strconv.Atoi(strings.TrimSpace(maxInlineEntry.Text))
+		if err != nil || maxInlineKB < 1 {
+			dialog.ShowError(fmt.Errorf("텍스트 첨부 최대 크기는 1 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		dailyBudget, err := strconv.ParseFloat(strings.TrimSpace(dailyBudgetEntry.Text), 64)
+		if err != nil || dailyBudget < 0 {
+			dialog.ShowError(fmt.Errorf("일일 비용 한도는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		frameInterval, err := strconv.ParseFloat(strings.TrimSpace(frameIntervalEntry.Text), 64)
+		if err != nil || frameInterval <= 0 {
+			dialog.ShowError(fmt.Errorf("추출 간격은 0보다 큰 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		sceneThreshold, err := strconv.ParseFloat(strings.TrimSpace(sceneThresholdEntry.Text), 64)
+		if err != nil || sceneThreshold <= 0 || sceneThreshold >= 1 {
+			dialog.ShowError(fmt.Errorf("화면 전환 임계값은 0과 1 사이의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxFrames, err := strconv.Atoi(strings.TrimSpace(maxFramesEntry.Text))
+		if err != nil || maxFrames < 0 {
+			dialog.ShowError(fmt.Errorf("최대 프레임 수는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		frameWidth, err := strconv.Atoi(strings.TrimSpace(frameWidthEntry.Text))
+		if err != nil || frameWidth < 0 {
+			dialog.ShowError(fmt.Errorf("프레임 가로 크기는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		dedupeDistance, err := strconv.Atoi(strings.TrimSpace(dedupeDistanceEntry.Text))
+		if err != nil || dedupeDistance < 0 || dedupeDistance > 64 {
+			dialog.ShowError(fmt.Errorf("중복 프레임 제거 거리는 0에서 64 사이의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		maxDimension, err := strconv.Atoi(strings.TrimSpace(maxDimensionEntry.Text))
+		if err != nil || maxDimension < 0 {
+			dialog.ShowError(fmt.Errorf("이미지 최대 크기는 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		convertPNGKB, err := strconv.Atoi(strings.TrimSpace(convertPNGEntry.Text))
+		if err != nil || convertPNGKB < 0 {
+			dialog.ShowError(fmt.Errorf("PNG→JPEG 변환 기준은 0 이상의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		jpegQuality, err := strconv.Atoi(strings.TrimSpace(jpegQualityEntry.Text))
+		if err != nil || jpegQuality < 1 || jpegQuality > 100 {
+			dialog.ShowError(fmt.Errorf("JPEG 품질은 1에서 100 사이의 숫자여야 합니다"), a.mainWindow)
+			return
+		}
+		agentCommand := strings.TrimSpace(agentCommandEntry.Text)
+		if aiBackendSelect.Selected == domain.AIBackendCLI && agentCommand == "" {
+			dialog.ShowError(fmt.Errorf("cli 백엔드를 사용하려면 에이전트 명령을 입력해야 합니다"), a.mainWindow)
+			return
+		}
+		customFields, err := parseCustomFieldsText(customFieldsEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, a.mainWindow)
+			return
+		}
+
 		// 설정 업데이트
 		a.config.Jira.URL = jiraURLEntry.Text
 		a.config.Jira.Email = jiraEmailEntry.Text
 		a.config.Jira.APIKey = jiraAPIKeyEntry.Text
+		a.config.Jira.AuthMode = authModeSelect.Selected
+		a.config.Jira.APIVersion = apiVersionSelect.Selected
+		a.config.Jira.AutoAttachResults = autoAttachCheck.Checked
+		a.config.Jira.FetchRelated = fetchRelatedCheck.Checked
+		a.config.Jira.MaxRetries = maxRetries
+		a.config.Jira.MaxConcurrency = maxConcurrency
+		if a.processIssueUC != nil {
+			a.processIssueUC.SetFetchRelatedDescriptions(fetchRelatedCheck.Checked)
+		}
+		if a.config.Jira.PhaseTransitions == nil {
+			a.config.Jira.PhaseTransitions = make(map[int]string)
+		}
+		a.config.Jira.PhaseTransitions[2] = strings.TrimSpace(transitionPhase2Entry.Text)
+		a.config.Jira.PhaseTransitions[3] = strings.TrimSpace(transitionPhase3Entry.Text)
+		a.config.CustomFields = customFields
+		if a.jiraClient != nil {
+			a.jiraClient.SetCustomFields(toDomainCustomFields(customFields))
+			a.jiraClient.SetRetryPolicy(jiraRetryPolicy(a.config.Jira))
+		}
 		a.config.Claude.Enabled = claudeEnabledCheck.Checked
 		a.config.Claude.CLIPath = claudePathEntry.Text
 		a.config.Claude.Model = modelSelect.Selected
 		a.config.Claude.HookScriptPath = hookScriptEntry.Text
+		a.config.Claude.OutputFormat = outputFormatSelect.Selected
+		a.config.Claude.DailyBudgetUSD = dailyBudget
+		a.config.Claude.ResumeSession = resumeSessionCheck.Checked
+		a.config.AI.Backend = aiBackendSelect.Selected
+		a.config.AI.AgentCommand = agentCommand
 		a.config.Output.Dir = outputDirEntry.Text
+		a.config.Output.CommentLimit = commentLimit
+		a.config.Output.ExcludeBotComments = excludeBotCommentsCheck.Checked
+		a.config.Output.BotCommentAuthors = splitBotAuthors(botAuthorsEntry.Text)
+		a.config.Output.MaxAttachmentMB = maxAttachmentMB
+		a.config.Output.DownloadWorkers = downloadWorkers
+		a.config.Output.MaxInlineKB = maxInlineKB
+		if a.downloader != nil {
+			a.downloader.SetMaxSize(int64(maxAttachmentMB) << 20)
+			a.downloader.SetWorkers(downloadWorkers)
+		}
+		if a.textReader != nil {
+			a.textReader.SetMaxBytes(int64(maxInlineKB) << 10)
+		}
+		a.config.Video = config.VideoConfig{
+			FrameMode:         frameModeSelect.Selected,
+			FrameInterval:     frameInterval,
+			SceneThreshold:    sceneThreshold,
+			MaxFrames:         maxFrames,
+			FrameWidth:        frameWidth,
+			ContactSheet:      contactSheetCheck.Checked,
+			DedupeDistance:    dedupeDistance,
+			TranscribeCommand: strings.TrimSpace(transcribeCommandEntry.Text),
+		}
+		if a.processIssueUC != nil {
+			a.processIssueUC.SetFrameOptions(frameOptions(a.config.Video))
+		}
+		if a.transcriber != nil {
+			a.transcriber.SetCommand(a.config.Video.TranscribeCommand)
+		}
+		a.config.Image = config.ImageConfig{
+			MaxDimension: maxDimension,
+			ConvertPNGKB: convertPNGKB,
+			JPEGQuality:  jpegQuality,
+		}
+		if a.imageProcessor != nil {
+			a.imageProcessor.SetOptions(imageOptions(a.config.Image))
+		}
+		if a.docGenerator != nil {
+			a.docGenerator.SetCommentOptions(adapter.CommentOptions{
+				Limit:       commentLimit,
+				ExcludeBots: excludeBotCommentsCheck.Checked,
+				BotAuthors:  a.config.Output.BotCommentAuthors,
+			})
+		}
 
 		// Claude Adapter 모델 업데이트
 		if a.claudeAdapter != nil {
 			a.claudeAdapter.SetModel(modelSelect.Selected)
 			a.claudeAdapter.SetHookScriptPath(hookScriptEntry.Text)
+			a.claudeAdapter.SetOutputFormat(outputFormatSelect.Selected)
+		}
+		if a.agentAdapter != nil {
+			a.agentAdapter.SetCommand(agentCommand)
 		}
+		a.selectAIAnalyzer(a.config.AI.Backend)
 		a.config.Claude.ChannelPaths[0] = projectPath1Entry.Text
 		a.config.Claude.ChannelPaths[1] = projectPath2Entry.Text
 		a.config.Claude.ChannelPaths[2] = projectPath3Entry.Text
@@ -147,3 +481,44 @@ func (a *App) showSettingsDialog() {
 	settingsDialog.Resize(fyne.NewSize(750, 780))
 	settingsDialog.Show()
 }
+
+// formatCustomFieldsText는 커스텀 필드 매핑을 설정 입력란용 텍스트로 변환한다.
+func formatCustomFieldsText(fields []config.CustomField) string {
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		lines = append(lines, fmt.Sprintf("%s = %s", field.ID, field.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCustomFieldsText는 "필드ID = 표시 이름" 형식의 줄 목록을 커스텀 필드 매핑으로 변환한다.
+func parseCustomFieldsText(text string) ([]config.CustomField, error) {
+	var fields []config.CustomField
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		id, name, found := strings.Cut(line, "=")
+		id, name = strings.TrimSpace(id), strings.TrimSpace(name)
+		if !found || id == "" {
+			return nil, fmt.Errorf("커스텀 필드 %d번째 줄 형식이 올바르지 않습니다 (예: customfield_10010 = 환경): %s", i+1, line)
+		}
+		if name == "" {
+			name = id
+		}
+		fields = append(fields, config.CustomField{ID: id, Name: name})
+	}
+	return fields, nil
+}
+
+// splitBotAuthors는 쉼표로 구분한 봇 계정 입력을 목록으로 변환한다 (빈 항목 제외).
+func splitBotAuthors(text string) []string {
+	var authors []string
+	for _, author := range strings.Split(text, ",") {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
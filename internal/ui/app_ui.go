@@ -108,7 +108,7 @@ func (a *App) createMainContent() fyne.CanvasObject {
 
 	a.stopAllBtn = widget.NewButtonWithIcon("전체 중지", theme.MediaStopIcon(), a.onStopAllQueues)
 	a.stopAllBtn.Importance = widget.DangerImportance
-	if !a.claudeAdapter.IsEnabled() {
+	if !a.analyzer().IsEnabled() {
 		a.stopAllBtn.Hide()
 	}
 
@@ -182,7 +182,7 @@ func (a *App) createChannelTab(channelIndex int) fyne.CanvasObject {
 	})
 	ch.ExecutePlanBtn.Importance = widget.WarningImportance
 	ch.ExecutePlanBtn.Disable()
-	if !a.claudeAdapter.IsEnabled() {
+	if !a.analyzer().IsEnabled() {
 		ch.ExecutePlanBtn.Hide()
 	}
 
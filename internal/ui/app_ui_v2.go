@@ -9,10 +9,12 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"jira-ai-generator/internal/adapter"
 	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/logger"
 	"jira-ai-generator/internal/ui/components"
 	"jira-ai-generator/internal/ui/state"
+	"jira-ai-generator/internal/usecase"
 )
 
 // AppV2State 새 UI 상태
@@ -164,6 +166,24 @@ func (v2 *AppV2State) subscribeEvents(a *App) {
 		data, _ := event.Data.(map[string]interface{})
 		a.handleIssueDeleteRequestV2(event.Channel, data, v2)
 	})
+
+	// 2차/3차 완료 시 Jira 워크플로 전환
+	eb.Subscribe(state.EventPhase2Complete, func(event state.Event) {
+		if record, ok := event.Data.(*domain.IssueRecord); ok {
+			a.transitionIssueForPhaseV2(event.Channel, record, 2, v2)
+		}
+	})
+	eb.Subscribe(state.EventPhase3Complete, func(event state.Event) {
+		if record, ok := event.Data.(*domain.IssueRecord); ok {
+			a.transitionIssueForPhaseV2(event.Channel, record, 3, v2)
+		}
+	})
+
+	// 분석 결과 Jira 코멘트 게시 요청
+	eb.Subscribe(state.EventJiraCommentRequest, func(event state.Event) {
+		data, _ := event.Data.(map[string]interface{})
+		a.handleJiraCommentRequestV2(event.Channel, data, v2)
+	})
 }
 
 // createMainContentV2 새 레이아웃으로 메인 콘텐츠 생성
@@ -177,7 +197,7 @@ func (a *App) createMainContentV2(v2 *AppV2State) fyne.CanvasObject {
 
 	a.stopAllBtn = widget.NewButtonWithIcon("전체 중지", theme.MediaStopIcon(), a.onStopAllQueues)
 	a.stopAllBtn.Importance = widget.DangerImportance
-	if !a.claudeAdapter.IsEnabled() {
+	if !a.analyzer().IsEnabled() {
 		a.stopAllBtn.Hide()
 	}
 
@@ -199,6 +219,14 @@ func (a *App) createMainContentV2(v2 *AppV2State) fyne.CanvasObject {
 		a.showSettingsDialog()
 	})
 
+	v2.sidebar.SetOnUsageClick(func() {
+		a.showUsageDialog()
+	})
+
+	v2.sidebar.SetOnJQLImport(func() {
+		a.showJQLImportDialogV2(v2)
+	})
+
 	// 채널 탭 생성 (새 컴포넌트 사용)
 	a.tabs = container.NewAppTabs(
 		container.NewTabItem("채널 1", a.createChannelTabV2(0, v2)),
@@ -352,7 +380,7 @@ func (a *App) onChannelProcessV2(channelIndex int, v2 *AppV2State) {
 	ch.CurrentMDPath = ""
 	ch.CurrentAnalysisPath = ""
 	ch.CurrentPlanPath = ""
-	ch.CurrentScriptPath = ""
+	ch.CurrentStatusPath = ""
 	v2.resultPanels[channelIndex].Reset()
 	v2.progressPanels[channelIndex].Reset()
 
@@ -391,8 +419,21 @@ func (a *App) onChannelProcessV2(channelIndex int, v2 *AppV2State) {
 			})
 		}
 
+		ctx, done := a.beginPhase1Run(channelIndex)
+		defer done()
+
 		logger.Debug("onChannelProcessV2: calling processIssueUC.Execute")
-		result, err := a.processIssueUC.Execute(url, onProgress)
+		result, err := a.processIssueUC.Execute(ctx, url, onProgress)
+		if usecase.IsCancelled(err) {
+			logger.Debug("onChannelProcessV2: cancelled by user")
+			fyne.Do(func() {
+				v2.appState.UpdatePhase(channelIndex, state.PhaseIdle)
+				v2.appState.AddLog(channelIndex, state.LogWarning, "분석 중지됨: "+url, "App")
+				ch.StatusLabel.SetText("중지됨")
+				v2.progressPanels[channelIndex].Reset()
+			})
+			return
+		}
 		if err != nil {
 			logger.Debug("onChannelProcessV2: Execute error: %v", err)
 			fyne.Do(func() {
@@ -441,6 +482,9 @@ func (a *App) onChannelProcessV2(channelIndex int, v2 *AppV2State) {
 				if err != nil {
 					logger.Debug("onChannelProcessV2: DB save error: %v", err)
 				}
+				if savedIssue != nil {
+					a.recordAttachments(savedIssue.ID, result.Attachments)
+				}
 
 				// 이력에 추가 (채널+이슈ID 조합으로 충돌 방지)
 				if savedIssue != nil {
@@ -460,6 +504,47 @@ func (a *App) onChannelProcessV2(channelIndex int, v2 *AppV2State) {
 	}()
 }
 
+// recordAttachments 이슈 레코드의 첨부파일 목록을 이번 Phase 1 결과로 교체한다.
+// Jira 첨부파일 ID, 크기, 내용 해시를 함께 남겨 같은 이슈를 다른 채널에서 열어도 캐시와 대조할 수 있다.
+func (a *App) recordAttachments(issueID int64, results []domain.DownloadResult) {
+	if a.attachmentStore == nil {
+		return
+	}
+	if err := a.attachmentStore.DeleteAttachmentsByIssue(issueID); err != nil {
+		logger.Debug("recordAttachments: delete error: %v", err)
+		return
+	}
+	for _, dr := range results {
+		if dr.Error != nil || dr.LocalPath == "" {
+			continue
+		}
+		record := &domain.AttachmentRecord{
+			IssueID:     issueID,
+			Filename:    dr.Attachment.Filename,
+			LocalPath:   dr.LocalPath,
+			MimeType:    dr.Attachment.MimeType,
+			IsVideo:     dr.IsVideo,
+			JiraID:      dr.Attachment.ID,
+			Size:        dr.Attachment.Size,
+			ContentHash: dr.ContentHash,
+		}
+		if err := a.attachmentStore.CreateAttachment(record); err != nil {
+			logger.Debug("recordAttachments: create error: %v", err)
+		}
+	}
+}
+
+// observeJiraRetries Jira 요청 재시도를 이벤트 버스 로그로 알린다.
+// 재시도는 요청 단위로 일어나 채널을 알 수 없으므로 현재 활성 채널 로그에 남긴다.
+func (a *App) observeJiraRetries(v2 *AppV2State) {
+	if a.jiraClient == nil {
+		return
+	}
+	a.jiraClient.SetRetryObserver(func(event adapter.RetryEvent) {
+		v2.appState.AddLog(v2.appState.ActiveChannel, state.LogWarning, event.String(), "Jira")
+	})
+}
+
 // RunV2 V2 UI로 앱 실행
 func (a *App) RunV2() {
 	a.mainWindow = a.fyneApp.NewWindow("Jira AI Generator v2")
@@ -468,6 +553,7 @@ func (a *App) RunV2() {
 
 	v2 := a.initV2State()
 	a.v2State = v2
+	a.observeJiraRetries(v2)
 	content := a.createMainContentV2(v2)
 	a.mainWindow.SetContent(content)
 
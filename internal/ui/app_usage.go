@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/port"
+)
+
+// usageTableHeaders는 사용량 표의 열 제목이다 (첫 열은 그룹 키).
+var usageTableHeaders = []string{"", "실행", "입력", "출력", "캐시 쓰기", "캐시 읽기", "비용 (USD)", "소요 시간"}
+
+// startOfToday는 로컬 시간 기준 오늘 0시를 반환한다.
+func startOfToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// todayUsage는 오늘(로컬 시간) 완료된 분석/실행의 사용량 합계를 반환한다.
+func (a *App) todayUsage() (domain.AnalysisUsage, error) {
+	var total domain.AnalysisUsage
+	if a.analysisStore == nil {
+		return total, nil
+	}
+	totals, err := a.analysisStore.SumAnalysisUsage(domain.UsageByDay, startOfToday())
+	if err != nil {
+		return total, err
+	}
+	for _, t := range totals {
+		total.Add(t.Usage)
+	}
+	return total, nil
+}
+
+// checkDailyBudget은 오늘 사용한 비용이 일일 한도에 도달했으면 domain.ErrBudgetExceeded를 감싼 에러를 반환한다.
+// 한도가 0이거나 사용량을 조회하지 못하면 실행을 막지 않는다.
+func (a *App) checkDailyBudget() error {
+	budget := a.config.Claude.DailyBudgetUSD
+	if budget <= 0 {
+		return nil
+	}
+	usage, err := a.todayUsage()
+	if err != nil {
+		logger.Debug("checkDailyBudget: failed to sum today's usage: %v", err)
+		return nil
+	}
+	if domain.BudgetExceeded(usage.CostUSD, budget) {
+		return fmt.Errorf("%w: 오늘 $%.2f 사용 (한도 $%.2f)", domain.ErrBudgetExceeded, usage.CostUSD, budget)
+	}
+	return nil
+}
+
+// runUsage는 실행을 시작한 백엔드가 보고한 사용량을 반환한다 (읽지 못하면 0).
+func runUsage(analyzer port.AIAnalyzer, statusPath string) domain.AnalysisUsage {
+	status, err := readRunStatus(analyzer, statusPath)
+	if err != nil {
+		logger.Debug("runUsage: %v", err)
+		return domain.AnalysisUsage{}
+	}
+	return status.Usage
+}
+
+// recordFailedRun은 실패한 실행도 비용이 발생하므로 사용량과 함께 failed 분석 결과로 기록한다.
+func (a *App) recordFailedRun(task *RunningTask, analysisPhase int, runErr error) {
+	if a.analysisStore == nil || task == nil || task.IssueID <= 0 {
+		return
+	}
+	now := time.Now()
+	if err := a.analysisStore.CreateAnalysisResult(&domain.AnalysisResult{
+		IssueID:       task.IssueID,
+		AnalysisPhase: analysisPhase,
+		Status:        "failed",
+		ErrorMessage:  runErr.Error(),
+		CompletedAt:   &now,
+		Usage:         runUsage(task.Analyzer, task.StatusPath),
+	}); err != nil {
+		logger.Debug("recordFailedRun: CreateAnalysisResult failed: %v", err)
+	}
+}
+
+// showUsageDialog는 이슈별/채널별/일별 토큰 사용량과 비용 합계를 표시한다.
+func (a *App) showUsageDialog() {
+	if a.analysisStore == nil {
+		dialog.ShowInformation("💰 사용량", "분석 결과 저장소가 설정되지 않았습니다.", a.mainWindow)
+		return
+	}
+
+	byDay, err := a.analysisStore.SumAnalysisUsage(domain.UsageByDay, time.Time{})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("사용량 조회 실패: %w", err), a.mainWindow)
+		return
+	}
+	byIssue, err := a.analysisStore.SumAnalysisUsage(domain.UsageByIssue, time.Time{})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("사용량 조회 실패: %w", err), a.mainWindow)
+		return
+	}
+	byChannel, err := a.analysisStore.SumAnalysisUsage(domain.UsageByChannel, time.Time{})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("사용량 조회 실패: %w", err), a.mainWindow)
+		return
+	}
+
+	today, _ := a.todayUsage()
+	summary := fmt.Sprintf("오늘: $%.4f", today.CostUSD)
+	if budget := a.config.Claude.DailyBudgetUSD; budget > 0 {
+		summary += fmt.Sprintf(" / 한도 $%.2f", budget)
+		if domain.BudgetExceeded(today.CostUSD, budget) {
+			summary += " (초과 - 새 실행이 차단됩니다)"
+		}
+	}
+
+	// 채널 키는 0부터 시작하는 인덱스다
+	channelLabel := func(key string) string {
+		if index, err := strconv.Atoi(key); err == nil {
+			return fmt.Sprintf("채널 %d", index+1)
+		}
+		return key
+	}
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("일별", usageTable("날짜", byDay, nil)),
+		container.NewTabItem("이슈별", usageTable("이슈", byIssue, nil)),
+		container.NewTabItem("채널별", usageTable("채널", byChannel, channelLabel)),
+	)
+
+	content := container.NewBorder(widget.NewLabel(summary), nil, nil, nil, tabs)
+	d := dialog.NewCustom("💰 토큰 사용량 및 비용", "닫기", content, a.mainWindow)
+	d.Resize(fyne.NewSize(820, 480))
+	d.Show()
+}
+
+// usageTable은 사용량 합계를 표로 만든다. keyLabel이 nil이면 키를 그대로 표시한다.
+func usageTable(keyHeader string, totals []*domain.UsageTotal, keyLabel func(string) string) fyne.CanvasObject {
+	if len(totals) == 0 {
+		return widget.NewLabel("기록된 사용량이 없습니다.")
+	}
+
+	rows := make([][]string, 0, len(totals))
+	for _, t := range totals {
+		key := t.Key
+		if keyLabel != nil {
+			key = keyLabel(key)
+		}
+		rows = append(rows, []string{
+			key,
+			strconv.Itoa(t.Runs),
+			strconv.FormatInt(t.Usage.InputTokens, 10),
+			strconv.FormatInt(t.Usage.OutputTokens, 10),
+			strconv.FormatInt(t.Usage.CacheCreationTokens, 10),
+			strconv.FormatInt(t.Usage.CacheReadTokens, 10),
+			fmt.Sprintf("$%.4f", t.Usage.CostUSD),
+			t.Usage.Duration().Round(time.Second).String(),
+		})
+	}
+
+	headers := append([]string{keyHeader}, usageTableHeaders[1:]...)
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			label.SetText(rows[id.Row-1][id.Col])
+		},
+	)
+	table.SetColumnWidth(0, 160)
+	for col := 1; col < len(headers); col++ {
+		table.SetColumnWidth(col, 90)
+	}
+	return table
+}
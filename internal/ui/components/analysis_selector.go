@@ -103,6 +103,9 @@ func NewAnalysisSelector(eventBus *state.EventBus, channelIdx int) *AnalysisSele
 	a.phase2List.SetOnDelete(func(record *domain.IssueRecord) {
 		a.onDeletePhase2Item(record)
 	})
+	a.phase2List.SetOnComment(func(record *domain.IssueRecord) {
+		a.onCommentPhase2Item(record)
+	})
 
 	a.startPhase2 = widget.NewButton("AI 플랜 생성", a.onStartPhase2)
 	a.startPhase2.Disable() // 초기에는 비활성화
@@ -147,6 +150,9 @@ func NewAnalysisSelector(eventBus *state.EventBus, channelIdx int) *AnalysisSele
 	a.phase3List.SetOnDelete(func(record *domain.IssueRecord) {
 		a.onDeletePhase3Item(record)
 	})
+	a.phase3List.SetOnComment(func(record *domain.IssueRecord) {
+		a.onCommentPhase3Item(record)
+	})
 
 	a.startPhase3 = widget.NewButton("AI 실행", a.onStartPhase3)
 	a.startPhase3.Disable() // 초기에는 비활성화
@@ -470,6 +476,36 @@ func (a *AnalysisSelector) onDeletePhase3Item(record *domain.IssueRecord) {
 	})
 }
 
+// onCommentPhase2Item은 2차 섹션 완료 항목의 AI 플랜을 Jira 코멘트로 게시하도록 요청한다.
+func (a *AnalysisSelector) onCommentPhase2Item(record *domain.IssueRecord) {
+	if record == nil {
+		return
+	}
+	a.eventBus.PublishSync(state.Event{
+		Type:    state.EventJiraCommentRequest,
+		Channel: a.channelIdx,
+		Data: map[string]interface{}{
+			"listPhase":   2,
+			"issueRecord": record,
+		},
+	})
+}
+
+// onCommentPhase3Item은 3차 섹션 완료 항목의 AI 실행 결과를 Jira 코멘트로 게시하도록 요청한다.
+func (a *AnalysisSelector) onCommentPhase3Item(record *domain.IssueRecord) {
+	if record == nil {
+		return
+	}
+	a.eventBus.PublishSync(state.Event{
+		Type:    state.EventJiraCommentRequest,
+		Channel: a.channelIdx,
+		Data: map[string]interface{}{
+			"listPhase":   3,
+			"issueRecord": record,
+		},
+	})
+}
+
 // CreateRenderer AnalysisSelector 렌더러
 func (a *AnalysisSelector) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(a.containerObj)
@@ -486,6 +522,7 @@ type CompletedList struct {
 	checkboxes     map[int]*widget.Check
 	onSelect       func(*domain.IssueRecord)
 	onDelete       func(*domain.IssueRecord)
+	onComment      func(*domain.IssueRecord)
 	completedPhase int // 이 Phase 이상이면 완료로 간주
 }
 
@@ -507,10 +544,13 @@ func NewCompletedList(completedPhase int) *CompletedList {
 			icon.Hide()
 			label := widget.NewLabel("")
 			label.Wrapping = fyne.TextTruncate
+			commentBtn := widget.NewButtonWithIcon("", theme.MailSendIcon(), nil)
+			commentBtn.Importance = widget.LowImportance
+			commentBtn.Hide()
 			deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), nil)
 			deleteBtn.Importance = widget.LowImportance
-			// Border: Left=check/icon, Right=delete, Center=label (label이 남은 공간 전체 사용)
-			return container.NewBorder(nil, nil, container.NewStack(check, icon), deleteBtn, label)
+			// Border: Left=check/icon, Right=comment+delete, Center=label (label이 남은 공간 전체 사용)
+			return container.NewBorder(nil, nil, container.NewStack(check, icon), container.NewHBox(commentBtn, deleteBtn), label)
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			if id >= len(c.items) {
@@ -524,18 +564,24 @@ func NewCompletedList(completedPhase int) *CompletedList {
 				// Border 컨테이너에서 자식 요소 찾기
 				var stackContainer *fyne.Container
 				var label *widget.Label
+				var commentBtn *widget.Button
 				var deleteBtn *widget.Button
 
 				for _, child := range border.Objects {
-					if stack, ok := child.(*fyne.Container); ok {
-						stackContainer = stack
+					if box, ok := child.(*fyne.Container); ok {
+						// 버튼 묶음(코멘트, 삭제)과 체크박스 스택을 구분한다.
+						if len(box.Objects) == 2 {
+							if first, ok := box.Objects[0].(*widget.Button); ok {
+								commentBtn = first
+								deleteBtn, _ = box.Objects[1].(*widget.Button)
+								continue
+							}
+						}
+						stackContainer = box
 					}
 					if l, ok := child.(*widget.Label); ok {
 						label = l
 					}
-					if btn, ok := child.(*widget.Button); ok {
-						deleteBtn = btn
-					}
 				}
 
 				if stackContainer != nil {
@@ -597,6 +643,19 @@ func NewCompletedList(completedPhase int) *CompletedList {
 					}
 					label.Refresh()
 				}
+				if commentBtn != nil {
+					currentItem := item
+					// 분석 결과가 있는 완료 항목에서만 Jira 코멘트 게시를 허용한다.
+					if isCompleted && c.onComment != nil {
+						commentBtn.OnTapped = func() {
+							c.onComment(currentItem)
+						}
+						commentBtn.Show()
+					} else {
+						commentBtn.OnTapped = nil
+						commentBtn.Hide()
+					}
+				}
 				if deleteBtn != nil {
 					currentItem := item
 					deleteBtn.OnTapped = func() {
@@ -654,6 +713,11 @@ func (c *CompletedList) SetOnDelete(callback func(*domain.IssueRecord)) {
 	c.onDelete = callback
 }
 
+// SetOnComment Jira 코멘트 게시 콜백 설정
+func (c *CompletedList) SetOnComment(callback func(*domain.IssueRecord)) {
+	c.onComment = callback
+}
+
 // Clear 목록 초기화
 func (c *CompletedList) Clear() {
 	c.items = make([]*domain.IssueRecord, 0)
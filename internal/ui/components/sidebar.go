@@ -21,10 +21,11 @@ type Sidebar struct {
 	container *fyne.Container
 
 	// 1차 분석 UI
-	urlEntry   *widget.Entry
-	analyzeBtn *widget.Button
-	eventBus   *state.EventBus
-	channelIdx int
+	urlEntry     *widget.Entry
+	analyzeBtn   *widget.Button
+	jqlImportBtn *widget.Button
+	eventBus     *state.EventBus
+	channelIdx   int
 
 	// 채널 목록
 	channelList   *widget.List
@@ -40,11 +41,16 @@ type Sidebar struct {
 	// 설정 버튼
 	settingsBtn *widget.Button
 
+	// 사용량 버튼
+	usageBtn *widget.Button
+
 	// 콜백
 	onChannelSelect func(index int)
 	onQueueSelect   func(jobID string)
 	onHistorySelect func(jobID string)
 	onSettingsClick func()
+	onUsageClick    func()
+	onJQLImport     func()
 }
 
 // ChannelInfo 채널 정보
@@ -70,6 +76,7 @@ func NewSidebar(eventBus *state.EventBus, channelIdx int) *Sidebar {
 		queuePanel:    NewQueuePanel(),
 		historyPanel:  NewHistoryPanel(),
 		settingsBtn:   widget.NewButton("⚙️ 설정", nil),
+		usageBtn:      widget.NewButton("💰 사용량", nil),
 	}
 
 	// URL 입력 필드 설정
@@ -78,12 +85,25 @@ func NewSidebar(eventBus *state.EventBus, channelIdx int) *Sidebar {
 	// 분석 시작 버튼 생성
 	s.analyzeBtn = widget.NewButton("분석 시작", s.onAnalyzeClick)
 
+	// JQL 일괄 가져오기 버튼 생성
+	s.jqlImportBtn = widget.NewButton("📥 JQL 가져오기", func() {
+		if s.onJQLImport != nil {
+			s.onJQLImport()
+		}
+	})
+
 	s.settingsBtn.OnTapped = func() {
 		if s.onSettingsClick != nil {
 			s.onSettingsClick()
 		}
 	}
 
+	s.usageBtn.OnTapped = func() {
+		if s.onUsageClick != nil {
+			s.onUsageClick()
+		}
+	}
+
 	// 채널 목록
 	s.channelList = widget.NewList(
 		func() int { return len(s.channelData) },
@@ -123,6 +143,7 @@ func NewSidebar(eventBus *state.EventBus, channelIdx int) *Sidebar {
 		widget.NewLabelWithStyle("🔍 1차 분석", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		s.urlEntry,
 		s.analyzeBtn,
+		s.jqlImportBtn,
 	)
 
 	channelSection := container.NewVBox(
@@ -141,7 +162,7 @@ func NewSidebar(eventBus *state.EventBus, channelIdx int) *Sidebar {
 	)
 
 	s.container = container.NewVBox(
-		s.settingsBtn,
+		container.NewGridWithColumns(2, s.settingsBtn, s.usageBtn),
 		widget.NewSeparator(),
 		analyzeSection,
 		widget.NewSeparator(),
@@ -205,6 +226,16 @@ func (s *Sidebar) SetOnSettingsClick(callback func()) {
 	s.onSettingsClick = callback
 }
 
+// SetOnUsageClick 사용량 버튼 콜백 설정
+func (s *Sidebar) SetOnUsageClick(callback func()) {
+	s.onUsageClick = callback
+}
+
+// SetOnJQLImport JQL 가져오기 버튼 콜백 설정
+func (s *Sidebar) SetOnJQLImport(callback func()) {
+	s.onJQLImport = callback
+}
+
 // UpdateChannel 채널 상태 업데이트
 func (s *Sidebar) UpdateChannel(index int, status string, count int) {
 	fyne.Do(func() {
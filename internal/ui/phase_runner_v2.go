@@ -1,11 +1,10 @@
 package ui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,6 +17,7 @@ import (
 	"jira-ai-generator/internal/adapter"
 	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/logger"
+	"jira-ai-generator/internal/port"
 	"jira-ai-generator/internal/ui/state"
 )
 
@@ -323,6 +323,54 @@ func (a *App) unregisterRunningTask(channelIndex int, taskID string) {
 	delete(a.runningTasks[channelIndex], taskID)
 }
 
+// phase1Run은 채널에서 진행 중인 1차 처리(이슈 조회·첨부 다운로드·프레임 추출)의 취소 함수를 보관한다.
+type phase1Run struct {
+	cancel context.CancelFunc
+}
+
+// beginPhase1Run은 지정한 채널들의 중지 버튼으로 취소할 수 있는 1차 처리용 context를 만든다.
+// 반환된 done은 처리가 끝나면 반드시 호출해야 한다.
+func (a *App) beginPhase1Run(channelIndices ...int) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &phase1Run{cancel: cancel}
+
+	a.runningTasksMu.Lock()
+	for _, channelIndex := range channelIndices {
+		if channelIndex >= 0 && channelIndex < 3 {
+			a.phase1Runs[channelIndex] = run
+		}
+	}
+	a.runningTasksMu.Unlock()
+
+	done := func() {
+		a.runningTasksMu.Lock()
+		for i := range a.phase1Runs {
+			if a.phase1Runs[i] == run {
+				a.phase1Runs[i] = nil
+			}
+		}
+		a.runningTasksMu.Unlock()
+		cancel()
+	}
+	return ctx, done
+}
+
+// cancelPhase1Run은 채널에서 진행 중인 1차 처리를 취소하고, 취소한 작업이 있었는지 반환한다.
+func (a *App) cancelPhase1Run(channelIndex int) bool {
+	if channelIndex < 0 || channelIndex >= 3 {
+		return false
+	}
+	a.runningTasksMu.Lock()
+	run := a.phase1Runs[channelIndex]
+	a.runningTasksMu.Unlock()
+
+	if run == nil {
+		return false
+	}
+	run.cancel()
+	return true
+}
+
 // markCancelRunningTasks는 채널의 실행 중 작업에 취소 플래그를 설정한다.
 func (a *App) markCancelRunningTasks(channelIndex int) []*RunningTask {
 	a.runningTasksMu.Lock()
@@ -373,71 +421,60 @@ func (a *App) markRunningTaskCancelledInDB(task *RunningTask) {
 	}
 }
 
-// killRunningTask는 등록된 실행 작업의 프로세스를 종료한다.
-// PID 기반 종료를 우선하고, 실패 시에만 pkill -f fallback을 사용한다.
+// killRunningTask는 등록된 실행 작업의 AI 프로세스를 종료한다.
+// 작업을 시작한 백엔드의 Cancel이 있으면 그것을 사용하고, 없으면 PID의 프로세스를 종료한다.
 func killRunningTask(task *RunningTask) {
 	if task == nil {
 		return
 	}
-	if task.PID > 0 {
-		exec.Command("kill", "-TERM", strconv.Itoa(task.PID)).Run()
-		if !isProcessRunning(task.PID) {
-			return
+	if task.Cancel != nil {
+		if err := task.Cancel(); err != nil {
+			logger.Debug("killRunningTask: cancel %s failed: %v", task.TaskID, err)
 		}
+		return
 	}
-	if task.ScriptPath != "" {
-		cleaned := filepath.Clean(task.ScriptPath)
-		if filepath.IsAbs(cleaned) {
-			exec.Command("pkill", "-f", cleaned).Run()
-		}
+	if task.PID <= 0 {
+		return
 	}
-}
-
-// isProcessRunning은 주어진 PID 프로세스가 실행 중인지 확인한다.
-func isProcessRunning(pid int) bool {
-	if pid <= 0 {
-		return false
+	if err := adapter.TerminateProcess(task.PID); err != nil {
+		logger.Debug("killRunningTask: terminate PID %d failed: %v", task.PID, err)
 	}
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid))
-	return cmd.Run() == nil
 }
 
-// parseClaudeExitCodeFromLog는 Claude 로그 파일에서 종료 코드를 추출한다.
-func parseClaudeExitCodeFromLog(logPath string) (int, bool) {
-	if strings.TrimSpace(logPath) == "" {
-		return 0, false
-	}
-
-	raw, err := os.ReadFile(logPath)
-	if err != nil {
-		return 0, false
+// readRunStatus는 실행을 시작한 백엔드에서 실행 상태를 읽는다.
+func readRunStatus(analyzer port.AIAnalyzer, statusPath string) (*domain.AIRunStatus, error) {
+	if analyzer == nil {
+		return nil, errors.New("analyzer is nil")
 	}
-
-	marker := "Claude exited with code:"
-	idx := strings.LastIndex(string(raw), marker)
-	if idx < 0 {
-		return 0, false
+	status, err := analyzer.RunStatus(&domain.AIRun{StatusPath: statusPath})
+	if err == nil && status == nil {
+		err = fmt.Errorf("run status is unavailable: %s", statusPath)
 	}
+	return status, err
+}
 
-	rest := strings.TrimSpace(string(raw)[idx+len(marker):])
-	if rest == "" {
-		return 0, false
-	}
-	line := rest
-	if nl := strings.Index(line, "\n"); nl >= 0 {
-		line = line[:nl]
-	}
-	fields := strings.Fields(line)
-	if len(fields) == 0 {
-		return 0, false
+// isRunFinished는 실행 종료(결과 파일 조립 포함)가 기록되었는지 확인한다.
+// 상태를 읽을 수 없으면 프로세스 존재 여부로 판단한다.
+func isRunFinished(analyzer port.AIAnalyzer, statusPath string, pid int) bool {
+	if status, err := readRunStatus(analyzer, statusPath); err == nil {
+		return status.Finished
 	}
+	return !adapter.ProcessRunning(pid)
+}
 
-	exitCode, convErr := strconv.Atoi(fields[0])
-	if convErr != nil {
-		return 0, false
+// runSessionID는 실행에 기록된 CLI 세션 ID를 반환한다 (읽지 못하거나 기록되지 않았으면 빈 문자열).
+func runSessionID(analyzer port.AIAnalyzer, statusPath string) string {
+	status, err := readRunStatus(analyzer, statusPath)
+	if err != nil {
+		return ""
 	}
+	return status.SessionID
+}
 
-	return exitCode, true
+// runSessionExpired는 이어서 실행하려던 세션을 CLI가 찾지 못해 실패했는지 확인한다.
+func runSessionExpired(analyzer port.AIAnalyzer, statusPath string) bool {
+	status, err := readRunStatus(analyzer, statusPath)
+	return err == nil && status.SessionExpired
 }
 
 // extractClaudeFailureReason은 로그 파일에서 실패 원인 후보를 추출한다.
@@ -488,20 +525,79 @@ func extractClaudeFailureReason(logPath string) string {
 	return reason
 }
 
-// waitForTaskResult는 프로세스 종료와 결과 파일 생성을 동시에 확인한다.
-func waitForTaskResult(task *RunningTask, outputPath string) error {
+// runEventTailer는 실행 상태가 가리키는 stream-json 출력에서 새 이벤트를 읽어 전달한다.
+type runEventTailer struct {
+	analyzer   port.AIAnalyzer
+	statusPath string
+	onEvent    func(adapter.StreamEvent)
+	tailer     *adapter.StreamTailer
+}
+
+func newRunEventTailer(analyzer port.AIAnalyzer, statusPath string, onEvent func(adapter.StreamEvent)) *runEventTailer {
+	return &runEventTailer{analyzer: analyzer, statusPath: statusPath, onEvent: onEvent}
+}
+
+// poll은 마지막 호출 이후 출력된 이벤트를 전달하고 마지막 이벤트를 반환한다 (없으면 ok=false).
+func (r *runEventTailer) poll() (last adapter.StreamEvent, ok bool) {
+	if r.tailer == nil {
+		status, err := readRunStatus(r.analyzer, r.statusPath)
+		if err != nil || status.OutputFormat != adapter.OutputFormatStreamJSON {
+			return last, false
+		}
+		r.tailer = adapter.NewStreamTailer(status.StdoutPath)
+	}
+	for _, event := range r.tailer.Poll() {
+		if r.onEvent != nil {
+			r.onEvent(event)
+		}
+		last, ok = event, true
+	}
+	return last, ok
+}
+
+// claudeEventReporter는 Claude 실행 이벤트를 채널 로그(EventLogAdded)와 진행 상황 메시지(EventProgressUpdate)로 발행한다.
+func claudeEventReporter(v2 *AppV2State, channelIndex int, issueKey string, phase state.ProcessPhase) func(adapter.StreamEvent) {
+	return func(event adapter.StreamEvent) {
+		if v2 == nil || v2.appState == nil {
+			return
+		}
+		message := fmt.Sprintf("%s %s", issueKey, event.Summary())
+		level := state.LogInfo
+		switch event.Kind {
+		case adapter.StreamEventInit, adapter.StreamEventToolResult:
+			level = state.LogDebug
+			if event.IsError {
+				level = state.LogWarning
+			}
+		case adapter.StreamEventResult:
+			if event.IsError {
+				level = state.LogError
+			}
+		}
+		v2.appState.AddLog(channelIndex, level, message, "Claude")
+		if event.Kind == adapter.StreamEventToolUse || event.Kind == adapter.StreamEventText {
+			v2.appState.EventBus.PublishProgress(channelIndex, phase, 0, 0, message)
+		}
+	}
+}
+
+// waitForTaskResult는 상태 파일로 실행 종료를 기다린 뒤 결과 파일과 종료 코드를 확인한다.
+// stream-json으로 실행 중이면 그동안 출력된 이벤트를 onEvent로 전달한다 (nil이면 무시).
+func waitForTaskResult(task *RunningTask, outputPath string, onEvent func(adapter.StreamEvent)) error {
 	deadline := time.Now().Add(phaseTaskTimeout)
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
+	events := newRunEventTailer(task.Analyzer, task.StatusPath, onEvent)
 	for range ticker.C {
 		if task.CancelRequested {
 			killRunningTask(task)
 			return errTaskCancelled
 		}
 
-		if !isProcessRunning(task.PID) {
-			time.Sleep(500 * time.Millisecond)
+		finished := isRunFinished(task.Analyzer, task.StatusPath, task.PID)
+		events.poll()
+		if finished {
 			break
 		}
 
@@ -527,11 +623,15 @@ func waitForTaskResult(task *RunningTask, outputPath string) error {
 		}
 		return fmt.Errorf("결과 파일 읽기 실패: %w", err)
 	}
-	if exitCode, ok := parseClaudeExitCodeFromLog(task.LogPath); ok && exitCode != 0 {
-		if reason := extractClaudeFailureReason(task.LogPath); reason != "" {
-			return fmt.Errorf("Claude 실행 실패(exit=%d): %s", exitCode, reason)
+	if status, err := readRunStatus(task.Analyzer, task.StatusPath); err == nil && status.Failed() {
+		reason := extractClaudeFailureReason(task.LogPath)
+		if reason == "" {
+			reason = status.Error
+		}
+		if reason != "" {
+			return fmt.Errorf("Claude 실행 실패(exit=%d): %s", status.ExitCode, reason)
 		}
-		return fmt.Errorf("Claude 실행 실패(exit=%d)", exitCode)
+		return fmt.Errorf("Claude 실행 실패(exit=%d)", status.ExitCode)
 	}
 
 	return nil
@@ -593,6 +693,37 @@ func (a *App) resolvePlanPathForIssue(record *domain.IssueRecord) string {
 	return ""
 }
 
+// resolvePlanSessionIDForIssue는 planPath를 만든 2차(플랜 생성) 실행의 CLI 세션 ID를 반환한다.
+// 가장 최근의 해당 플랜 생성 결과만 보며, 세션 ID가 기록되지 않았으면 빈 문자열을 반환한다.
+func (a *App) resolvePlanSessionIDForIssue(record *domain.IssueRecord, planPath string) string {
+	if record == nil || a.analysisStore == nil {
+		return ""
+	}
+	results, err := a.analysisStore.ListAnalysisResultsByIssue(record.ID)
+	if err != nil {
+		return ""
+	}
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		if r.AnalysisPhase == 1 && r.Status == "completed" && r.PlanPath == planPath {
+			return r.SessionID
+		}
+	}
+	return ""
+}
+
+// startPhase3Run은 sessionID가 있으면 2차 세션을 이어서 계획을 실행하고,
+// 세션이 없거나 백엔드가 세션 재개를 지원하지 않으면 플랜 전체를 새 세션으로 전달한다.
+func startPhase3Run(analyzer port.AIAnalyzer, planPath, sessionID, workDir string) (*domain.AIRun, error) {
+	if sessionID != "" {
+		run, err := analyzer.ResumePlan(planPath, sessionID, workDir)
+		if !errors.Is(err, domain.ErrSessionUnsupported) {
+			return run, err
+		}
+	}
+	return analyzer.ExecutePlan(planPath, workDir)
+}
+
 // runPhase2BatchV2는 선택된 1차 완료 항목들을 병렬로 2차 실행한다.
 func (a *App) runPhase2BatchV2(channelIndex int, records []*domain.IssueRecord, v2 *AppV2State) {
 	a.runPhaseBatchV2(channelIndex, records, "2차", v2)
@@ -618,6 +749,15 @@ func (a *App) runPhaseBatchV2(channelIndex int, records []*domain.IssueRecord, p
 		return
 	}
 
+	if err := a.checkDailyBudget(); err != nil {
+		fyne.Do(func() {
+			v2.appState.FailJob(channelIndex, "", err)
+			v2.progressPanels[channelIndex].SetError("일일 비용 한도 초과")
+		})
+		v2.appState.AddLog(channelIndex, state.LogError, fmt.Sprintf("%s 실행 중단: %v", phaseLabel, err), "App")
+		return
+	}
+
 	if phaseLabel == "2차" {
 		v2.appState.UpdatePhase(channelIndex, state.PhaseAIPlanGeneration)
 	} else {
@@ -703,11 +843,17 @@ func (a *App) runPhase2RecordV2(channelIndex int, record *domain.IssueRecord, wo
 		return outcome
 	}
 
-	var result *adapter.PlanResult
+	var result *domain.AIRun
 	var err error
+	analyzer := a.analyzer()
 	hookRetryCount := 0
 	for {
-		result, err = a.claudeAdapter.AnalyzeAndGeneratePlan(record.MDPath, a.config.AI.PromptTemplate, workDir)
+		// 병렬 실행·재시도 중에도 한도를 넘으면 새 실행을 시작하지 않는다
+		if budgetErr := a.checkDailyBudget(); budgetErr != nil {
+			outcome.err = budgetErr
+			return outcome
+		}
+		result, err = analyzer.GeneratePlan(record.MDPath, a.config.AI.PromptTemplate, workDir)
 		if err == nil {
 			task := &RunningTask{
 				TaskID:       fmt.Sprintf("phase2:%d:%d", channelIndex, record.ID),
@@ -716,15 +862,20 @@ func (a *App) runPhase2RecordV2(channelIndex int, record *domain.IssueRecord, wo
 				ChannelIndex: channelIndex,
 				PhaseLabel:   "2차",
 				PID:          result.PID,
-				ScriptPath:   result.ScriptPath,
+				StatusPath:   result.StatusPath,
 				LogPath:      result.LogPath,
+				Analyzer:     analyzer,
+				Cancel:       cancelFunc(analyzer, result),
 			}
 			a.registerRunningTask(task)
-			waitErr := waitForTaskResult(task, result.PlanPath)
+			waitErr := waitForTaskResult(task, result.OutputPath, claudeEventReporter(v2, channelIndex, record.IssueKey, state.PhaseAIPlanGeneration))
 			a.unregisterRunningTask(channelIndex, task.TaskID)
 			if waitErr == nil {
 				break
 			}
+			if !errors.Is(waitErr, errTaskCancelled) {
+				a.recordFailedRun(task, 1, waitErr)
+			}
 			if isHookRelatedError(waitErr) && hookRetryCount < maxHookRetries && a.askRetryForHookFailure(record.IssueKey, "2차", waitErr) {
 				hookRetryCount++
 				continue
@@ -751,25 +902,28 @@ func (a *App) runPhase2RecordV2(channelIndex int, record *domain.IssueRecord, wo
 		if createErr := a.analysisStore.CreateAnalysisResult(&domain.AnalysisResult{
 			IssueID:       record.ID,
 			AnalysisPhase: 1,
-			ResultPath:    result.PlanPath,
-			PlanPath:      result.PlanPath,
+			ResultPath:    result.OutputPath,
+			PlanPath:      result.OutputPath,
 			Status:        "completed",
 			CompletedAt:   &now,
+			Usage:         runUsage(analyzer, result.StatusPath),
+			SessionID:     runSessionID(analyzer, result.StatusPath),
 		}); createErr != nil {
 			logger.Debug("runPhase2RecordV2: CreateAnalysisResult failed: %v", createErr)
 		}
 	}
+	a.autoAttachResultV2(channelIndex, record.IssueKey, result.OutputPath, v2)
 
-	outcome.planPath = result.PlanPath
-	analysisContent := fmt.Sprintf("AI 플랜 생성 완료\n이슈: %s\n경로: %s", record.IssueKey, result.PlanPath)
-	if raw, readErr := os.ReadFile(result.PlanPath); readErr == nil {
+	outcome.planPath = result.OutputPath
+	analysisContent := fmt.Sprintf("AI 플랜 생성 완료\n이슈: %s\n경로: %s", record.IssueKey, result.OutputPath)
+	if raw, readErr := os.ReadFile(result.OutputPath); readErr == nil {
 		analysisContent = string(raw)
 	}
 	fyne.Do(func() {
 		v2.resultPanels[channelIndex].SetAnalysis(analysisContent)
 		a.channels[channelIndex].AnalysisText.SetText(v2.resultPanels[channelIndex].GetAnalysis())
-		a.channels[channelIndex].CurrentPlanPath = result.PlanPath
-		a.channels[channelIndex].CurrentAnalysisPath = result.PlanPath
+		a.channels[channelIndex].CurrentPlanPath = result.OutputPath
+		a.channels[channelIndex].CurrentAnalysisPath = result.OutputPath
 	})
 
 	v2.appState.EventBus.Publish(state.Event{
@@ -794,11 +948,26 @@ func (a *App) runPhase3RecordV2(channelIndex int, record *domain.IssueRecord, wo
 		return outcome
 	}
 
-	var result *adapter.AnalysisResult
+	// 2차 세션을 이어서 실행하면 분석한 맥락을 그대로 쓰므로 플랜 전체를 다시 읽지 않는다
+	sessionID := ""
+	if a.config.Claude.ResumeSession {
+		sessionID = a.resolvePlanSessionIDForIssue(record, planPath)
+	}
+
+	var result *domain.AIRun
 	var err error
+	analyzer := a.analyzer()
 	hookRetryCount := 0
 	for {
-		result, err = a.claudeAdapter.ExecutePlan(planPath, workDir)
+		// 병렬 실행·재시도 중에도 한도를 넘으면 새 실행을 시작하지 않는다
+		if budgetErr := a.checkDailyBudget(); budgetErr != nil {
+			outcome.err = budgetErr
+			return outcome
+		}
+		result, err = startPhase3Run(analyzer, planPath, sessionID, workDir)
+		if err == nil && result.ResumedSession != "" {
+			v2.appState.AddLog(channelIndex, state.LogInfo, fmt.Sprintf("%s: 2차 세션 %s을 이어서 실행합니다", record.IssueKey, result.ResumedSession), "App")
+		}
 		if err == nil {
 			task := &RunningTask{
 				TaskID:       fmt.Sprintf("phase3:%d:%d", channelIndex, record.ID),
@@ -807,15 +976,26 @@ func (a *App) runPhase3RecordV2(channelIndex int, record *domain.IssueRecord, wo
 				ChannelIndex: channelIndex,
 				PhaseLabel:   "3차",
 				PID:          result.PID,
-				ScriptPath:   result.ScriptPath,
-				LogPath:      strings.TrimSuffix(result.OutputPath, "_execution.md") + "_exec_log.txt",
+				StatusPath:   result.StatusPath,
+				LogPath:      result.LogPath,
+				Analyzer:     analyzer,
+				Cancel:       cancelFunc(analyzer, result),
 			}
 			a.registerRunningTask(task)
-			waitErr := waitForTaskResult(task, result.OutputPath)
+			waitErr := waitForTaskResult(task, result.OutputPath, claudeEventReporter(v2, channelIndex, record.IssueKey, state.PhaseAIExecution))
 			a.unregisterRunningTask(channelIndex, task.TaskID)
 			if waitErr == nil {
 				break
 			}
+			// 세션이 없거나 만료되었으면 플랜 전체를 새 세션으로 다시 전달한다 (다른 실패는 그대로 실패 처리)
+			if result.ResumedSession != "" && !errors.Is(waitErr, errTaskCancelled) && runSessionExpired(analyzer, result.StatusPath) {
+				v2.appState.AddLog(channelIndex, state.LogWarning, fmt.Sprintf("%s: 2차 세션 %s을 찾을 수 없어 플랜 전체를 새 세션으로 전달합니다", record.IssueKey, result.ResumedSession), "App")
+				sessionID = ""
+				continue
+			}
+			if !errors.Is(waitErr, errTaskCancelled) {
+				a.recordFailedRun(task, 2, waitErr)
+			}
 			if isHookRelatedError(waitErr) && hookRetryCount < maxHookRetries && a.askRetryForHookFailure(record.IssueKey, "3차", waitErr) {
 				hookRetryCount++
 				continue
@@ -847,10 +1027,13 @@ func (a *App) runPhase3RecordV2(channelIndex int, record *domain.IssueRecord, wo
 			ExecutionPath: result.OutputPath,
 			Status:        "completed",
 			CompletedAt:   &now,
+			Usage:         runUsage(analyzer, result.StatusPath),
+			SessionID:     runSessionID(analyzer, result.StatusPath),
 		}); createErr != nil {
 			logger.Debug("runPhase3RecordV2: CreateAnalysisResult failed: %v", createErr)
 		}
 	}
+	a.autoAttachResultV2(channelIndex, record.IssueKey, result.OutputPath, v2)
 
 	outcome.executionPath = result.OutputPath
 	analysisContent := fmt.Sprintf("AI 실행 완료\n이슈: %s\n출력: %s", record.IssueKey, result.OutputPath)
@@ -2,29 +2,39 @@ package ui
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"jira-ai-generator/internal/adapter"
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
 )
 
-// TestParseClaudeExitCodeFromLog는 로그에서 Claude 종료코드를 정상 파싱하는지 검증한다.
-func TestParseClaudeExitCodeFromLog(t *testing.T) {
-	tempDir := t.TempDir()
-	logPath := filepath.Join(tempDir, "claude.log")
-	logContent := "[2026-02-11 10:00:00] Running Claude...\nClaude exited with code: 7\n"
-	if err := os.WriteFile(logPath, []byte(logContent), 0644); err != nil {
-		t.Fatalf("failed to write log file: %v", err)
+// TestIsRunFinished는 상태 파일의 종료 기록으로 실행 완료를 판정하는지 검증한다.
+func TestIsRunFinished(t *testing.T) {
+	statusPath := filepath.Join(t.TempDir(), "TEST-101_plan_status.json")
+	writeStatus := func(finished bool) {
+		raw := fmt.Sprintf(`{"pid": %d, "finished": %t}`, os.Getpid(), finished)
+		if err := os.WriteFile(statusPath, []byte(raw), 0644); err != nil {
+			t.Fatalf("failed to write status file: %v", err)
+		}
 	}
 
-	exitCode, ok := parseClaudeExitCodeFromLog(logPath)
-	if !ok {
-		t.Fatal("expected parse result to be valid")
+	analyzer := adapter.NewFakeAIAnalyzer("")
+	writeStatus(false)
+	if isRunFinished(analyzer, statusPath, os.Getpid()) {
+		t.Fatal("expected running status to be unfinished")
+	}
+	writeStatus(true)
+	if !isRunFinished(analyzer, statusPath, os.Getpid()) {
+		t.Fatal("expected finished status to be finished")
 	}
-	if exitCode != 7 {
-		t.Fatalf("expected exitCode=7, got %d", exitCode)
+	// 상태 파일이 없으면 프로세스 존재 여부로 판단한다.
+	if !isRunFinished(analyzer, filepath.Join(t.TempDir(), "missing.json"), 0) {
+		t.Fatal("expected missing status with no process to be finished")
 	}
 }
 
@@ -32,29 +42,36 @@ func TestParseClaudeExitCodeFromLog(t *testing.T) {
 func TestWaitForTaskResult_ReturnsErrorOnNonZeroClaudeExit(t *testing.T) {
 	tempDir := t.TempDir()
 	outputPath := filepath.Join(tempDir, "TEST-101_plan.md")
-	logPath := filepath.Join(tempDir, "TEST-101_plan_log.txt")
+	statusPath := filepath.Join(tempDir, "TEST-101_plan_status.json")
+	logPath := filepath.Join(tempDir, "TEST-101_plan_stderr.txt")
 
 	if err := os.WriteFile(outputPath, []byte("# output"), 0644); err != nil {
 		t.Fatalf("failed to write output file: %v", err)
 	}
-	logContent := "some text\nClaude exited with code: 2\nHook validation failed: denied\n"
-	if err := os.WriteFile(logPath, []byte(logContent), 0644); err != nil {
+	if err := os.WriteFile(statusPath, []byte(`{"pid": 0, "exit_code": 2, "finished": true}`), 0644); err != nil {
+		t.Fatalf("failed to write status file: %v", err)
+	}
+	if err := os.WriteFile(logPath, []byte("some text\nHook validation failed: denied\n"), 0644); err != nil {
 		t.Fatalf("failed to write log file: %v", err)
 	}
 
 	task := &RunningTask{
-		IssueKey: "TEST-101",
-		PID:      0, // PID<=0이면 실행 중이 아닌 상태로 즉시 결과 판정을 수행한다.
-		LogPath:  logPath,
+		IssueKey:   "TEST-101",
+		StatusPath: statusPath,
+		LogPath:    logPath,
+		Analyzer:   adapter.NewFakeAIAnalyzer(""),
 	}
 
-	err := waitForTaskResult(task, outputPath)
+	err := waitForTaskResult(task, outputPath, nil)
 	if err == nil {
 		t.Fatal("expected waitForTaskResult to return error")
 	}
 	if !strings.Contains(err.Error(), "exit=2") {
 		t.Fatalf("expected exit code in error message, got: %v", err)
 	}
+	if !strings.Contains(err.Error(), "Hook validation failed") {
+		t.Fatalf("expected stderr reason in error message, got: %v", err)
+	}
 }
 
 // TestIsHookRelatedError는 Hook 설정/런타임 오류 판별 규칙을 검증한다.
@@ -70,3 +87,101 @@ func TestIsHookRelatedError(t *testing.T) {
 	}
 }
 
+
+// TestWaitForTaskResult_FakeAnalyzer는 실제 CLI 없이 가짜 백엔드의 실행 결과를 기다리고 취소하는지 검증한다.
+func TestWaitForTaskResult_FakeAnalyzer(t *testing.T) {
+	tempDir := t.TempDir()
+	mdPath := filepath.Join(tempDir, "TEST-102.md")
+	if err := os.WriteFile(mdPath, []byte("# TEST-102\n"), 0644); err != nil {
+		t.Fatalf("failed to write md file: %v", err)
+	}
+
+	fake := adapter.NewFakeAIAnalyzer("### ISSUE_SUMMARY\n가짜 분석\n")
+	app := &App{aiAnalyzer: fake}
+
+	run, err := app.analyzer().GeneratePlan(mdPath, "prompt", tempDir)
+	if err != nil {
+		t.Fatalf("GeneratePlan failed: %v", err)
+	}
+	task := &RunningTask{IssueKey: "TEST-102", StatusPath: run.StatusPath, LogPath: run.LogPath, Analyzer: fake, Cancel: cancelFunc(fake, run)}
+	if err := waitForTaskResult(task, run.OutputPath, nil); err != nil {
+		t.Fatalf("expected fake run to succeed, got: %v", err)
+	}
+
+	fake.Hold = true
+	run, err = app.analyzer().ExecutePlan(run.OutputPath, tempDir)
+	if err != nil {
+		t.Fatalf("ExecutePlan failed: %v", err)
+	}
+	task = &RunningTask{IssueKey: "TEST-102", StatusPath: run.StatusPath, LogPath: run.LogPath, Analyzer: fake, Cancel: cancelFunc(fake, run), CancelRequested: true}
+	if err := waitForTaskResult(task, run.OutputPath, nil); !errors.Is(err, errTaskCancelled) {
+		t.Fatalf("expected errTaskCancelled, got: %v", err)
+	}
+	if cancelled := fake.Cancelled(); len(cancelled) != 1 || cancelled[0] != run {
+		t.Fatalf("expected the held run to be cancelled through the analyzer, got: %+v", cancelled)
+	}
+}
+
+// TestResolvePlanSessionIDForIssue는 같은 플랜을 만든 최근 2차 실행의 세션 ID만 사용하는지 검증한다.
+func TestResolvePlanSessionIDForIssue(t *testing.T) {
+	results := []*domain.AnalysisResult{
+		{AnalysisPhase: 1, Status: "completed", PlanPath: "/out/A_plan.md", SessionID: "old"},
+		{AnalysisPhase: 1, Status: "failed", PlanPath: "/out/A_plan.md", SessionID: "failed"},
+		{AnalysisPhase: 1, Status: "completed", PlanPath: "/out/A_plan.md", SessionID: "plan"},
+		{AnalysisPhase: 2, Status: "completed", PlanPath: "/out/A_plan.md", SessionID: "exec"},
+	}
+	app := &App{analysisStore: &mock.AnalysisResultStore{
+		ListAnalysisResultsByIssueFunc: func(issueID int64) ([]*domain.AnalysisResult, error) { return results, nil },
+	}}
+	record := &domain.IssueRecord{ID: 1, IssueKey: "A"}
+
+	if got := app.resolvePlanSessionIDForIssue(record, "/out/A_plan.md"); got != "plan" {
+		t.Fatalf("expected latest completed plan session, got %q", got)
+	}
+	if got := app.resolvePlanSessionIDForIssue(record, "/other/A_plan.md"); got != "" {
+		t.Fatalf("expected no session for another plan file, got %q", got)
+	}
+}
+
+// TestStartPhase3Run_SessionFallback는 세션 재개와 만료·미지원 시 플랜 재전송 흐름을 검증한다.
+func TestStartPhase3Run_SessionFallback(t *testing.T) {
+	tempDir := t.TempDir()
+	mdPath := filepath.Join(tempDir, "TEST-103.md")
+	if err := os.WriteFile(mdPath, []byte("# TEST-103\n"), 0644); err != nil {
+		t.Fatalf("failed to write md file: %v", err)
+	}
+
+	fake := adapter.NewFakeAIAnalyzer("ok")
+	plan, err := fake.GeneratePlan(mdPath, "prompt", tempDir)
+	if err != nil {
+		t.Fatalf("GeneratePlan failed: %v", err)
+	}
+	sessionID := runSessionID(fake, plan.StatusPath)
+	if sessionID == "" {
+		t.Fatal("expected plan run to record a session ID")
+	}
+
+	run, err := startPhase3Run(fake, plan.OutputPath, sessionID, tempDir)
+	if err != nil || run.ResumedSession != sessionID || runSessionExpired(fake, run.StatusPath) {
+		t.Fatalf("expected resumed run, got %+v (err %v)", run, err)
+	}
+
+	run, err = startPhase3Run(fake, plan.OutputPath, "expired", tempDir)
+	if err != nil || !runSessionExpired(fake, run.StatusPath) {
+		t.Fatalf("expected expired session to be recorded, got %+v (err %v)", run, err)
+	}
+
+	// 세션을 지원하지 않는 백엔드는 플랜 전체를 새 세션으로 실행한다
+	unsupported := &mock.AIAnalyzer{
+		ResumePlanFunc: func(planPath, sessionID, workDir string) (*domain.AIRun, error) {
+			return nil, domain.ErrSessionUnsupported
+		},
+		ExecutePlanFunc: func(planPath, workDir string) (*domain.AIRun, error) {
+			return &domain.AIRun{OutputPath: "new-session"}, nil
+		},
+	}
+	run, err = startPhase3Run(unsupported, plan.OutputPath, sessionID, tempDir)
+	if err != nil || run.OutputPath != "new-session" || run.ResumedSession != "" {
+		t.Fatalf("expected fallback to ExecutePlan, got %+v (err %v)", run, err)
+	}
+}
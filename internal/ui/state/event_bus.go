@@ -34,6 +34,7 @@ const (
 	EventDBSync             EventType = "db.sync"              // DB 동기화 완료
 	EventIssueListRefresh   EventType = "issue.list.refresh"   // 이슈 목록 갱신 필요
 	EventIssueDeleteRequest EventType = "issue.delete.request" // 이슈 삭제 요청
+	EventJiraCommentRequest EventType = "jira.comment.request" // 분석 결과 Jira 코멘트 게시 요청
 )
 
 // ProcessPhase 처리 단계 정의
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/port"
+)
+
+// AttachResultUseCase handles uploading generated analysis files to Jira as attachments
+type AttachResultUseCase struct {
+	jiraRepo port.JiraRepository
+}
+
+// NewAttachResultUseCase creates a new AttachResultUseCase
+func NewAttachResultUseCase(jiraRepo port.JiraRepository) *AttachResultUseCase {
+	return &AttachResultUseCase{
+		jiraRepo: jiraRepo,
+	}
+}
+
+// Execute는 로컬 결과 파일(_plan.md / _execution.md)을 이슈 첨부파일로 업로드한다.
+func (uc *AttachResultUseCase) Execute(ctx context.Context, issueKey, path string) (*domain.Attachment, error) {
+	if issueKey == "" {
+		return nil, fmt.Errorf("issue key is empty")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("result path is empty")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result file: %w", err)
+	}
+	defer file.Close()
+
+	attachment, err := uc.jiraRepo.UploadAttachment(ctx, issueKey, filepath.Base(path), file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload result file: %w", err)
+	}
+	if attachment == nil {
+		return nil, fmt.Errorf("failed to upload result file: no attachment returned")
+	}
+
+	return attachment, nil
+}
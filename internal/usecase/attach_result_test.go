@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
+	"jira-ai-generator/internal/usecase"
+)
+
+func TestAttachResultUseCase_Execute_UploadsFile(t *testing.T) {
+	path := writeResultFile(t, "TEST-1_plan.md", "# Plan")
+
+	var gotKey, gotName, gotContent string
+	jira := &mock.JiraRepository{
+		UploadAttachmentFunc: func(_ context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error) {
+			data, _ := io.ReadAll(content)
+			gotKey, gotName, gotContent = issueKey, filename, string(data)
+			return &domain.Attachment{ID: "200", Filename: filename}, nil
+		},
+	}
+
+	uc := usecase.NewAttachResultUseCase(jira)
+	attachment, err := uc.Execute(context.Background(), "TEST-1", path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attachment.ID != "200" {
+		t.Errorf("expected attachment ID 200, got %s", attachment.ID)
+	}
+	if gotKey != "TEST-1" || gotName != "TEST-1_plan.md" || gotContent != "# Plan" {
+		t.Errorf("unexpected upload: key=%s name=%s content=%q", gotKey, gotName, gotContent)
+	}
+}
+
+func TestAttachResultUseCase_Execute_DefaultMockReturnsAttachment(t *testing.T) {
+	uc := usecase.NewAttachResultUseCase(&mock.JiraRepository{})
+	attachment, err := uc.Execute(context.Background(), "TEST-1", writeResultFile(t, "TEST-1_execution.md", "# Exec"))
+	if err != nil || attachment == nil {
+		t.Fatalf("expected attachment from default mock, got %v, %v", attachment, err)
+	}
+	if attachment.Filename != "TEST-1_execution.md" {
+		t.Errorf("expected uploaded filename, got %q", attachment.Filename)
+	}
+}
+
+func TestAttachResultUseCase_Execute_Errors(t *testing.T) {
+	jira := &mock.JiraRepository{
+		UploadAttachmentFunc: func(_ context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error) {
+			return nil, errors.New("forbidden")
+		},
+	}
+	uc := usecase.NewAttachResultUseCase(jira)
+
+	if _, err := uc.Execute(context.Background(), "TEST-1", "/nonexistent/TEST-1_plan.md"); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := uc.Execute(context.Background(), "TEST-1", writeResultFile(t, "x.md", "x")); err == nil {
+		t.Error("expected upload error to be returned")
+	}
+
+	nilUpload := usecase.NewAttachResultUseCase(&mock.JiraRepository{
+		UploadAttachmentFunc: func(_ context.Context, issueKey, filename string, content io.Reader) (*domain.Attachment, error) {
+			return nil, nil
+		},
+	})
+	if _, err := nilUpload.Execute(context.Background(), "TEST-1", writeResultFile(t, "y.md", "y")); err == nil {
+		t.Error("expected error when upload returns no attachment")
+	}
+}
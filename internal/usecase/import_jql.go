@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/port"
+)
+
+const (
+	// jqlSearchPageSize는 JQL 검색 1회 요청당 조회할 이슈 수다 (Jira Cloud 최대값 100).
+	jqlSearchPageSize = 100
+)
+
+// JQLImportUseCase handles bulk import of issues matched by a JQL query
+type JQLImportUseCase struct {
+	jiraRepo     port.JiraRepository
+	processIssue *ProcessIssueUseCase
+}
+
+// NewJQLImportUseCase creates a new JQLImportUseCase
+func NewJQLImportUseCase(jiraRepo port.JiraRepository, processIssue *ProcessIssueUseCase) *JQLImportUseCase {
+	return &JQLImportUseCase{
+		jiraRepo:     jiraRepo,
+		processIssue: processIssue,
+	}
+}
+
+// JQLImportItem은 채널에 배정된 단일 이슈의 1차 처리 결과다.
+type JQLImportItem struct {
+	Issue        domain.JiraIssue
+	ChannelIndex int
+	Result       *domain.ProcessResult
+	Err          error
+}
+
+// JQLImportProgressCallback은 채널별 개별 이슈 진행률을 보고한다.
+type JQLImportProgressCallback func(channelIndex int, issueKey string, progress float64, status string)
+
+// SearchAll은 JQL 검색 결과를 페이지 단위로 모두 조회한다. maxIssues가 0 이하이면 전체를 조회한다.
+func (uc *JQLImportUseCase) SearchAll(ctx context.Context, jql string, maxIssues int) ([]domain.JiraIssue, error) {
+	jql = strings.TrimSpace(jql)
+	if jql == "" {
+		return nil, fmt.Errorf("JQL이 비어 있습니다")
+	}
+
+	var issues []domain.JiraIssue
+	startAt := 0
+	for {
+		pageSize := jqlSearchPageSize
+		if maxIssues > 0 && maxIssues-len(issues) < pageSize {
+			pageSize = maxIssues - len(issues)
+		}
+
+		page, err := uc.jiraRepo.SearchIssues(ctx, jql, startAt, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+		if page == nil || len(page.Issues) == 0 {
+			break
+		}
+
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+
+		if maxIssues > 0 && len(issues) >= maxIssues {
+			issues = issues[:maxIssues]
+			break
+		}
+		if startAt >= page.Total {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+// AssignChannels는 이슈를 채널 수만큼 라운드로빈으로 분배한다.
+func AssignChannels(issues []domain.JiraIssue, channelCount int) [][]domain.JiraIssue {
+	if channelCount <= 0 {
+		channelCount = 1
+	}
+	assigned := make([][]domain.JiraIssue, channelCount)
+	for i, issue := range issues {
+		channel := i % channelCount
+		assigned[channel] = append(assigned[channel], issue)
+	}
+	return assigned
+}
+
+// Execute는 JQL 검색 결과의 모든 이슈를 채널별로 분배하여 1차 처리한다.
+// 채널 간에는 병렬로, 채널 내부에서는 순차로 실행하며 항목이 끝날 때마다 onItem을 호출한다.
+// ctx가 취소되면 진행 중인 이슈를 중단하고 남은 이슈는 처리하지 않는다.
+func (uc *JQLImportUseCase) Execute(ctx context.Context, jql string, maxIssues, channelCount int, onProgress JQLImportProgressCallback, onItem func(JQLImportItem)) ([]JQLImportItem, error) {
+	issues, err := uc.SearchAll(ctx, jql, maxIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := AssignChannels(issues, channelCount)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	items := make([]JQLImportItem, 0, len(issues))
+
+	for channelIndex, channelIssues := range assigned {
+		if len(channelIssues) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(channel int, queue []domain.JiraIssue) {
+			defer wg.Done()
+			for _, issue := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				issueKey := issue.Key
+				result, execErr := uc.processIssue.Execute(ctx, issueKey, func(progress float64, status string) {
+					if onProgress != nil {
+						onProgress(channel, issueKey, progress, status)
+					}
+				})
+				item := JQLImportItem{
+					Issue:        issue,
+					ChannelIndex: channel,
+					Result:       result,
+					Err:          execErr,
+				}
+
+				mu.Lock()
+				items = append(items, item)
+				mu.Unlock()
+
+				if onItem != nil {
+					onItem(item)
+				}
+			}
+		}(channelIndex, channelIssues)
+	}
+
+	wg.Wait()
+	return items, ctx.Err()
+}
@@ -0,0 +1,155 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
+	"jira-ai-generator/internal/usecase"
+)
+
+// newPagedSearchMock은 total개의 이슈를 페이지 단위로 반환하는 Mock을 생성한다.
+func newPagedSearchMock(total int, calls *[]int) *mock.JiraRepository {
+	return &mock.JiraRepository{
+		SearchIssuesFunc: func(_ context.Context, jql string, startAt, maxResults int) (*domain.IssueSearchResult, error) {
+			if calls != nil {
+				*calls = append(*calls, startAt)
+			}
+			// 서버가 요청보다 작은 페이지를 돌려주는 상황을 재현한다.
+			if maxResults > 2 {
+				maxResults = 2
+			}
+			page := &domain.IssueSearchResult{StartAt: startAt, MaxResults: maxResults, Total: total}
+			for i := startAt; i < total && i < startAt+maxResults; i++ {
+				page.Issues = append(page.Issues, domain.JiraIssue{Key: fmt.Sprintf("TEST-%d", i+1)})
+			}
+			return page, nil
+		},
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{Key: issueKey, Summary: "summary " + issueKey}, nil
+		},
+	}
+}
+
+func newImportProcessUseCase(jira *mock.JiraRepository) *usecase.ProcessIssueUseCase {
+	return usecase.NewProcessIssueUseCase(
+		jira,
+		&mock.AttachmentDownloader{},
+		&mock.VideoProcessor{},
+		&mock.DocumentGenerator{
+			GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+				return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+			},
+			SaveToFileFunc: func(doc *domain.GeneratedDocument) (string, error) {
+				return "/output/" + doc.IssueKey + ".md", nil
+			},
+		},
+		"/output",
+	)
+}
+
+func TestJQLImportUseCase_SearchAll_Paginates(t *testing.T) {
+	var calls []int
+	jira := newPagedSearchMock(5, &calls)
+	uc := usecase.NewJQLImportUseCase(jira, newImportProcessUseCase(jira))
+
+	issues, err := uc.SearchAll(context.Background(), "sprint = 1 AND labels = ai-candidate", 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(issues) != 5 {
+		t.Fatalf("expected 5 issues, got %d", len(issues))
+	}
+	expectedCalls := []int{0, 2, 4}
+	if fmt.Sprint(calls) != fmt.Sprint(expectedCalls) {
+		t.Errorf("expected startAt sequence %v, got %v", expectedCalls, calls)
+	}
+}
+
+func TestJQLImportUseCase_SearchAll_RespectsMaxIssues(t *testing.T) {
+	jira := newPagedSearchMock(10, nil)
+	uc := usecase.NewJQLImportUseCase(jira, newImportProcessUseCase(jira))
+
+	issues, err := uc.SearchAll(context.Background(), "project = TEST", 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+}
+
+func TestJQLImportUseCase_SearchAll_EmptyJQL(t *testing.T) {
+	jira := newPagedSearchMock(1, nil)
+	uc := usecase.NewJQLImportUseCase(jira, newImportProcessUseCase(jira))
+
+	if _, err := uc.SearchAll(context.Background(), "   ", 0); err == nil {
+		t.Fatal("expected error for empty JQL")
+	}
+}
+
+func TestAssignChannels_RoundRobin(t *testing.T) {
+	issues := []domain.JiraIssue{{Key: "A-1"}, {Key: "A-2"}, {Key: "A-3"}, {Key: "A-4"}}
+
+	assigned := usecase.AssignChannels(issues, 3)
+
+	if len(assigned) != 3 {
+		t.Fatalf("expected 3 channels, got %d", len(assigned))
+	}
+	if len(assigned[0]) != 2 || assigned[0][1].Key != "A-4" {
+		t.Errorf("expected channel 0 to hold A-1 and A-4, got %v", assigned[0])
+	}
+	if len(assigned[1]) != 1 || len(assigned[2]) != 1 {
+		t.Errorf("expected channels 1 and 2 to hold one issue each, got %d/%d", len(assigned[1]), len(assigned[2]))
+	}
+}
+
+func TestJQLImportUseCase_Execute_ProcessesAllHits(t *testing.T) {
+	jira := newPagedSearchMock(4, nil)
+	jira.GetIssueFunc = func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+		if issueKey == "TEST-3" {
+			return nil, errors.New("not found")
+		}
+		return &domain.JiraIssue{Key: issueKey}, nil
+	}
+	uc := usecase.NewJQLImportUseCase(jira, newImportProcessUseCase(jira))
+
+	var mu sync.Mutex
+	var callbackKeys []string
+	items, err := uc.Execute(context.Background(), "project = TEST", 0, 3, nil, func(item usecase.JQLImportItem) {
+		mu.Lock()
+		callbackKeys = append(callbackKeys, item.Issue.Key)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+	sort.Strings(callbackKeys)
+	if fmt.Sprint(callbackKeys) != "[TEST-1 TEST-2 TEST-3 TEST-4]" {
+		t.Errorf("unexpected callback keys: %v", callbackKeys)
+	}
+
+	failed := 0
+	for _, item := range items {
+		if item.ChannelIndex < 0 || item.ChannelIndex >= 3 {
+			t.Errorf("unexpected channel index %d", item.ChannelIndex)
+		}
+		if item.Err != nil {
+			failed++
+			if item.Issue.Key != "TEST-3" {
+				t.Errorf("unexpected failure for %s: %v", item.Issue.Key, item.Err)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Errorf("expected exactly 1 failed item, got %d", failed)
+	}
+}
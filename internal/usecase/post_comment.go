@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/port"
+)
+
+const (
+	// AnalysisPhasePlan은 2차 분석(AI 플랜, _plan.md) 결과의 analysis_phase 값이다.
+	AnalysisPhasePlan = 1
+	// AnalysisPhaseExecution은 3차 분석(AI 실행, _execution.md) 결과의 analysis_phase 값이다.
+	AnalysisPhaseExecution = 2
+
+	// maxCommentLength는 Jira 코멘트 본문 최대 길이(32767자)보다 여유 있게 잡은 게시 한도다.
+	maxCommentLength = 30000
+)
+
+// PostCommentUseCase handles posting AI analysis results back to Jira as comments
+type PostCommentUseCase struct {
+	jiraRepo      port.JiraRepository
+	analysisStore port.AnalysisResultStore
+}
+
+// NewPostCommentUseCase creates a new PostCommentUseCase
+func NewPostCommentUseCase(jiraRepo port.JiraRepository, analysisStore port.AnalysisResultStore) *PostCommentUseCase {
+	return &PostCommentUseCase{
+		jiraRepo:      jiraRepo,
+		analysisStore: analysisStore,
+	}
+}
+
+// PostCommentResult는 코멘트 게시 결과다.
+type PostCommentResult struct {
+	CommentID string
+	Updated   bool // 기존 코멘트를 갱신했으면 true
+}
+
+// Execute는 이슈의 최신 플랜/실행 결과를 Jira 코멘트로 게시한다.
+// 같은 단계의 결과를 이전에 게시한 적이 있으면 새 코멘트 대신 기존 코멘트를 갱신한다.
+func (uc *PostCommentUseCase) Execute(ctx context.Context, issue *domain.IssueRecord, analysisPhase int) (*PostCommentResult, error) {
+	if issue == nil {
+		return nil, fmt.Errorf("issue is nil")
+	}
+	if analysisPhase != AnalysisPhasePlan && analysisPhase != AnalysisPhaseExecution {
+		return nil, fmt.Errorf("unsupported analysis phase: %d", analysisPhase)
+	}
+
+	results, err := uc.analysisStore.ListAnalysisResultsByIssue(issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list analysis results: %w", err)
+	}
+
+	target, existingCommentID := selectCommentTarget(results, analysisPhase)
+	if target == nil {
+		return nil, fmt.Errorf("%s 결과가 없습니다: %s", analysisPhaseLabel(analysisPhase), issue.IssueKey)
+	}
+
+	resultPath := analysisResultPath(target, analysisPhase)
+	raw, err := os.ReadFile(resultPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read analysis result: %w", err)
+	}
+
+	body := BuildAnalysisComment(issue.IssueKey, analysisPhase, string(raw))
+	result := &PostCommentResult{}
+
+	if existingCommentID != "" {
+		err = uc.jiraRepo.UpdateComment(ctx, issue.IssueKey, existingCommentID, body)
+		switch {
+		case err == nil:
+			result.CommentID = existingCommentID
+			result.Updated = true
+		case errors.Is(err, domain.ErrNotFound):
+			// Jira에서 코멘트가 삭제된 경우 새로 게시한다.
+		default:
+			return nil, fmt.Errorf("failed to update comment: %w", err)
+		}
+	}
+
+	if result.CommentID == "" {
+		commentID, err := uc.jiraRepo.AddComment(ctx, issue.IssueKey, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add comment: %w", err)
+		}
+		result.CommentID = commentID
+	}
+
+	if target.JiraCommentID != result.CommentID {
+		target.JiraCommentID = result.CommentID
+		if err := uc.analysisStore.UpdateAnalysisResult(target); err != nil {
+			return result, fmt.Errorf("failed to save comment id: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// selectCommentTarget은 단계별 최신 완료 결과와 이전에 게시된 코멘트 ID를 찾는다.
+// 재분석으로 결과 행이 새로 생겨도 같은 코멘트를 갱신하도록 이전 행의 코멘트 ID를 이어받는다.
+func selectCommentTarget(results []*domain.AnalysisResult, analysisPhase int) (*domain.AnalysisResult, string) {
+	var target *domain.AnalysisResult
+	var commentID string
+	for _, r := range results {
+		if r == nil || r.AnalysisPhase != analysisPhase {
+			continue
+		}
+		if r.JiraCommentID != "" {
+			commentID = r.JiraCommentID
+		}
+		if r.Status == "completed" && analysisResultPath(r, analysisPhase) != "" {
+			target = r
+		}
+	}
+	return target, commentID
+}
+
+// analysisResultPath는 단계에 맞는 결과 파일 경로를 반환한다.
+func analysisResultPath(result *domain.AnalysisResult, analysisPhase int) string {
+	path := result.PlanPath
+	if analysisPhase == AnalysisPhaseExecution {
+		path = result.ExecutionPath
+	}
+	if path == "" {
+		path = result.ResultPath
+	}
+	return path
+}
+
+// analysisPhaseLabel은 단계별 표시 이름을 반환한다.
+func analysisPhaseLabel(analysisPhase int) string {
+	if analysisPhase == AnalysisPhaseExecution {
+		return "AI 실행"
+	}
+	return "AI 플랜"
+}
+
+// BuildAnalysisComment는 분석 결과 Markdown에 제목을 붙이고 Jira 길이 제한에 맞게 자른다.
+func BuildAnalysisComment(issueKey string, analysisPhase int, content string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## 🤖 %s 결과 (%s)\n\n", analysisPhaseLabel(analysisPhase), issueKey))
+
+	content = strings.TrimSpace(content)
+	const truncatedNotice = "\n\n> ⚠️ 내용이 길어 일부만 게시했습니다. 전체 내용은 로컬 결과 파일을 확인하세요."
+	limit := maxCommentLength - utf8.RuneCountInString(sb.String()) - utf8.RuneCountInString(truncatedNotice)
+	if utf8.RuneCountInString(content) > limit {
+		content = string([]rune(content)[:limit]) + truncatedNotice
+	}
+	sb.WriteString(content)
+
+	return sb.String()
+}
@@ -0,0 +1,165 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
+	"jira-ai-generator/internal/usecase"
+)
+
+func writeResultFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write result file: %v", err)
+	}
+	return path
+}
+
+func TestPostCommentUseCase_Execute_AddsNewComment(t *testing.T) {
+	planPath := writeResultFile(t, "TEST-1_plan.md", "# Plan\n\n- step 1")
+	stored := &domain.AnalysisResult{ID: 10, IssueID: 1, AnalysisPhase: 1, PlanPath: planPath, Status: "completed"}
+
+	var savedCommentID string
+	store := &mock.AnalysisResultStore{
+		ListAnalysisResultsByIssueFunc: func(issueID int64) ([]*domain.AnalysisResult, error) {
+			return []*domain.AnalysisResult{stored}, nil
+		},
+		UpdateAnalysisResultFunc: func(result *domain.AnalysisResult) error {
+			savedCommentID = result.JiraCommentID
+			return nil
+		},
+	}
+
+	var postedBody string
+	jira := &mock.JiraRepository{
+		AddCommentFunc: func(_ context.Context, issueKey, markdown string) (string, error) {
+			postedBody = markdown
+			return "10001", nil
+		},
+		UpdateCommentFunc: func(_ context.Context, issueKey, commentID, markdown string) error {
+			t.Error("UpdateComment should not be called for first post")
+			return nil
+		},
+	}
+
+	uc := usecase.NewPostCommentUseCase(jira, store)
+	result, err := uc.Execute(context.Background(), &domain.IssueRecord{ID: 1, IssueKey: "TEST-1"}, usecase.AnalysisPhasePlan)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.CommentID != "10001" || result.Updated {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if savedCommentID != "10001" {
+		t.Errorf("expected comment ID to be saved, got %q", savedCommentID)
+	}
+	if !strings.Contains(postedBody, "TEST-1") || !strings.Contains(postedBody, "- step 1") {
+		t.Errorf("unexpected comment body: %s", postedBody)
+	}
+}
+
+func TestPostCommentUseCase_Execute_UpdatesExistingComment(t *testing.T) {
+	oldPath := writeResultFile(t, "old_execution.md", "old")
+	newPath := writeResultFile(t, "new_execution.md", "new")
+	older := &domain.AnalysisResult{ID: 1, AnalysisPhase: 2, ExecutionPath: oldPath, Status: "completed", JiraCommentID: "555"}
+	latest := &domain.AnalysisResult{ID: 2, AnalysisPhase: 2, ExecutionPath: newPath, Status: "completed"}
+
+	var updated *domain.AnalysisResult
+	store := &mock.AnalysisResultStore{
+		ListAnalysisResultsByIssueFunc: func(issueID int64) ([]*domain.AnalysisResult, error) {
+			return []*domain.AnalysisResult{older, latest}, nil
+		},
+		UpdateAnalysisResultFunc: func(result *domain.AnalysisResult) error {
+			updated = result
+			return nil
+		},
+	}
+
+	var updatedCommentID, updatedBody string
+	jira := &mock.JiraRepository{
+		AddCommentFunc: func(_ context.Context, issueKey, markdown string) (string, error) {
+			t.Error("AddComment should not be called when a comment already exists")
+			return "", nil
+		},
+		UpdateCommentFunc: func(_ context.Context, issueKey, commentID, markdown string) error {
+			updatedCommentID = commentID
+			updatedBody = markdown
+			return nil
+		},
+	}
+
+	uc := usecase.NewPostCommentUseCase(jira, store)
+	result, err := uc.Execute(context.Background(), &domain.IssueRecord{ID: 1, IssueKey: "TEST-2"}, usecase.AnalysisPhaseExecution)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.Updated || result.CommentID != "555" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if updatedCommentID != "555" || !strings.HasSuffix(updatedBody, "new") {
+		t.Errorf("expected latest execution to update comment 555, got id=%s body=%q", updatedCommentID, updatedBody)
+	}
+	if updated != latest || latest.JiraCommentID != "555" {
+		t.Errorf("expected comment ID to be carried over to latest result")
+	}
+}
+
+func TestPostCommentUseCase_Execute_RepostsWhenCommentDeleted(t *testing.T) {
+	planPath := writeResultFile(t, "plan.md", "plan")
+	stored := &domain.AnalysisResult{ID: 3, AnalysisPhase: 1, PlanPath: planPath, Status: "completed", JiraCommentID: "777"}
+	store := &mock.AnalysisResultStore{
+		ListAnalysisResultsByIssueFunc: func(issueID int64) ([]*domain.AnalysisResult, error) {
+			return []*domain.AnalysisResult{stored}, nil
+		},
+	}
+	jira := &mock.JiraRepository{
+		UpdateCommentFunc: func(_ context.Context, issueKey, commentID, markdown string) error {
+			return fmt.Errorf("comment %s: %w", commentID, domain.ErrNotFound)
+		},
+		AddCommentFunc: func(_ context.Context, issueKey, markdown string) (string, error) {
+			return "888", nil
+		},
+	}
+
+	uc := usecase.NewPostCommentUseCase(jira, store)
+	result, err := uc.Execute(context.Background(), &domain.IssueRecord{ID: 1, IssueKey: "TEST-3"}, usecase.AnalysisPhasePlan)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Updated || result.CommentID != "888" || stored.JiraCommentID != "888" {
+		t.Errorf("expected new comment 888 to replace deleted one, got %+v", result)
+	}
+}
+
+func TestPostCommentUseCase_Execute_NoResult(t *testing.T) {
+	store := &mock.AnalysisResultStore{
+		ListAnalysisResultsByIssueFunc: func(issueID int64) ([]*domain.AnalysisResult, error) {
+			return []*domain.AnalysisResult{{AnalysisPhase: 1, Status: "failed", PlanPath: "/tmp/x"}}, nil
+		},
+	}
+	uc := usecase.NewPostCommentUseCase(&mock.JiraRepository{}, store)
+
+	if _, err := uc.Execute(context.Background(), &domain.IssueRecord{ID: 1, IssueKey: "TEST-4"}, usecase.AnalysisPhasePlan); err == nil {
+		t.Fatal("expected error when no completed result exists")
+	}
+}
+
+func TestBuildAnalysisComment_Truncates(t *testing.T) {
+	content := strings.Repeat("가", 40000)
+
+	body := usecase.BuildAnalysisComment("TEST-5", usecase.AnalysisPhasePlan, content)
+
+	if n := len([]rune(body)); n > 30000 {
+		t.Errorf("expected body to be truncated to 30000 runes, got %d", n)
+	}
+	if !strings.Contains(body, "일부만 게시") {
+		t.Error("expected truncation notice")
+	}
+}
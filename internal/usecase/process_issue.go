@@ -1,11 +1,17 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/logger"
 	"jira-ai-generator/internal/port"
 )
 
@@ -16,6 +22,13 @@ type ProcessIssueUseCase struct {
 	videoProcessor port.VideoProcessor
 	docGenerator   port.DocumentGenerator
 	outputDir      string
+
+	settingsMu               sync.RWMutex // 처리 중에 설정 화면에서 바뀔 수 있는 fetchRelatedDescriptions, frameOptions를 보호
+	fetchRelatedDescriptions bool
+	attachmentReader         port.AttachmentReader // nil이면 텍스트 첨부파일을 문서에 넣지 않음
+	frameOptions             domain.FrameOptions
+	transcriber              port.Transcriber    // nil이면 동영상 음성을 인식하지 않음
+	imageProcessor           port.ImageProcessor // nil이면 원본 이미지를 그대로 문서에 넣음
 }
 
 // NewProcessIssueUseCase creates a new ProcessIssueUseCase
@@ -32,9 +45,39 @@ func NewProcessIssueUseCase(
 		videoProcessor: videoProcessor,
 		docGenerator:   docGenerator,
 		outputDir:      outputDir,
+		frameOptions:   domain.DefaultFrameOptions(),
 	}
 }
 
+// SetFetchRelatedDescriptions enables fetching descriptions of related issues (one level deep)
+func (uc *ProcessIssueUseCase) SetFetchRelatedDescriptions(enabled bool) {
+	uc.settingsMu.Lock()
+	defer uc.settingsMu.Unlock()
+	uc.fetchRelatedDescriptions = enabled
+}
+
+// SetAttachmentReader enables embedding text attachments (logs, JSON) and zip archives in the document
+func (uc *ProcessIssueUseCase) SetAttachmentReader(reader port.AttachmentReader) {
+	uc.attachmentReader = reader
+}
+
+// SetFrameOptions sets how frames are extracted from video attachments
+func (uc *ProcessIssueUseCase) SetFrameOptions(opts domain.FrameOptions) {
+	uc.settingsMu.Lock()
+	defer uc.settingsMu.Unlock()
+	uc.frameOptions = opts.Normalized()
+}
+
+// SetTranscriber enables including the narration of video attachments in the document
+func (uc *ProcessIssueUseCase) SetTranscriber(transcriber port.Transcriber) {
+	uc.transcriber = transcriber
+}
+
+// SetImageProcessor enables downscaling/compressing images and frames before they are referenced in the document
+func (uc *ProcessIssueUseCase) SetImageProcessor(processor port.ImageProcessor) {
+	uc.imageProcessor = processor
+}
+
 // ProgressCallback is called to report progress
 type ProgressCallback func(progress float64, status string)
 
@@ -68,26 +111,60 @@ func extractIssueKeyFromURL(input string) string {
 	return input
 }
 
-// Execute processes a Jira issue and generates a document
-func (uc *ProcessIssueUseCase) Execute(issueKeyOrURL string, onProgress ProgressCallback) (*domain.ProcessResult, error) {
+// Execute processes a Jira issue and generates a document.
+// ctx가 취소되면 진행 중인 조회/다운로드/프레임 추출을 중단하고, 이번 실행에서 이슈 디렉토리에 새로 만든 파일을 지운다.
+func (uc *ProcessIssueUseCase) Execute(ctx context.Context, issueKeyOrURL string, onProgress ProgressCallback) (*domain.ProcessResult, error) {
 	result := &domain.ProcessResult{}
 
 	// Extract issue key from URL if needed
 	issueKey := extractIssueKeyFromURL(issueKeyOrURL)
 
+	// 취소 시 정리할 파일 목록 (이번 실행에서 만든 첨부파일과 프레임).
+	// 다시 실행한 경우 이전 실행의 파일을 덮어쓸 수 있으므로, 실행 전부터 있던 파일은 지우지 않는다.
+	var written []string
+	existed := existingFiles(filepath.Join(uc.outputDir, issueKey))
+	cancelled := func(err error) (*domain.ProcessResult, error) {
+		uc.cleanup(issueKey, written, existed)
+		result.ErrorMessage = "사용자 요청으로 중단되었습니다"
+		return result, err
+	}
+
 	// Step 1: Fetch issue
 	onProgress(0.1, "Jira 이슈 조회 중...")
-	issue, err := uc.jiraRepo.GetIssue(issueKey)
+	issue, err := uc.jiraRepo.GetIssue(ctx, issueKey)
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelled(ctx.Err())
+		}
 		result.ErrorMessage = fmt.Sprintf("이슈 조회 실패: %v", err)
 		return result, err
 	}
 
+	// 처리 도중 설정이 바뀌어도 한 번의 실행 안에서는 같은 값을 쓴다
+	uc.settingsMu.RLock()
+	fetchRelated, frameOptions := uc.fetchRelatedDescriptions, uc.frameOptions
+	uc.settingsMu.RUnlock()
+
+	if fetchRelated && len(issue.Related) > 0 {
+		onProgress(0.2, "관련 이슈 조회 중...")
+		uc.fillRelatedDescriptions(ctx, issue)
+	}
+
 	// Step 2: Download attachments
 	onProgress(0.3, "첨부파일 다운로드 중...")
-	mediaAttachments := filterMediaAttachments(issue.Attachments)
-	downloadResults, err := uc.downloader.DownloadAll(issueKey, mediaAttachments)
+	downloadResults, err := uc.downloader.DownloadAll(ctx, issueKey, filterSupportedAttachments(issueKey, issue.Attachments), func(p domain.DownloadProgress) {
+		onProgress(downloadStageProgress(p), formatDownloadStatus(p))
+	})
+	for _, dr := range downloadResults {
+		// 캐시에서 가져온 파일은 이전 실행 결과일 수 있으므로 취소해도 지우지 않는다
+		if dr.Error == nil && dr.LocalPath != "" && !dr.FromCache {
+			written = append(written, dr.LocalPath)
+		}
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelled(ctx.Err())
+		}
 		result.ErrorMessage = fmt.Sprintf("첨부파일 다운로드 실패: %v", err)
 		return result, err
 	}
@@ -96,30 +173,122 @@ func (uc *ProcessIssueUseCase) Execute(issueKeyOrURL string, onProgress Progress
 	var imagePaths []string
 	var framePaths []string
 
+	issue.MediaNames = make(map[string]string)
 	for _, dr := range downloadResults {
 		if dr.Error != nil {
 			continue
 		}
-		if !dr.IsVideo {
+		// 같은 이름의 첨부파일이 여러 개면 본문의 {{MEDIA:이름}}은 첫 번째 파일을 가리킨다
+		if _, ok := issue.MediaNames[dr.Attachment.Filename]; !ok {
+			issue.MediaNames[dr.Attachment.Filename] = filepath.Base(dr.LocalPath)
+		}
+		kind := dr.Kind
+		if kind == domain.AttachmentUnsupported {
+			kind = domain.ClassifyAttachment(dr.Attachment.MimeType, dr.Attachment.Filename)
+		}
+		switch kind {
+		case domain.AttachmentImage:
 			imagePaths = append(imagePaths, dr.LocalPath)
+		case domain.AttachmentText, domain.AttachmentArchive:
+			if uc.attachmentReader == nil {
+				continue
+			}
+			inline, err := uc.attachmentReader.Read(dr.LocalPath, dr.Attachment.Filename, kind)
+			if err != nil || inline == nil {
+				// 바이너리이거나 손상된 파일은 문서 생성을 막지 않도록 건너뛴다
+				continue
+			}
+			issue.Inlined = append(issue.Inlined, *inline)
 		}
 	}
 
 	// Step 4: Extract video frames
 	onProgress(0.5, "동영상 프레임 추출 중...")
+	issue.Videos = make(map[string]domain.VideoInfo)
+	for _, dr := range downloadResults {
+		if dr.Error != nil || !dr.IsVideo {
+			continue
+		}
+		// 메타데이터는 문서에 참고로 넣는 정보이므로 ffprobe가 없거나 실패해도 계속 진행한다
+		if info, err := uc.videoProcessor.Probe(ctx, dr.LocalPath); err == nil && info != nil {
+			issue.Videos[filepath.Base(dr.LocalPath)] = *info
+		}
+	}
 	if uc.videoProcessor.IsAvailable() {
 		for _, dr := range downloadResults {
 			if dr.Error != nil || !dr.IsVideo {
 				continue
 			}
 			framesDir := filepath.Join(uc.outputDir, issueKey, "frames")
-			frames, err := uc.videoProcessor.ExtractFrames(dr.LocalPath, framesDir, 1.0, 10)
+			if frames, ok := cachedFrames(framesDir, dr, frameOptions); ok {
+				framePaths = append(framePaths, frames...)
+				continue
+			}
+			frames, err := uc.videoProcessor.ExtractFrames(ctx, dr.LocalPath, framesDir, frameOptions)
+			written = append(written, frames...)
+			if ctx.Err() != nil {
+				return cancelled(ctx.Err())
+			}
 			if err == nil {
 				framePaths = append(framePaths, frames...)
+				saveFramesMarker(framesDir, dr, frameOptions, frames)
+			}
+		}
+	}
+
+	// Step 4-1: Transcribe narration
+	issue.Transcripts = make(map[string][]domain.TranscriptSegment)
+	if uc.transcriber != nil && uc.transcriber.IsAvailable() {
+		framesDir := filepath.Join(uc.outputDir, issueKey, "frames")
+		for _, dr := range downloadResults {
+			if dr.Error != nil || !dr.IsVideo {
+				continue
+			}
+			name := filepath.Base(dr.LocalPath)
+			if info, ok := issue.Videos[name]; ok && !info.HasAudio {
+				continue
+			}
+			if segments, ok := cachedTranscript(framesDir, dr); ok {
+				issue.Transcripts[name] = segments
+				continue
+			}
+			onProgress(0.7, fmt.Sprintf("동영상 음성 인식 중: %s", dr.Attachment.Filename))
+			segments, err := uc.transcriber.Transcribe(ctx, dr.LocalPath)
+			if ctx.Err() != nil {
+				return cancelled(ctx.Err())
+			}
+			// 음성 인식은 참고 정보이므로 실패해도 문서 생성은 계속한다
+			if err != nil || len(segments) == 0 {
+				continue
+			}
+			issue.Transcripts[name] = segments
+			if path, ok := saveTranscript(framesDir, dr, segments); ok {
+				written = append(written, path)
+			}
+		}
+	}
+
+	// Step 4-2: Prepare images (원본은 그대로 두고 줄이거나 압축한 사본을 문서에 넣음)
+	if uc.imageProcessor != nil && len(imagePaths)+len(framePaths) > 0 {
+		onProgress(0.75, "이미지 최적화 중...")
+		var renamed map[string]string
+		imagePaths, renamed = uc.prepareImages(ctx, imagePaths, &written)
+		framePaths, _ = uc.prepareImages(ctx, framePaths, &written)
+		if ctx.Err() != nil {
+			return cancelled(ctx.Err())
+		}
+		// JPEG로 바뀌어 이름이 달라진 이미지도 본문의 {{MEDIA:파일명}}으로 찾을 수 있게 한다
+		for original, local := range issue.MediaNames {
+			if name, ok := renamed[local]; ok {
+				issue.MediaNames[original] = name
 			}
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return cancelled(err)
+	}
+
 	// Step 5: Generate document
 	onProgress(0.8, "문서 생성 중...")
 	doc, err := uc.docGenerator.Generate(issue, imagePaths, framePaths, uc.outputDir)
@@ -139,17 +308,229 @@ func (uc *ProcessIssueUseCase) Execute(issueKeyOrURL string, onProgress Progress
 	result.Success = true
 	result.Document = doc
 	result.MDPath = mdPath
+	result.Attachments = downloadResults
 
 	return result, nil
 }
 
-// filterMediaAttachments filters attachments to only include images and videos
-func filterMediaAttachments(attachments []domain.Attachment) []domain.Attachment {
-	var media []domain.Attachment
+// cachedFrames returns the frames extracted earlier from the same video content with the same options.
+// 마커 파일이 없거나 원본 해시/옵션이 다르거나 프레임이 하나라도 지워졌으면 다시 추출한다.
+func cachedFrames(framesDir string, dr domain.DownloadResult, opts domain.FrameOptions) ([]string, bool) {
+	if dr.ContentHash == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(framesMarkerPath(framesDir, dr.LocalPath))
+	if err != nil {
+		return nil, false
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if lines[0] != framesMarkerKey(dr.ContentHash, opts) {
+		return nil, false
+	}
+	var frames []string
+	for _, name := range lines[1:] {
+		path := filepath.Join(framesDir, name)
+		if _, err := os.Stat(path); err != nil {
+			return nil, false
+		}
+		frames = append(frames, path)
+	}
+	return frames, len(frames) > 0
+}
+
+// saveFramesMarker records which video content and options produced frames
+func saveFramesMarker(framesDir string, dr domain.DownloadResult, opts domain.FrameOptions, frames []string) {
+	if dr.ContentHash == "" || len(frames) == 0 {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(framesMarkerKey(dr.ContentHash, opts) + "\n")
+	for _, frame := range frames {
+		sb.WriteString(filepath.Base(frame) + "\n")
+	}
+	os.WriteFile(framesMarkerPath(framesDir, dr.LocalPath), []byte(sb.String()), 0644)
+}
+
+func framesMarkerPath(framesDir, videoPath string) string {
+	videoName := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+	return filepath.Join(framesDir, "."+videoName+".frames")
+}
+
+func framesMarkerKey(hash string, opts domain.FrameOptions) string {
+	return hash + " " + opts.String()
+}
+
+// prepareImages replaces each path with its processed copy, keeping the original when processing fails.
+// renamed는 파일명이 바뀐 이미지의 원래 파일명 → 새 파일명이다.
+func (uc *ProcessIssueUseCase) prepareImages(ctx context.Context, paths []string, written *[]string) (prepared []string, renamed map[string]string) {
+	renamed = make(map[string]string)
+	for _, path := range paths {
+		processed, err := uc.imageProcessor.Process(ctx, path)
+		if err != nil || processed == "" {
+			prepared = append(prepared, path)
+			continue
+		}
+		if processed != path {
+			*written = append(*written, processed)
+			if filepath.Base(processed) != filepath.Base(path) {
+				renamed[filepath.Base(path)] = filepath.Base(processed)
+			}
+		}
+		prepared = append(prepared, processed)
+	}
+	return prepared, renamed
+}
+
+// transcriptCache is the on-disk form of a transcript, keyed by the video content hash
+type transcriptCache struct {
+	ContentHash string                     `json:"contentHash"`
+	Segments    []domain.TranscriptSegment `json:"segments"`
+}
+
+// cachedTranscript returns the transcript recognized earlier from the same video content
+func cachedTranscript(framesDir string, dr domain.DownloadResult) ([]domain.TranscriptSegment, bool) {
+	if dr.ContentHash == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(transcriptCachePath(framesDir, dr.LocalPath))
+	if err != nil {
+		return nil, false
+	}
+	var cache transcriptCache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.ContentHash != dr.ContentHash {
+		return nil, false
+	}
+	return cache.Segments, len(cache.Segments) > 0
+}
+
+// saveTranscript stores a transcript so re-runs skip the (slow) speech recognition
+func saveTranscript(framesDir string, dr domain.DownloadResult, segments []domain.TranscriptSegment) (string, bool) {
+	if dr.ContentHash == "" {
+		return "", false
+	}
+	data, err := json.Marshal(transcriptCache{ContentHash: dr.ContentHash, Segments: segments})
+	if err != nil {
+		return "", false
+	}
+	path := transcriptCachePath(framesDir, dr.LocalPath)
+	if err := os.MkdirAll(framesDir, 0755); err != nil {
+		return "", false
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+func transcriptCachePath(framesDir, videoPath string) string {
+	videoName := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+	return filepath.Join(framesDir, "."+videoName+".transcript.json")
+}
+
+// downloadStageProgress maps attachment download progress onto the 0.3-0.5 range of the overall progress
+func downloadStageProgress(p domain.DownloadProgress) float64 {
+	if p.Total == 0 {
+		return 0.3
+	}
+	return 0.3 + 0.2*float64(p.Completed)/float64(p.Total)
+}
+
+// formatDownloadStatus describes the file currently being downloaded, e.g. "첨부파일 다운로드 중 (1/3): video.mp4 42%"
+func formatDownloadStatus(p domain.DownloadProgress) string {
+	status := fmt.Sprintf("첨부파일 다운로드 중 (%d/%d): %s", p.Completed, p.Total, p.Filename)
+	if p.BytesTotal > 0 {
+		percent := p.BytesDone * 100 / p.BytesTotal
+		if percent > 100 {
+			percent = 100
+		}
+		status += fmt.Sprintf(" %d%%", percent)
+	}
+	return status
+}
+
+// IsCancelled reports whether err came from a cancelled Execute call
+func IsCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// cleanup removes files created by a cancelled run, then the frames and issue directories if they are left empty.
+// existed에 있는 파일은 이번 실행이 덮어썼더라도 이전 실행 결과이므로 남겨 둔다.
+func (uc *ProcessIssueUseCase) cleanup(issueKey string, written []string, existed map[string]bool) {
+	for _, path := range written {
+		if existed[filepath.Clean(path)] {
+			continue
+		}
+		os.Remove(path)
+	}
+	issueDir := filepath.Join(uc.outputDir, issueKey)
+	// os.Remove는 비어 있지 않은 디렉토리를 지우지 않으므로 이전 실행 결과는 보존된다
+	os.Remove(filepath.Join(issueDir, "frames", "processed"))
+	os.Remove(filepath.Join(issueDir, "processed"))
+	os.Remove(filepath.Join(issueDir, "frames"))
+	os.Remove(issueDir)
+}
+
+// existingFiles lists the files already under dir (recursively) before a run starts
+func existingFiles(dir string) map[string]bool {
+	existed := make(map[string]bool)
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			existed[filepath.Clean(path)] = true
+		}
+		return nil
+	})
+	return existed
+}
+
+// fillRelatedDescriptions fetches the description of each related issue.
+// 관련 이슈의 관련 이슈는 따라가지 않으며, 조회 실패는 로그만 남기고 문서 생성은 계속한다.
+func (uc *ProcessIssueUseCase) fillRelatedDescriptions(ctx context.Context, issue *domain.JiraIssue) {
+	// 같은 이슈가 여러 관계(예: 하위 작업이면서 blocks)로 연결될 수 있으므로 키마다 한 번만 조회한다
+	details := make(map[string]*domain.JiraIssue)
+	for i := range issue.Related {
+		related := &issue.Related[i]
+		if related.Key == "" || related.Key == issue.Key {
+			continue
+		}
+		detail, seen := details[related.Key]
+		if !seen {
+			if ctx.Err() != nil {
+				return
+			}
+			var err error
+			detail, err = uc.jiraRepo.GetIssueBrief(ctx, related.Key)
+			if err != nil {
+				logger.Debug("fillRelatedDescriptions: failed to fetch %s: %v", related.Key, err)
+				detail = nil
+			}
+			details[related.Key] = detail
+		}
+		if detail == nil {
+			continue
+		}
+		related.Description = detail.Description
+		if related.Summary == "" {
+			related.Summary = detail.Summary
+		}
+	}
+}
+
+// filterSupportedAttachments keeps images, videos, text files and zip archives.
+// 이전 실행에서 자동 첨부한 생성 문서(<KEY>_plan.md 등)는 로컬 결과를 덮어쓰고 이전 AI 결과를
+// 문서에 다시 넣게 되므로 받지 않는다.
+func filterSupportedAttachments(issueKey string, attachments []domain.Attachment) []domain.Attachment {
+	generated := make(map[string]bool)
+	for _, name := range domain.GeneratedOutputNames(issueKey) {
+		generated[strings.ToLower(name)] = true
+	}
+	var supported []domain.Attachment
 	for _, att := range attachments {
-		if strings.HasPrefix(att.MimeType, "image/") || strings.HasPrefix(att.MimeType, "video/") {
-			media = append(media, att)
+		if generated[strings.ToLower(att.Filename)] {
+			continue
+		}
+		if domain.ClassifyAttachment(att.MimeType, att.Filename) != domain.AttachmentUnsupported {
+			supported = append(supported, att)
 		}
 	}
-	return media
+	return supported
 }
@@ -1,8 +1,13 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"jira-ai-generator/internal/domain"
 	"jira-ai-generator/internal/mock"
@@ -12,7 +17,7 @@ import (
 func TestProcessIssueUseCase_Execute_Success(t *testing.T) {
 	// Arrange
 	mockJira := &mock.JiraRepository{
-		GetIssueFunc: func(issueKey string) (*domain.JiraIssue, error) {
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
 			return &domain.JiraIssue{
 				Key:         issueKey,
 				Summary:     "Test Issue",
@@ -23,7 +28,7 @@ func TestProcessIssueUseCase_Execute_Success(t *testing.T) {
 	}
 
 	mockDownloader := &mock.AttachmentDownloader{
-		DownloadAllFunc: func(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error) {
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
 			return []domain.DownloadResult{}, nil
 		},
 	}
@@ -59,7 +64,7 @@ func TestProcessIssueUseCase_Execute_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := uc.Execute("TEST-123", onProgress)
+	result, err := uc.Execute(context.Background(), "TEST-123", onProgress)
 
 	// Assert
 	if err != nil {
@@ -82,7 +87,7 @@ func TestProcessIssueUseCase_Execute_Success(t *testing.T) {
 func TestProcessIssueUseCase_Execute_JiraError(t *testing.T) {
 	// Arrange
 	mockJira := &mock.JiraRepository{
-		GetIssueFunc: func(issueKey string) (*domain.JiraIssue, error) {
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
 			return nil, errors.New("jira connection failed")
 		},
 	}
@@ -100,7 +105,7 @@ func TestProcessIssueUseCase_Execute_JiraError(t *testing.T) {
 	)
 
 	// Act
-	result, err := uc.Execute("TEST-123", func(float64, string) {})
+	result, err := uc.Execute(context.Background(), "TEST-123", func(float64, string) {})
 
 	// Assert
 	if err == nil {
@@ -117,7 +122,7 @@ func TestProcessIssueUseCase_Execute_JiraError(t *testing.T) {
 func TestProcessIssueUseCase_Execute_WithAttachments(t *testing.T) {
 	// Arrange
 	mockJira := &mock.JiraRepository{
-		GetIssueFunc: func(issueKey string) (*domain.JiraIssue, error) {
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
 			return &domain.JiraIssue{
 				Key:     issueKey,
 				Summary: "Issue with attachments",
@@ -130,18 +135,22 @@ func TestProcessIssueUseCase_Execute_WithAttachments(t *testing.T) {
 	}
 
 	mockDownloader := &mock.AttachmentDownloader{
-		DownloadAllFunc: func(issueKey string, attachments []domain.Attachment) ([]domain.DownloadResult, error) {
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
 			return []domain.DownloadResult{
 				{Attachment: attachments[0], LocalPath: "/output/image.png", IsVideo: false},
-				{Attachment: attachments[1], LocalPath: "/output/video.mp4", IsVideo: true},
+				{Attachment: attachments[1], LocalPath: "/output/2_video.mp4", IsVideo: true},
 			}, nil
 		},
 	}
 
 	var receivedImagePaths []string
+	var receivedMediaNames map[string]string
+	var receivedVideos map[string]domain.VideoInfo
 	mockDocGenerator := &mock.DocumentGenerator{
 		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
 			receivedImagePaths = imagePaths
+			receivedMediaNames = issue.MediaNames
+			receivedVideos = issue.Videos
 			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
 		},
 		SaveToFileFunc: func(doc *domain.GeneratedDocument) (string, error) {
@@ -151,9 +160,12 @@ func TestProcessIssueUseCase_Execute_WithAttachments(t *testing.T) {
 
 	mockVideoProcessor := &mock.VideoProcessor{
 		IsAvailableFunc: func() bool { return true },
-		ExtractFramesFunc: func(videoPath, outputDir string, interval float64, maxFrames int) ([]string, error) {
+		ExtractFramesFunc: func(_ context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error) {
 			return []string{"/output/frame1.png", "/output/frame2.png"}, nil
 		},
+		ProbeFunc: func(_ context.Context, videoPath string) (*domain.VideoInfo, error) {
+			return &domain.VideoInfo{Duration: 90 * time.Second, Width: 1280, Height: 720, Codec: "h264"}, nil
+		},
 	}
 
 	uc := usecase.NewProcessIssueUseCase(
@@ -165,7 +177,7 @@ func TestProcessIssueUseCase_Execute_WithAttachments(t *testing.T) {
 	)
 
 	// Act
-	result, err := uc.Execute("TEST-456", func(float64, string) {})
+	result, err := uc.Execute(context.Background(), "TEST-456", func(float64, string) {})
 
 	// Assert
 	if err != nil {
@@ -177,4 +189,510 @@ func TestProcessIssueUseCase_Execute_WithAttachments(t *testing.T) {
 	if len(receivedImagePaths) != 1 {
 		t.Errorf("expected 1 image path, got %d", len(receivedImagePaths))
 	}
+	if receivedMediaNames["video.mp4"] != "2_video.mp4" || receivedMediaNames["image.png"] != "image.png" {
+		t.Errorf("expected original to local name mapping, got %v", receivedMediaNames)
+	}
+	if info, ok := receivedVideos["2_video.mp4"]; !ok || info.Width != 1280 || len(receivedVideos) != 1 {
+		t.Errorf("expected probed metadata keyed by local video name, got %v", receivedVideos)
+	}
+}
+
+func TestProcessIssueUseCase_Execute_FetchesRelatedDescriptions(t *testing.T) {
+	var fetched []string
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			if issueKey != "TEST-1" {
+				t.Errorf("related issues must not be fetched with the full GetIssue, got %s", issueKey)
+			}
+			return &domain.JiraIssue{
+				Key: "TEST-1",
+				Related: []domain.RelatedIssue{
+					{Key: "TEST-2", Relation: domain.RelationSubtask},
+					{Key: "TEST-3", Relation: "blocks"},
+					{Key: "TEST-2", Relation: "is blocked by"},
+				},
+			}, nil
+		},
+		GetIssueBriefFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			fetched = append(fetched, issueKey)
+			if issueKey == "TEST-2" {
+				return &domain.JiraIssue{Key: "TEST-2", Summary: "sub", Description: "sub description"}, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+
+	var generated *domain.JiraIssue
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			generated = issue
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, &mock.AttachmentDownloader{}, &mock.VideoProcessor{}, mockDocGenerator, "/output")
+	uc.SetFetchRelatedDescriptions(true)
+
+	if _, err := uc.Execute(context.Background(), "TEST-1", func(float64, string) {}); err != nil {
+		t.Fatalf("expected success even when a related issue fails, got %v", err)
+	}
+
+	if len(fetched) != 2 || fetched[0] != "TEST-2" || fetched[1] != "TEST-3" {
+		t.Errorf("expected one brief fetch per unique related key, got %v", fetched)
+	}
+	for _, i := range []int{0, 2} {
+		if generated.Related[i].Description != "sub description" || generated.Related[i].Summary != "sub" {
+			t.Errorf("expected related[%d] description filled, got %+v", i, generated.Related[i])
+		}
+	}
+	if generated.Related[1].Description != "" {
+		t.Errorf("expected failed fetch to leave description empty, got %+v", generated.Related[1])
+	}
+}
+
+// TestProcessIssueUseCase_Execute_SkipsGeneratedOutputAttachments는 자동 첨부된 이전 실행 결과를
+// 다시 받지 않고 다른 Markdown 첨부파일만 받는지 검증한다.
+func TestProcessIssueUseCase_Execute_SkipsGeneratedOutputAttachments(t *testing.T) {
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{Key: issueKey, Attachments: []domain.Attachment{
+				{Filename: "TEST-1.md", MimeType: "text/markdown"},
+				{Filename: "TEST-1_plan.md", MimeType: "text/markdown"},
+				{Filename: "test-1_execution.md", MimeType: "application/octet-stream"},
+				{Filename: "notes.md", MimeType: "text/markdown"},
+			}}, nil
+		},
+	}
+	var requested []string
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, _ string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			for _, att := range attachments {
+				requested = append(requested, att.Filename)
+			}
+			return nil, nil
+		},
+	}
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, &mock.VideoProcessor{}, mockDocGenerator, t.TempDir())
+	if _, err := uc.Execute(context.Background(), "TEST-1", func(float64, string) {}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(requested) != 1 || requested[0] != "notes.md" {
+		t.Errorf("expected only notes.md to be downloaded, got %v", requested)
+	}
+}
+
+func TestProcessIssueUseCase_Execute_CancelledCleansUpWrittenFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	issueDir := filepath.Join(outputDir, "TEST-1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{Key: issueKey, Attachments: []domain.Attachment{
+				{Filename: "a.png", MimeType: "image/png"},
+				{Filename: "b.mp4", MimeType: "video/mp4"},
+			}}, nil
+		},
+	}
+	// 첫 첨부파일을 쓴 뒤 사용자가 중지한 상황을 흉내 낸다
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(ctx context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			if err := os.MkdirAll(issueDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(issueDir, "a.png")
+			if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cancel()
+			return []domain.DownloadResult{{Attachment: attachments[0], LocalPath: path}}, ctx.Err()
+		},
+	}
+	generateCalled := false
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			generateCalled = true
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, &mock.VideoProcessor{}, mockDocGenerator, outputDir)
+	result, err := uc.Execute(ctx, "TEST-1", func(float64, string) {})
+
+	if !usecase.IsCancelled(err) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+	if result.Success || generateCalled {
+		t.Error("expected cancelled run not to generate a document")
+	}
+	if _, statErr := os.Stat(issueDir); !os.IsNotExist(statErr) {
+		t.Errorf("expected issue directory to be removed, stat err=%v", statErr)
+	}
+}
+
+// TestProcessIssueUseCase_Execute_CancelledRerunKeepsReplacedFiles는 다시 실행하다 취소했을 때
+// 이번 실행이 덮어쓴 이전 첨부파일과 프레임은 남기고 새로 만든 파일만 지우는지 검증한다.
+func TestProcessIssueUseCase_Execute_CancelledRerunKeepsReplacedFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	issueDir := filepath.Join(outputDir, "TEST-1")
+	framesDir := filepath.Join(issueDir, "frames")
+	if err := os.MkdirAll(framesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	earlierImage := filepath.Join(issueDir, "a.png")
+	earlierVideo := filepath.Join(issueDir, "b.mp4")
+	earlierFrame := filepath.Join(framesDir, "b_frame_001.png")
+	for _, path := range []string{earlierImage, earlierVideo, earlierFrame} {
+		if err := os.WriteFile(path, []byte("previous run"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{Key: issueKey, Attachments: []domain.Attachment{
+				{Filename: "a.png", MimeType: "image/png"},
+				{Filename: "b.mp4", MimeType: "video/mp4"},
+				{Filename: "c.png", MimeType: "image/png"},
+			}}, nil
+		},
+	}
+	// 이전 첨부파일을 다시 받아 덮어쓰고 새 첨부파일을 하나 더 받는다
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, _ string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			var results []domain.DownloadResult
+			for _, att := range attachments {
+				path := filepath.Join(issueDir, att.Filename)
+				if err := os.WriteFile(path, []byte("this run"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				results = append(results, domain.DownloadResult{Attachment: att, LocalPath: path, IsVideo: att.MimeType == "video/mp4"})
+			}
+			return results, nil
+		},
+	}
+	// 프레임을 다시 추출해 이전 프레임을 덮어쓰고 새 프레임을 만든 뒤 사용자가 중지한 상황을 흉내 낸다
+	newFrame := filepath.Join(framesDir, "b_frame_002.png")
+	mockVideo := &mock.VideoProcessor{
+		IsAvailableFunc: func() bool { return true },
+		ExtractFramesFunc: func(_ context.Context, _ string, _ string, _ domain.FrameOptions) ([]string, error) {
+			for _, path := range []string{earlierFrame, newFrame} {
+				if err := os.WriteFile(path, []byte("this run"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cancel()
+			return []string{earlierFrame, newFrame}, nil
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, mockVideo, &mock.DocumentGenerator{}, outputDir)
+	if _, err := uc.Execute(ctx, "TEST-1", func(float64, string) {}); !usecase.IsCancelled(err) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+
+	for _, path := range []string{earlierImage, earlierVideo, earlierFrame} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected replaced file from the earlier run to be kept: %v", err)
+		}
+	}
+	for _, path := range []string{filepath.Join(issueDir, "c.png"), newFrame} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected file created by the cancelled run to be removed: %s (err=%v)", path, err)
+		}
+	}
+}
+
+func TestProcessIssueUseCase_Execute_CancelKeepsEarlierFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	issueDir := filepath.Join(outputDir, "TEST-2")
+	if err := os.MkdirAll(issueDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	previous := filepath.Join(issueDir, "TEST-2.md")
+	if err := os.WriteFile(previous, []byte("# previous run"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(ctx context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return nil, fmt.Errorf("failed to fetch issue: %w", ctx.Err())
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, &mock.AttachmentDownloader{}, &mock.VideoProcessor{}, &mock.DocumentGenerator{}, outputDir)
+	_, err := uc.Execute(ctx, "TEST-2", func(float64, string) {})
+
+	if !usecase.IsCancelled(err) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+	if _, statErr := os.Stat(previous); statErr != nil {
+		t.Errorf("expected files from earlier runs to be kept, got %v", statErr)
+	}
+}
+
+// TestProcessIssueUseCase_Execute_ReusesFramesForUnchangedVideo는 내용 해시가 같은 동영상은
+// 프레임을 다시 추출하지 않고, 해시가 바뀌면 다시 추출하는지 검증한다.
+func TestProcessIssueUseCase_Execute_ReusesFramesForUnchangedVideo(t *testing.T) {
+	outputDir := t.TempDir()
+	videoPath := filepath.Join(outputDir, "TEST-3", "clip.mp4")
+	hash := "aaaa"
+
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{
+				Key:         issueKey,
+				Attachments: []domain.Attachment{{ID: "1", Filename: "clip.mp4", MimeType: "video/mp4"}},
+			}, nil
+		},
+	}
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			return []domain.DownloadResult{
+				{Attachment: attachments[0], LocalPath: videoPath, IsVideo: true, ContentHash: hash},
+			}, nil
+		},
+	}
+	extractions := 0
+	mockVideoProcessor := &mock.VideoProcessor{
+		IsAvailableFunc: func() bool { return true },
+		ExtractFramesFunc: func(_ context.Context, videoPath, framesDir string, opts domain.FrameOptions) ([]string, error) {
+			extractions++
+			if err := os.MkdirAll(framesDir, 0755); err != nil {
+				return nil, err
+			}
+			frame := filepath.Join(framesDir, "clip_frame_0001.png")
+			return []string{frame}, os.WriteFile(frame, []byte("png"), 0644)
+		},
+	}
+	var receivedFrames []string
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			receivedFrames = framePaths
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+		SaveToFileFunc: func(doc *domain.GeneratedDocument) (string, error) { return "", nil },
+	}
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, mockVideoProcessor, mockDocGenerator, outputDir)
+
+	for _, step := range []struct {
+		hash            string
+		wantExtractions int
+	}{
+		{"aaaa", 1}, // 첫 실행
+		{"aaaa", 1}, // 같은 내용: 재사용
+		{"bbbb", 2}, // 내용 변경: 다시 추출
+	} {
+		hash = step.hash
+		result, err := uc.Execute(context.Background(), "TEST-3", func(float64, string) {})
+		if err != nil || !result.Success {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if extractions != step.wantExtractions {
+			t.Errorf("hash %s: expected %d extractions, got %d", step.hash, step.wantExtractions, extractions)
+		}
+		if len(receivedFrames) != 1 || filepath.Base(receivedFrames[0]) != "clip_frame_0001.png" {
+			t.Errorf("hash %s: unexpected frames %v", step.hash, receivedFrames)
+		}
+		if len(result.Attachments) != 1 {
+			t.Errorf("expected download results in ProcessResult, got %+v", result.Attachments)
+		}
+	}
+}
+
+// TestProcessIssueUseCase_Execute_InlinesTextAttachments는 로그/zip 첨부파일을 읽어 문서 생성에 넘기고
+// 읽을 수 없는 파일은 건너뛰는지 검증한다.
+func TestProcessIssueUseCase_Execute_InlinesTextAttachments(t *testing.T) {
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{
+				Key: issueKey,
+				Attachments: []domain.Attachment{
+					{ID: "1", Filename: "crash.log", MimeType: "application/octet-stream"},
+					{ID: "2", Filename: "logs.zip", MimeType: "application/zip"},
+					{ID: "3", Filename: "broken.txt", MimeType: "text/plain"},
+					{ID: "4", Filename: "spec.pdf", MimeType: "application/pdf"},
+				},
+			}, nil
+		},
+	}
+	var requested []string
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			var results []domain.DownloadResult
+			for _, att := range attachments {
+				requested = append(requested, att.Filename)
+				results = append(results, domain.DownloadResult{Attachment: att, LocalPath: "/output/" + att.Filename})
+			}
+			return results, nil
+		},
+	}
+	mockReader := &mock.AttachmentReader{
+		ReadFunc: func(localPath, filename string, kind domain.AttachmentKind) (*domain.InlineAttachment, error) {
+			if filename == "broken.txt" {
+				return nil, errors.New("not text")
+			}
+			return &domain.InlineAttachment{Filename: filename, Kind: kind}, nil
+		},
+	}
+	var inlined []domain.InlineAttachment
+	var imagePaths []string
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, images, frames []string, outputDir string) (*domain.GeneratedDocument, error) {
+			inlined = issue.Inlined
+			imagePaths = images
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+	}
+
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, &mock.VideoProcessor{}, mockDocGenerator, "/output")
+	uc.SetAttachmentReader(mockReader)
+	if _, err := uc.Execute(context.Background(), "TEST-5", func(float64, string) {}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(requested) != 3 {
+		t.Errorf("expected log, zip and txt to be downloaded (not pdf), got %v", requested)
+	}
+	if len(inlined) != 2 || inlined[0].Kind != domain.AttachmentText || inlined[1].Kind != domain.AttachmentArchive {
+		t.Errorf("expected crash.log and logs.zip inlined, got %+v", inlined)
+	}
+	if len(imagePaths) != 0 {
+		t.Errorf("expected text attachments not to be treated as images, got %v", imagePaths)
+	}
+}
+
+// TestProcessIssueUseCase_Execute_TranscribesNarration은 오디오가 있는 동영상만 음성 인식하고,
+// 같은 내용의 동영상은 저장된 결과를 재사용하는지 검증한다.
+func TestProcessIssueUseCase_Execute_TranscribesNarration(t *testing.T) {
+	outputDir := t.TempDir()
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{
+				Key: issueKey,
+				Attachments: []domain.Attachment{
+					{ID: "1", Filename: "narrated.mp4", MimeType: "video/mp4"},
+					{ID: "2", Filename: "silent.mp4", MimeType: "video/mp4"},
+				},
+			}, nil
+		},
+	}
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			return []domain.DownloadResult{
+				{Attachment: attachments[0], LocalPath: filepath.Join(outputDir, issueKey, "narrated.mp4"), IsVideo: true, ContentHash: "aaaa"},
+				{Attachment: attachments[1], LocalPath: filepath.Join(outputDir, issueKey, "silent.mp4"), IsVideo: true, ContentHash: "bbbb"},
+			}, nil
+		},
+	}
+	mockVideoProcessor := &mock.VideoProcessor{
+		ProbeFunc: func(_ context.Context, videoPath string) (*domain.VideoInfo, error) {
+			return &domain.VideoInfo{HasAudio: filepath.Base(videoPath) == "narrated.mp4"}, nil
+		},
+	}
+	var transcribed []string
+	mockTranscriber := &mock.Transcriber{
+		IsAvailableFunc: func() bool { return true },
+		TranscribeFunc: func(_ context.Context, videoPath string) ([]domain.TranscriptSegment, error) {
+			transcribed = append(transcribed, filepath.Base(videoPath))
+			return []domain.TranscriptSegment{{Start: 2 * time.Second, End: 4 * time.Second, Text: "저장이 안 됩니다"}}, nil
+		},
+	}
+	var received map[string][]domain.TranscriptSegment
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			received = issue.Transcripts
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+		SaveToFileFunc: func(doc *domain.GeneratedDocument) (string, error) { return "", nil },
+	}
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, mockVideoProcessor, mockDocGenerator, outputDir)
+	uc.SetTranscriber(mockTranscriber)
+
+	for run := 1; run <= 2; run++ {
+		if _, err := uc.Execute(context.Background(), "TEST-5", func(float64, string) {}); err != nil {
+			t.Fatalf("run %d: Execute failed: %v", run, err)
+		}
+		if len(transcribed) != 1 || transcribed[0] != "narrated.mp4" {
+			t.Errorf("run %d: expected only the narrated video to be transcribed once, got %v", run, transcribed)
+		}
+		segments := received["narrated.mp4"]
+		if len(received) != 1 || len(segments) != 1 || segments[0].Text != "저장이 안 됩니다" || segments[0].Start != 2*time.Second {
+			t.Errorf("run %d: unexpected transcripts %+v", run, received)
+		}
+	}
+}
+
+// TestProcessIssueUseCase_Execute_PreparesImages는 이미지와 프레임을 최적화한 사본으로 바꾸고,
+// 이름이 바뀐 이미지도 본문의 첨부파일명으로 찾을 수 있게 하는지 검증한다.
+func TestProcessIssueUseCase_Execute_PreparesImages(t *testing.T) {
+	mockJira := &mock.JiraRepository{
+		GetIssueFunc: func(_ context.Context, issueKey string) (*domain.JiraIssue, error) {
+			return &domain.JiraIssue{
+				Key: issueKey,
+				Attachments: []domain.Attachment{
+					{ID: "1", Filename: "screen shot.png", MimeType: "image/png"},
+					{ID: "2", Filename: "clip.mp4", MimeType: "video/mp4"},
+				},
+			}, nil
+		},
+	}
+	mockDownloader := &mock.AttachmentDownloader{
+		DownloadAllFunc: func(_ context.Context, issueKey string, attachments []domain.Attachment, _ func(domain.DownloadProgress)) ([]domain.DownloadResult, error) {
+			return []domain.DownloadResult{
+				{Attachment: attachments[0], LocalPath: "/output/TEST-6/screen shot.png"},
+				{Attachment: attachments[1], LocalPath: "/output/TEST-6/clip.mp4", IsVideo: true},
+			}, nil
+		},
+	}
+	mockVideoProcessor := &mock.VideoProcessor{
+		IsAvailableFunc: func() bool { return true },
+		ExtractFramesFunc: func(_ context.Context, videoPath, outputDir string, opts domain.FrameOptions) ([]string, error) {
+			return []string{"/output/TEST-6/frames/clip_frame_0001_0ms.png"}, nil
+		},
+	}
+	mockImageProcessor := &mock.ImageProcessor{
+		ProcessFunc: func(_ context.Context, imagePath string) (string, error) {
+			if filepath.Base(imagePath) == "screen shot.png" {
+				return "/output/TEST-6/processed/screen shot.png.jpg", nil
+			}
+			return filepath.Join(filepath.Dir(imagePath), "processed", filepath.Base(imagePath)), nil
+		},
+	}
+	var receivedImages, receivedFrames []string
+	var receivedMediaNames map[string]string
+	mockDocGenerator := &mock.DocumentGenerator{
+		GenerateFunc: func(issue *domain.JiraIssue, imagePaths, framePaths []string, outputDir string) (*domain.GeneratedDocument, error) {
+			receivedImages, receivedFrames, receivedMediaNames = imagePaths, framePaths, issue.MediaNames
+			return &domain.GeneratedDocument{IssueKey: issue.Key}, nil
+		},
+		SaveToFileFunc: func(doc *domain.GeneratedDocument) (string, error) { return "", nil },
+	}
+	uc := usecase.NewProcessIssueUseCase(mockJira, mockDownloader, mockVideoProcessor, mockDocGenerator, "/output")
+	uc.SetImageProcessor(mockImageProcessor)
+
+	if _, err := uc.Execute(context.Background(), "TEST-6", func(float64, string) {}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(receivedImages) != 1 || receivedImages[0] != "/output/TEST-6/processed/screen shot.png.jpg" {
+		t.Errorf("expected processed image, got %v", receivedImages)
+	}
+	if len(receivedFrames) != 1 || receivedFrames[0] != "/output/TEST-6/frames/processed/clip_frame_0001_0ms.png" {
+		t.Errorf("expected processed frame, got %v", receivedFrames)
+	}
+	if receivedMediaNames["screen shot.png"] != "screen shot.png.jpg" || receivedMediaNames["clip.mp4"] != "clip.mp4" {
+		t.Errorf("expected media names to follow renamed images, got %v", receivedMediaNames)
+	}
 }
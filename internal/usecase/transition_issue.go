@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/port"
+)
+
+// TransitionIssueUseCase handles moving Jira issues through workflow transitions
+type TransitionIssueUseCase struct {
+	jiraRepo port.JiraRepository
+}
+
+// NewTransitionIssueUseCase creates a new TransitionIssueUseCase
+func NewTransitionIssueUseCase(jiraRepo port.JiraRepository) *TransitionIssueUseCase {
+	return &TransitionIssueUseCase{
+		jiraRepo: jiraRepo,
+	}
+}
+
+// Execute는 이름이 transitionName인 워크플로 전환을 찾아 실행한다.
+// 전환 이름 또는 대상 상태 이름을 대소문자 구분 없이 비교하며, 현재 상태에서 사용할 수 없으면 에러를 반환한다.
+func (uc *TransitionIssueUseCase) Execute(ctx context.Context, issueKey, transitionName string) (*domain.Transition, error) {
+	transitionName = strings.TrimSpace(transitionName)
+	if transitionName == "" {
+		return nil, fmt.Errorf("transition name is empty")
+	}
+
+	transitions, err := uc.jiraRepo.GetTransitions(ctx, issueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transitions: %w", err)
+	}
+
+	target := findTransition(transitions, transitionName)
+	if target == nil {
+		available := make([]string, 0, len(transitions))
+		for _, t := range transitions {
+			available = append(available, t.Name)
+		}
+		return nil, fmt.Errorf("transition %q not available for %s (available: %s)", transitionName, issueKey, strings.Join(available, ", "))
+	}
+
+	if err := uc.jiraRepo.DoTransition(ctx, issueKey, target.ID); err != nil {
+		return nil, fmt.Errorf("failed to transition issue: %w", err)
+	}
+
+	return target, nil
+}
+
+// findTransition은 전환 이름을 우선 비교하고, 없으면 대상 상태 이름으로 찾는다.
+func findTransition(transitions []domain.Transition, name string) *domain.Transition {
+	for i := range transitions {
+		if strings.EqualFold(strings.TrimSpace(transitions[i].Name), name) {
+			return &transitions[i]
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(strings.TrimSpace(transitions[i].ToStatus), name) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jira-ai-generator/internal/domain"
+	"jira-ai-generator/internal/mock"
+	"jira-ai-generator/internal/usecase"
+)
+
+func newTransitionMock(performed *string) *mock.JiraRepository {
+	return &mock.JiraRepository{
+		GetTransitionsFunc: func(_ context.Context, issueKey string) ([]domain.Transition, error) {
+			return []domain.Transition{
+				{ID: "11", Name: "Start Progress", ToStatus: "In Progress"},
+				{ID: "21", Name: "AI Plan Ready", ToStatus: "Plan Ready"},
+				{ID: "31", Name: "Send to review", ToStatus: "In Review"},
+			}, nil
+		},
+		DoTransitionFunc: func(_ context.Context, issueKey, transitionID string) error {
+			*performed = transitionID
+			return nil
+		},
+	}
+}
+
+func TestTransitionIssueUseCase_Execute_MatchesTransitionName(t *testing.T) {
+	var performed string
+	uc := usecase.NewTransitionIssueUseCase(newTransitionMock(&performed))
+
+	transition, err := uc.Execute(context.Background(), "TEST-1", "ai plan ready")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if transition.ID != "21" || performed != "21" {
+		t.Errorf("expected transition 21, got %+v (performed %s)", transition, performed)
+	}
+}
+
+func TestTransitionIssueUseCase_Execute_MatchesTargetStatus(t *testing.T) {
+	var performed string
+	uc := usecase.NewTransitionIssueUseCase(newTransitionMock(&performed))
+
+	if _, err := uc.Execute(context.Background(), "TEST-1", "In Review"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if performed != "31" {
+		t.Errorf("expected transition 31 via target status, got %s", performed)
+	}
+}
+
+func TestTransitionIssueUseCase_Execute_MissingTransition(t *testing.T) {
+	var performed string
+	uc := usecase.NewTransitionIssueUseCase(newTransitionMock(&performed))
+
+	if _, err := uc.Execute(context.Background(), "TEST-1", "Done"); err == nil {
+		t.Fatal("expected error for missing transition")
+	}
+	if performed != "" {
+		t.Errorf("expected no transition to be performed, got %s", performed)
+	}
+}
+
+func TestTransitionIssueUseCase_Execute_ForbiddenTransition(t *testing.T) {
+	var performed string
+	jira := newTransitionMock(&performed)
+	jira.DoTransitionFunc = func(_ context.Context, issueKey, transitionID string) error {
+		return errors.New("API error (status 403)")
+	}
+	uc := usecase.NewTransitionIssueUseCase(jira)
+
+	if _, err := uc.Execute(context.Background(), "TEST-1", "AI Plan Ready"); err == nil {
+		t.Fatal("expected error for forbidden transition")
+	}
+}